@@ -19,10 +19,29 @@ package v1alpha1
 import (
 	legacyconfigv1 "github.com/openshift/api/legacyconfig/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type SyncScope string
 type ObjectRefKind string
+type AzureMemberScope string
+type MissedRunPolicy string
+type GroupMergeStrategy string
+type UsernameCase string
+type DeletionPolicy string
+type EmptyGroupPolicy string
+type AzureServicePrincipalNameAttribute string
+type RoleRefKind string
+type GroupOutput string
+type PruneMode string
+type OwnershipConflictPolicy string
+type LogLevel string
+
+// +kubebuilder:validation:Enum=label;annotation
+type AttributeMappingTarget string
+
+// +kubebuilder:validation:Enum=security;mailEnabledSecurity;microsoft365;distribution
+type AzureGroupType string
 
 const (
 	OneSyncScope SyncScope = "one"
@@ -30,6 +49,82 @@ const (
 
 	ConfigMapObjectRefKind ObjectRefKind = "ConfigMap"
 	SecretMapObjectRefKind ObjectRefKind = "Secret"
+
+	DirectAzureMemberScope     AzureMemberScope = "direct"
+	TransitiveAzureMemberScope AzureMemberScope = "transitive"
+
+	AppIDAzureServicePrincipalNameAttribute       AzureServicePrincipalNameAttribute = "appId"
+	DisplayNameAzureServicePrincipalNameAttribute AzureServicePrincipalNameAttribute = "displayName"
+
+	SecurityAzureGroupType            AzureGroupType = "security"
+	MailEnabledSecurityAzureGroupType AzureGroupType = "mailEnabledSecurity"
+	Microsoft365AzureGroupType        AzureGroupType = "microsoft365"
+	DistributionAzureGroupType        AzureGroupType = "distribution"
+
+	RunOnceMissedRunPolicy MissedRunPolicy = "runOnce"
+	SkipMissedRunPolicy    MissedRunPolicy = "skip"
+
+	UnionGroupMergeStrategy    GroupMergeStrategy = "union"
+	PriorityGroupMergeStrategy GroupMergeStrategy = "priority"
+	ErrorGroupMergeStrategy    GroupMergeStrategy = "error"
+
+	LowerUsernameCase    UsernameCase = "lower"
+	UpperUsernameCase    UsernameCase = "upper"
+	PreserveUsernameCase UsernameCase = "preserve"
+
+	DeleteDeletionPolicy DeletionPolicy = "Delete"
+	RetainDeletionPolicy DeletionPolicy = "Retain"
+
+	GroupGroupOutput     GroupOutput = "Group"
+	ConfigMapGroupOutput GroupOutput = "ConfigMap"
+
+	DeletePruneMode     PruneMode = "delete"
+	QuarantinePruneMode PruneMode = "quarantine"
+
+	FirstOwnerWinsOwnershipConflictPolicy OwnershipConflictPolicy = "firstOwnerWins"
+	ErrorOwnershipConflictPolicy          OwnershipConflictPolicy = "error"
+
+	ErrorLogLevel LogLevel = "error"
+	InfoLogLevel  LogLevel = "info"
+	DebugLogLevel LogLevel = "debug"
+
+	CreateEmptyGroupPolicy EmptyGroupPolicy = "create"
+	SkipEmptyGroupPolicy   EmptyGroupPolicy = "skip"
+	PruneEmptyGroupPolicy  EmptyGroupPolicy = "prune"
+
+	RoleRoleRefKind        RoleRefKind = "Role"
+	ClusterRoleRoleRefKind RoleRefKind = "ClusterRole"
+
+	LabelAttributeMappingTarget      AttributeMappingTarget = "label"
+	AnnotationAttributeMappingTarget AttributeMappingTarget = "annotation"
+
+	ValidatedProviderCondition = "Validated"
+	BoundProviderCondition     = "Bound"
+	SyncedProviderCondition    = "Synced"
+	DegradedProviderCondition  = "Degraded"
+
+	OwnershipConflictProviderCondition = "OwnershipConflict"
+
+	DistributedClusterCondition = "Distributed"
+
+	SyncStaleCondition = "SyncStale"
+
+	ValidationSucceededReason    = "ValidationSucceeded"
+	ValidationFailedReason       = "ValidationFailed"
+	BindSucceededReason          = "BindSucceeded"
+	BindFailedReason             = "BindFailed"
+	SyncSucceededReason          = "SyncSucceeded"
+	SyncFailedReason             = "SyncFailed"
+	AsExpectedReason             = "AsExpected"
+	PruneThresholdExceededReason = "PruneThresholdExceeded"
+	GroupConflictReason          = "GroupConflict"
+	DistributionSucceededReason  = "DistributionSucceeded"
+	DistributionFailedReason     = "DistributionFailed"
+	OwnershipConflictReason      = "OwnershipConflict"
+	NoOwnershipConflictReason    = "NoOwnershipConflict"
+	SyncOverdueReason            = "SyncOverdue"
+	SyncCurrentReason            = "SyncCurrent"
+	InvalidScheduleReason        = "InvalidSchedule"
 )
 
 // GroupSyncSpec defines the desired state of GroupSync
@@ -46,6 +141,255 @@ type GroupSyncSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Optional
 	Schedule string `json:"schedule,omitempty"`
+
+	// MissedRunPolicy determines whether a single synchronization is performed or skipped when scheduled synchronizations were missed, such as while the operator was not running
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Missed Run Policy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=runOnce;skip
+	MissedRunPolicy MissedRunPolicy `json:"missedRunPolicy,omitempty"`
+
+	// Migration enables dual synchronization of multiple providers while migrating between identity providers
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Migration"
+	// +kubebuilder:validation:Optional
+	Migration *Migration `json:"migration,omitempty"`
+
+	// PruneMaxDelete is the maximum number of groups pruned for a provider in a single synchronization, either as an absolute number or as a percentage of the groups managed by the provider. Pruning is skipped and the provider is marked as degraded when exceeded
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune Max Delete",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XIntOrString
+	PruneMaxDelete *intstr.IntOrString `json:"pruneMaxDelete,omitempty"`
+
+	// RetryInterval is the time waited before retrying a failed synchronization. The time waited doubles after each consecutive failure up to the max retry interval. Failed synchronizations are retried by the rate limiter of the operator when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retry Interval",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
+
+	// MaxRetryInterval is the maximum time waited before retrying a failed synchronization when a retry interval is set. Default is 1h
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Retry Interval",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	MaxRetryInterval *metav1.Duration `json:"maxRetryInterval,omitempty"`
+
+	// PruneGracePeriod is the time groups no longer returned by a provider are kept before being pruned. Groups returned again by the provider within the grace period are kept
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune Grace Period",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	PruneGracePeriod *metav1.Duration `json:"pruneGracePeriod,omitempty"`
+
+	// PruneMode determines whether pruned groups are deleted (delete) or quarantined (quarantine). Quarantined groups have their users removed and are labeled as orphaned, preserving the RoleBindings referencing them. Default is delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune Mode"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=delete;quarantine
+	// +kubebuilder:default:=delete
+	PruneMode PruneMode `json:"pruneMode,omitempty"`
+
+	// DryRun retrieves groups from the providers and reports the groups that would be created, updated and pruned without making any changes
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Dry Run",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DeletionPolicy determines whether the groups synchronized by the providers are deleted (Delete) or kept (Retain) when the GroupSync is deleted. Default is Retain
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Deletion Policy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default:=Retain
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// MembershipChangesUserLimit is the maximum number of added and removed users listed for each group in the membership changes reported in the status. Users are not listed when 0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Membership Changes User Limit",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MembershipChangesUserLimit int `json:"membershipChangesUserLimit,omitempty"`
+
+	// GroupMergeStrategy determines how groups with the same name returned by multiple providers are handled. Groups are either merged into a single group containing the users of all providers (union), taken from the provider declared first (priority) or rejected (error). Default is priority
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Merge Strategy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=union;priority;error
+	GroupMergeStrategy GroupMergeStrategy `json:"groupMergeStrategy,omitempty"`
+
+	// OwnershipConflictPolicy determines how groups returned by a provider that are already synchronized by another GroupSync or provider are handled. Groups are either left to their first owner (firstOwnerWins) or fail the synchronization of the provider (error). Conflicts are recorded in the OwnershipConflict condition of the provider. Default is firstOwnerWins
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ownership Conflict Policy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=firstOwnerWins;error
+	OwnershipConflictPolicy OwnershipConflictPolicy `json:"ownershipConflictPolicy,omitempty"`
+
+	// RoleBindings bind a Role or ClusterRole to the synchronized groups in namespaces. The RoleBindings are maintained by the operator and deleted when no longer specified
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Role Bindings"
+	// +kubebuilder:validation:Optional
+	RoleBindings []GroupRoleBinding `json:"roleBindings,omitempty"`
+
+	// RoleBindingsOnly maintains the RoleBindings of the synchronized groups without writing the groups, such as when the groups of users are provided by the identity provider of the cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Role Bindings Only",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	RoleBindingsOnly bool `json:"roleBindingsOnly,omitempty"`
+
+	// RoleMappings map the synchronized groups to ClusterRoles. A ClusterRoleBinding binding each ClusterRole to the groups mapped to it is maintained by the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Role Mappings"
+	// +kubebuilder:validation:Optional
+	RoleMappings []RoleMapping `json:"roleMappings,omitempty"`
+
+	// GroupNamespaces creates a namespace for each of the synchronized groups in which the group is bound to a ClusterRole
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Namespaces"
+	// +kubebuilder:validation:Optional
+	GroupNamespaces *GroupNamespaces `json:"groupNamespaces,omitempty"`
+
+	// Clusters are remote clusters to which the groups synchronized by the GroupSync are distributed
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Clusters"
+	// +kubebuilder:validation:Optional
+	Clusters []RemoteCluster `json:"clusters,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Output determines whether the synchronized groups are written as OpenShift Groups (Group) or to a ConfigMap in the namespace of the GroupSync (ConfigMap), which does not require the user.openshift.io API. Default is Group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Output"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Group;ConfigMap
+	// +kubebuilder:default:=Group
+	Output GroupOutput `json:"output,omitempty"`
+}
+
+// RemoteCluster represents a remote cluster to which the synchronized groups are distributed
+// +k8s:openapi-gen=true
+type RemoteCluster struct {
+	// Name is the name identifying the remote cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// KubeConfigSecret is a reference to a secret containing the kubeconfig used to access the remote cluster. The kubeconfig is read from the kubeconfig key unless another key is specified
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="KubeConfig Secret"
+	// +kubebuilder:validation:Required
+	KubeConfigSecret *ObjectRef `json:"kubeConfigSecret"`
+}
+
+// GroupNamespaces represents the configuration of the namespaces created for synchronized groups
+// +k8s:openapi-gen=true
+type GroupNamespaces struct {
+	// NameTemplate is a Go template rendering the name of the namespace of a group. The template has access to the .Group name and the .Provider name. Sprig functions are available. Default is {{ .Group }}
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name Template",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	NameTemplate string `json:"nameTemplate,omitempty"`
+
+	// Groups is a list of names, glob patterns or regular expressions matching the synchronized groups for which namespaces are created. Namespaces are created for all synchronized groups when not specified
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Groups []string `json:"groups,omitempty"`
+
+	// ClusterRole is the name of the ClusterRole bound to the group in its namespace. Default is admin
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ClusterRole",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=admin
+	ClusterRole string `json:"clusterRole,omitempty"`
+
+	// Prune deletes the namespaces created for groups that are no longer synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Prune bool `json:"prune,omitempty"`
+
+	// Hierarchy creates the namespaces of groups with a parent group as Hierarchical Namespace Controller (HNC) subnamespaces of the namespace of the parent group using SubnamespaceAnchors. Requires HNC to be installed
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hierarchy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Hierarchy bool `json:"hierarchy,omitempty"`
+}
+
+// UserProvisioning represents the configuration of the Users created for the members of synchronized groups
+// +k8s:openapi-gen=true
+type UserProvisioning struct {
+	// IdentityProvider is the name of the OAuth identity provider of the Identities created and linked to the Users. The name of the User is used as the user name of the identity provider. Identities are not created when not specified
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Identity Provider",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	IdentityProvider string `json:"identityProvider,omitempty"`
+}
+
+// RateLimit represents the limit of the requests issued to a provider
+// +k8s:openapi-gen=true
+type RateLimit struct {
+	// RequestsPerSecond is the maximum sustained number of requests issued to the provider per second
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Requests Per Second",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond int32 `json:"requestsPerSecond"`
+
+	// Burst is the maximum number of requests issued to the provider at once before being limited to the requests per second. Default is the requests per second
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Burst",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// RoleMapping represents the mapping of synchronized groups to a ClusterRole
+// +k8s:openapi-gen=true
+type RoleMapping struct {
+	// Groups is a list of names, glob patterns or regular expressions matching the synchronized groups mapped to the ClusterRole
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Groups []string `json:"groups"`
+
+	// ClusterRole is the name of the ClusterRole bound to the groups
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ClusterRole",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	ClusterRole string `json:"clusterRole"`
+}
+
+// GroupRoleBinding represents the RoleBindings binding a Role or ClusterRole to synchronized groups in namespaces
+// +k8s:openapi-gen=true
+type GroupRoleBinding struct {
+	// Name is the name of the RoleBinding maintained in each of the namespaces
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespaces is the list of namespaces in which the RoleBinding is maintained
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Namespaces",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Namespaces []string `json:"namespaces"`
+
+	// RoleRef references the Role or ClusterRole bound to the groups
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Role Reference"
+	// +kubebuilder:validation:Required
+	RoleRef GroupRoleRef `json:"roleRef"`
+
+	// Groups is a list of names, glob patterns or regular expressions matching the synchronized groups bound to the role. All synchronized groups are bound when not specified
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Groups []string `json:"groups,omitempty"`
+
+	// Providers restricts the groups bound to the role to those synchronized by the named providers. Groups of every provider are bound when not specified
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Providers",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Providers []string `json:"providers,omitempty"`
+}
+
+// GroupRoleRef represents a reference to the Role or ClusterRole bound to synchronized groups
+// +k8s:openapi-gen=true
+type GroupRoleRef struct {
+	// Kind of the role, either Role or ClusterRole. Default is ClusterRole
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kind"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Role;ClusterRole
+	// +kubebuilder:default:=ClusterRole
+	Kind RoleRefKind `json:"kind,omitempty"`
+
+	// Name of the role
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+}
+
+// Migration represents the configuration for migrating between providers
+// +k8s:openapi-gen=true
+type Migration struct {
+	// Providers is the list of names of the providers participating in the migration
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Migration Providers",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=2
+	Providers []string `json:"providers"`
+
+	// SourceOfTruth is the name of the provider whose groups are written. Groups from the remaining migration providers are only compared
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Source of Truth",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	SourceOfTruth string `json:"sourceOfTruth"`
 }
 
 // GroupSyncStatus defines the observed state of GroupSync
@@ -56,49 +400,546 @@ type GroupSyncStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
-	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions",xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// LastSyncSuccessTime represents the time last synchronization completed successfully
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Sync Success Time"
+	LastSyncSuccessTime *metav1.Time `json:"lastSyncSuccessTime,omitempty"`
+
+	// ObservedGeneration represents the generation of the GroupSync last synchronized successfully
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// MissedRuns represents the total number of scheduled synchronizations that were missed
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Missed Runs"
+	MissedRuns int64 `json:"missedRuns,omitempty"`
+
+	// LastMissedRunTime represents the time missed scheduled synchronizations were last recorded
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Missed Run Time"
+	LastMissedRunTime *metav1.Time `json:"lastMissedRunTime,omitempty"`
+
+	// RetryCount represents the number of consecutive failed synchronizations retried using the retry interval
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Retry Count"
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// NextRetryTime represents the time the last failed synchronization will be retried
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Next Retry Time"
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// Migration represents the differences between the source of truth and the remaining migration providers
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Migration"
+	Migration *MigrationStatus `json:"migration,omitempty"`
+
+	// Providers represents the state of each of the providers
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Providers"
+	Providers []ProviderStatus `json:"providers,omitempty"`
+
+	// DryRun represents the changes that the last dry run would have made
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Dry Run"
+	DryRun *DryRunStatus `json:"dryRun,omitempty"`
+
+	// MembershipChanges represents the groups whose members were changed by the last synchronization. At most 100 groups are listed
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Membership Changes"
+	MembershipChanges []GroupMembershipChange `json:"membershipChanges,omitempty"`
+
+	// Clusters represents the state of the distribution of the groups to each of the remote clusters
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Clusters"
+	Clusters []ClusterStatus `json:"clusters,omitempty"`
+}
+
+// ClusterStatus represents the state of the distribution of the groups to a remote cluster
+// +k8s:openapi-gen=true
+type ClusterStatus struct {
+	// Name is the name of the remote cluster
+	Name string `json:"name"`
+
+	// Conditions represent whether the groups were distributed to the remote cluster
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Groups is the number of groups distributed to the remote cluster by the last distribution
+	// +kubebuilder:validation:Optional
+	Groups int `json:"groups,omitempty"`
+
+	// LastSyncSuccessTime represents the time the groups were last distributed successfully to the remote cluster
+	// +kubebuilder:validation:Optional
+	LastSyncSuccessTime *metav1.Time `json:"lastSyncSuccessTime,omitempty"`
+}
+
+// GroupMembershipChange represents the changes made to the members of a group by a synchronization
+// +k8s:openapi-gen=true
+type GroupMembershipChange struct {
+	// Name is the name of the group
+	Name string `json:"name"`
+
+	// Provider is the name of the provider that synchronized the group
+	Provider string `json:"provider"`
+
+	// Added is the number of users added to the group
+	Added int `json:"added"`
+
+	// Removed is the number of users removed from the group
+	Removed int `json:"removed"`
+
+	// AddedUsers are the users added to the group, limited by the membership changes user limit
+	// +kubebuilder:validation:Optional
+	AddedUsers []string `json:"addedUsers,omitempty"`
+
+	// RemovedUsers are the users removed from the group, limited by the membership changes user limit
+	// +kubebuilder:validation:Optional
+	RemovedUsers []string `json:"removedUsers,omitempty"`
+}
+
+// DryRunStatus represents the changes that a dry run would have made
+// +k8s:openapi-gen=true
+type DryRunStatus struct {
+	// Providers contains the changes that would have been made for each provider
+	// +kubebuilder:validation:Optional
+	Providers []DryRunProviderStatus `json:"providers,omitempty"`
+
+	// LastDryRunTime represents the time of the last dry run
+	// +kubebuilder:validation:Optional
+	LastDryRunTime *metav1.Time `json:"lastDryRunTime,omitempty"`
+}
+
+// DryRunProviderStatus represents the changes that a dry run would have made for a provider. At most 100 group names are listed for each change
+// +k8s:openapi-gen=true
+type DryRunProviderStatus struct {
+	// Name is the name of the provider
+	Name string `json:"name"`
+
+	// Created is the number of groups that would have been created
+	Created int `json:"created"`
+
+	// Updated is the number of groups that would have been updated
+	Updated int `json:"updated"`
+
+	// Pruned is the number of groups that would have been pruned
+	Pruned int `json:"pruned"`
+
+	// CreatedGroups are the names of the groups that would have been created
+	// +kubebuilder:validation:Optional
+	CreatedGroups []string `json:"createdGroups,omitempty"`
+
+	// UpdatedGroups are the names of the groups that would have been updated
+	// +kubebuilder:validation:Optional
+	UpdatedGroups []string `json:"updatedGroups,omitempty"`
+
+	// PrunedGroups are the names of the groups that would have been pruned
+	// +kubebuilder:validation:Optional
+	PrunedGroups []string `json:"prunedGroups,omitempty"`
+}
+
+// ProviderStatus represents the state of a provider
+// +k8s:openapi-gen=true
+type ProviderStatus struct {
+	// Name is the name of the provider
+	Name string `json:"name"`
+
+	// Conditions represent whether the provider was validated, bound and synchronized and whether it is degraded
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// LastSyncSuccessTime represents the time the provider was last synchronized successfully
+	// +kubebuilder:validation:Optional
+	LastSyncSuccessTime *metav1.Time `json:"lastSyncSuccessTime,omitempty"`
+
+	// LastConnectivityCheckTime represents the time the operator last checked that it could bind to the provider between synchronizations
+	// +kubebuilder:validation:Optional
+	LastConnectivityCheckTime *metav1.Time `json:"lastConnectivityCheckTime,omitempty"`
+}
+
+// MigrationStatus represents the outcome of comparing the providers participating in a migration
+// +k8s:openapi-gen=true
+type MigrationStatus struct {
+	// SourceOfTruth is the name of the provider whose groups were written
+	SourceOfTruth string `json:"sourceOfTruth"`
+
+	// Comparisons contains the differences between the source of truth and each of the remaining migration providers
+	// +kubebuilder:validation:Optional
+	Comparisons []ProviderComparison `json:"comparisons,omitempty"`
+
+	// LastComparisonTime represents the time the providers were last compared
+	// +kubebuilder:validation:Optional
+	LastComparisonTime *metav1.Time `json:"lastComparisonTime,omitempty"`
+}
+
+// ProviderComparison represents the differences between the source of truth and another provider
+// +k8s:openapi-gen=true
+type ProviderComparison struct {
+	// Provider is the name of the provider compared against the source of truth
+	Provider string `json:"provider"`
+
+	// MissingGroups are the groups found in the source of truth that were not returned by the provider
+	// +kubebuilder:validation:Optional
+	MissingGroups []string `json:"missingGroups,omitempty"`
+
+	// AdditionalGroups are the groups returned by the provider that are not found in the source of truth
+	// +kubebuilder:validation:Optional
+	AdditionalGroups []string `json:"additionalGroups,omitempty"`
+
+	// MembershipDifferences are the groups found in both providers whose members differ
+	// +kubebuilder:validation:Optional
+	MembershipDifferences []GroupMembershipDifference `json:"membershipDifferences,omitempty"`
+}
+
+// GroupMembershipDifference represents the membership differences for a single group
+// +k8s:openapi-gen=true
+type GroupMembershipDifference struct {
+	// Name is the name of the group
+	Name string `json:"name"`
+
+	// MissingUsers are the users found in the source of truth that were not returned by the provider
+	// +kubebuilder:validation:Optional
+	MissingUsers []string `json:"missingUsers,omitempty"`
+
+	// AdditionalUsers are the users returned by the provider that are not found in the source of truth
+	// +kubebuilder:validation:Optional
+	AdditionalUsers []string `json:"additionalUsers,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// GroupSync is the Schema for the groupsyncs API
+// +operator-sdk:csv:customresourcedefinitions:displayName="Group Sync"
+// +kubebuilder:resource:path=groupsyncs,scope=Namespaced
+// +k8s:openapi-gen=true
+type GroupSync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GroupSyncSpec   `json:"spec,omitempty"`
+	Status GroupSyncStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GroupSyncList contains a list of GroupSync
+// +k8s:openapi-gen=true
+type GroupSyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GroupSync `json:"items"`
+}
+
+// Provider represents the container for a single provider
+// +k8s:openapi-gen=true
+type Provider struct {
+	// Name represents the name of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name of the Provider"
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// MappingWebhook is an optional external service invoked to transform the groups returned by the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Mapping Webhook"
+	// +kubebuilder:validation:Optional
+	MappingWebhook *MappingWebhook `json:"mappingWebhook,omitempty"`
+
+	// Schedule represents a cron based configuration for synchronizing the provider independently of the schedule of the GroupSync
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Timeout is the maximum duration of validating the provider, binding to the provider and retrieving its groups. Requests in flight are cancelled and the synchronization of the provider fails once exceeded so that an unresponsive provider does not block the synchronization of other GroupSyncs
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// RateLimit limits the requests issued to the provider so that synchronizing many groups does not exceed the rate limits of the provider. Not supported by LDAP providers
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rate Limit"
+	// +kubebuilder:validation:Optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// PreserveUnmanagedUsers only adds and removes the users previously synchronized by the provider so that users added manually to the groups are kept
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Preserve Unmanaged Users",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	PreserveUnmanagedUsers bool `json:"preserveUnmanagedUsers,omitempty"`
+
+	// EmptyGroupPolicy determines how groups without users are handled. Empty groups are created and updated (create), are not created while existing groups are updated (skip) or are not created while existing groups are deleted (prune). Default is create
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Empty Group Policy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=create;skip;prune
+	EmptyGroupPolicy EmptyGroupPolicy `json:"emptyGroupPolicy,omitempty"`
+
+	// UserProvisioning creates the OpenShift Users, and optionally their Identities, of the members of the groups synchronized by the provider that do not exist yet
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="User Provisioning"
+	// +kubebuilder:validation:Optional
+	UserProvisioning *UserProvisioning `json:"userProvisioning,omitempty"`
+
+	// GroupFilterExpression is a CEL expression evaluated against each group of the provider. Only groups for which the expression evaluates to true are synchronized. The group is available as the group variable with the name, id, attributes and memberCount fields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Filter Expression",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	GroupFilterExpression string `json:"groupFilterExpression,omitempty"`
+
+	// UserFilterExpression is a CEL expression evaluated against each user of the groups of the provider. Only users for which the expression evaluates to true are synchronized. The user is available as the user variable with the name and group fields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="User Filter Expression",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	UserFilterExpression string `json:"userFilterExpression,omitempty"`
+
+	// MinGroupSize is the minimum number of users of the groups of the provider that are synchronized. Groups with fewer users are skipped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum Group Size",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MinGroupSize *int `json:"minGroupSize,omitempty"`
+
+	// MaxGroupSize is the maximum number of users of the groups of the provider that are synchronized. Groups with more users are skipped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Group Size",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxGroupSize *int `json:"maxGroupSize,omitempty"`
+
+	// GroupNamePrefix is prepended to the names of the groups of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Name Prefix",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	GroupNamePrefix string `json:"groupNamePrefix,omitempty"`
+
+	// GroupNameSuffix is appended to the names of the groups of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Name Suffix",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	GroupNameSuffix string `json:"groupNameSuffix,omitempty"`
+
+	// GroupNameTemplate is a Go template rendering the names of the groups of the provider. The template has access to the .Provider name and the .Group with the Name, ID and Attributes fields. Sprig functions are available
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Name Template",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	GroupNameTemplate string `json:"groupNameTemplate,omitempty"`
+
+	// GroupNameRewrites are regular expression based rules applied in order to the names of the groups of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Name Rewrites"
+	// +kubebuilder:validation:Optional
+	GroupNameRewrites []GroupNameRewrite `json:"groupNameRewrites,omitempty"`
+
+	// LowercaseGroupNames converts the names of the groups of the provider to lowercase after the group name rewrites are applied
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Lowercase Group Names",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	LowercaseGroupNames bool `json:"lowercaseGroupNames,omitempty"`
+
+	// GroupNameMappings maps the names or identifiers of groups of the provider to the names of the groups in the cluster. Mapped names are not transformed further
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Name Mappings"
+	// +kubebuilder:validation:Optional
+	GroupNameMappings map[string]string `json:"groupNameMappings,omitempty"`
+
+	// GroupNameMappingsRef is a reference to a ConfigMap or Secret whose entries map the names or identifiers of groups of the provider to the names of the groups in the cluster. Entries of groupNameMappings take precedence
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Containing the Group Name Mappings"
+	// +kubebuilder:validation:Optional
+	GroupNameMappingsRef *ObjectRef `json:"groupNameMappingsRef,omitempty"`
+
+	// UsernameCase converts the names of the users of the provider to lowercase (lower) or uppercase (upper), such as to match the names of the users logging in to the cluster. Default is preserve
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Username Case"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=lower;upper;preserve
+	UsernameCase UsernameCase `json:"usernameCase,omitempty"`
+
+	// UsernameRewrites are regular expression based rules applied in order to the names of the users of the provider before the username case is converted
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Username Rewrites"
+	// +kubebuilder:validation:Optional
+	UsernameRewrites []UsernameRewrite `json:"usernameRewrites,omitempty"`
+
+	// MetadataMappings set labels or annotations on the synchronized groups from the metadata of the groups in the provider, such as their description, email or path
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metadata Mappings"
+	// +kubebuilder:validation:Optional
+	MetadataMappings []MetadataMapping `json:"metadataMappings,omitempty"`
+
+	// HierarchyMetadata records the parent and child groups of the groups of the provider as annotations and their depth in the hierarchy of groups as a label
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hierarchy Metadata",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	HierarchyMetadata bool `json:"hierarchyMetadata,omitempty"`
+
+	// CredentialsSource loads the credentials of the provider from a source other than the credentials secret of the provider, such as mounted files, environment variables or HashiCorp Vault
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credentials Source"
+	// +kubebuilder:validation:Optional
+	CredentialsSource *CredentialsSource `json:"credentialsSource,omitempty"`
+
+	// CredentialsKeys maps the keys of the credentials expected by the provider, such as AZURE_TENANT_ID, to the keys of the credentials secret or credentials source containing their values, so that credentials provisioned using other naming conventions can be used without being copied
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credentials Keys"
+	// +kubebuilder:validation:Optional
+	CredentialsKeys map[string]string `json:"credentialsKeys,omitempty"`
+
+	// CaBundleConfigMapRef references a ConfigMap containing a bundle of CA certificates trusted when communicating with the provider in addition to the certificate referenced by the provider and the bundle configured for the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA Bundle ConfigMap"
+	// +kubebuilder:validation:Optional
+	CaBundleConfigMapRef *ConfigMapRef `json:"caBundleConfigMapRef,omitempty"`
+
+	// ClientCertificateSecretRef references a Secret of type kubernetes.io/tls containing the client certificate and key presented to providers requiring mutual TLS
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Certificate Secret"
+	// +kubebuilder:validation:Optional
+	ClientCertificateSecretRef *SecretRef `json:"clientCertificateSecretRef,omitempty"`
+
+	// LogLevel overrides the verbosity of the operator for the lines logged while synchronizing the provider, logging only errors (error), informational messages (info) or also debugging messages (debug)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Level"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=error;info;debug
+	LogLevel LogLevel `json:"logLevel,omitempty"`
+
+	*ProviderType `json:",inline"`
+}
+
+// CredentialsSource represents a source of the credentials of a provider other than a Secret. The credentials are made of the same keys as the credentials secret of the provider. Exactly one source must be specified
+// +k8s:openapi-gen=true
+type CredentialsSource struct {
+	// File loads the credentials from the files of a directory mounted in the operator, such as by the Secrets Store CSI driver
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="File Credentials Source"
+	// +kubebuilder:validation:Optional
+	File *FileCredentialsSource `json:"file,omitempty"`
+
+	// Env maps the keys of the credentials to the names of environment variables of the operator containing their values
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Environment Variable Credentials Source"
+	// +kubebuilder:validation:Optional
+	Env map[string]string `json:"env,omitempty"`
+
+	// Vault loads the credentials from a path of HashiCorp Vault
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Vault Credentials Source"
+	// +kubebuilder:validation:Optional
+	Vault *VaultCredentialsSource `json:"vault,omitempty"`
+}
+
+// FileCredentialsSource represents credentials stored in a directory containing a file for each key
+// +k8s:openapi-gen=true
+type FileCredentialsSource struct {
+	// Path is the directory containing the files. The name of each file is the key of the credential it contains
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Path",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+}
+
+// VaultCredentialsSource represents credentials stored in HashiCorp Vault. The operator authenticates using the Kubernetes auth method with the token of its service account
+// +k8s:openapi-gen=true
+type VaultCredentialsSource struct {
+	// Address is the URL of the Vault server
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Address",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+
+	// Path is the path of the secret containing the credentials, such as secret/data/keycloak for version 2 of the KV secrets engine
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Path",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	// Role is the role of the Kubernetes auth method used to authenticate
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Role",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Role string `json:"role"`
+
+	// AuthPath is the path at which the Kubernetes auth method is enabled. Default is kubernetes
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Auth Path",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	AuthPath string `json:"authPath,omitempty"`
+
+	// Ca is a reference to a Secret or ConfigMap containing a CA certificate to communicate to Vault
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Containing the CA Certificate",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Optional
+	Ca *ObjectRef `json:"ca,omitempty"`
+}
+
+// MappingWebhook represents an external service that transforms group names and memberships
+// +k8s:openapi-gen=true
+type MappingWebhook struct {
+	// URL is the location of the mapping webhook
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Mapping Webhook URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// Ca is a reference to a Secret or ConfigMap containing a CA certificate to communicate to the mapping webhook
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Containing the CA Certificate",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Optional
+	Ca *ObjectRef `json:"ca,omitempty"`
+
+	// CredentialsSecret is a reference to a secret containing a bearer token used to authenticate to the mapping webhook
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Credentials",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *ObjectRef `json:"credentialsSecret,omitempty"`
+
+	// Insecure specifies whether to allow for unverified certificates to be used when communicating to the mapping webhook
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ignore SSL Verification",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
 
-	// LastSyncSuccessTime represents the time last synchronization completed successfully
+	// Timeout is the maximum duration to wait for a response from the mapping webhook. Default is 30s
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Optional
-	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Sync Success Time"
-	LastSyncSuccessTime *metav1.Time `json:"lastSyncSuccessTime,omitempty"`
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
-// +kubebuilder:object:root=true
-// +kubebuilder:subresource:status
-
-// GroupSync is the Schema for the groupsyncs API
-// +operator-sdk:csv:customresourcedefinitions:displayName="Group Sync"
-// +kubebuilder:resource:path=groupsyncs,scope=Namespaced
+// GroupNameRewrite represents a rule replacing the matches of a regular expression in the names of groups
 // +k8s:openapi-gen=true
-type GroupSync struct {
-	metav1.TypeMeta   `json:",inline"`
-	metav1.ObjectMeta `json:"metadata,omitempty"`
+type GroupNameRewrite struct {
+	// Pattern is the regular expression matched against the group name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pattern",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Pattern string `json:"pattern"`
 
-	Spec   GroupSyncSpec   `json:"spec,omitempty"`
-	Status GroupSyncStatus `json:"status,omitempty"`
+	// Replacement replaces each match of the pattern. Submatches can be referenced using $1 or ${name}
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Replacement",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Replacement string `json:"replacement,omitempty"`
 }
 
-// +kubebuilder:object:root=true
-
-// GroupSyncList contains a list of GroupSync
+// UsernameRewrite represents a rule replacing the matches of a regular expression in the names of users
 // +k8s:openapi-gen=true
-type GroupSyncList struct {
-	metav1.TypeMeta `json:",inline"`
-	metav1.ListMeta `json:"metadata,omitempty"`
-	Items           []GroupSync `json:"items"`
+type UsernameRewrite struct {
+	// Pattern is the regular expression matched against the username
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pattern",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Pattern string `json:"pattern"`
+
+	// Replacement replaces each match of the pattern. Submatches can be referenced using $1 or ${name}
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Replacement",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Replacement string `json:"replacement,omitempty"`
 }
 
-// Provider represents the container for a single provider
+// MetadataMapping represents the mapping of a metadata field of the groups of a provider to a label or annotation
 // +k8s:openapi-gen=true
-type Provider struct {
-	// Name represents the name of the provider
-	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name of the Provider"
+type MetadataMapping struct {
+	// Source is the name of the metadata field exposed by the provider, such as description, email, path or the name of a custom attribute
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Source",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Source string `json:"source"`
+
+	// Name is the key of the label or annotation
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
 
-	*ProviderType `json:",inline"`
+	// Target specifies whether the metadata field is set as a label or annotation
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="annotation"
+	Target AttributeMappingTarget `json:"target,omitempty"`
+}
+
+// Proxy represents the configuration of an HTTP or HTTPS proxy
+// +k8s:openapi-gen=true
+type Proxy struct {
+	// URL is the location of the proxy
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// CredentialsSecret is a reference to a secret containing the username and password used to authenticate to the proxy
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Credentials",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *ObjectRef `json:"credentialsSecret,omitempty"`
 }
 
 // ProviderType represents the provider to synchronize against
@@ -133,12 +974,32 @@ type ProviderType struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Okta Provider"
 	// +kubebuilder:validation:Optional
 	Okta *OktaProvider `json:"okta,omitempty"`
+
+	// Plugin represents an out-of-tree provider implemented by a plugin serving the provider plugin gRPC protocol
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Plugin Provider"
+	// +kubebuilder:validation:Optional
+	Plugin *PluginProvider `json:"plugin,omitempty"`
+
+	// Exec represents an out-of-tree provider implemented by a command printing the groups of the provider in JSON
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exec Provider"
+	// +kubebuilder:validation:Optional
+	Exec *ExecProvider `json:"exec,omitempty"`
+
+	// Fake represents a provider returning the groups specified inline, intended for testing the operator without a group source
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Fake Provider"
+	// +kubebuilder:validation:Optional
+	Fake *FakeProvider `json:"fake,omitempty"`
 }
 
 // KeycloakProvider represents integration with Keycloak
 // +k8s:openapi-gen=true
 type KeycloakProvider struct {
 
+	// AttributeMappings represents a list of group attributes to set as labels or annotations on the synchronized groups
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Attribute Mappings"
+	// +kubebuilder:validation:Optional
+	AttributeMappings []AttributeMapping `json:"attributeMappings,omitempty"`
+
 	// Ca is a reference to a Secret or ConfigMap containing a CA certificate to communicate to the Keycloak server
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Containing the CA Certificate",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
 	// +kubebuilder:validation:Optional
@@ -150,16 +1011,46 @@ type KeycloakProvider struct {
 	// Deprecated: Use Ca instead.
 	CaSecret *ObjectRef `json:"caSecret,omitempty"`
 
-	// CredentialsSecret is a reference to a secret containing authentication details for the Keycloak server
+	// ClientRoles represents a list of client IDs whose roles will be synchronized as groups named <client>-<role>
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Clients Whose Roles to Synchronize"
+	// +kubebuilder:validation:Optional
+	ClientRoles []string `json:"clientRoles,omitempty"`
+
+	// ContextPath is the context path of the Keycloak server, such as an empty path for Keycloak 17 and later which no longer serve Keycloak under /auth by default. Default is /auth
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Context Path",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	ContextPath *string `json:"contextPath,omitempty"`
+
+	// CredentialsSecret is a reference to a secret containing authentication details for the Keycloak server. Required unless the credentials source of the provider is specified
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Credentials",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
-	// +kubebuilder:validation:Required
-	CredentialsSecret *ObjectRef `json:"credentialsSecret"`
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *ObjectRef `json:"credentialsSecret,omitempty"`
 
-	// Groups represents a filtered list of groups to synchronize
+	// ExcludedGroups represents a list of groups or regular expressions matching groups, including subgroups, that will not be synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups to Exclude"
+	// +kubebuilder:validation:Optional
+	ExcludedGroups []string `json:"excludedGroups,omitempty"`
+
+	// ExcludeDisabledUsers specifies whether users who are not enabled should be excluded from group membership
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exclude Disabled Users",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	ExcludeDisabledUsers bool `json:"excludeDisabledUsers,omitempty"`
+
+	// ExcludeServiceAccounts specifies whether the service account users of clients should be excluded from group membership. Default is true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exclude Service Accounts",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	ExcludeServiceAccounts *bool `json:"excludeServiceAccounts,omitempty"`
+
+	// Groups represents a filtered list of groups or regular expressions matching groups to synchronize
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups to Synchronize"
 	// +kubebuilder:validation:Optional
 	Groups []string `json:"groups,omitempty"`
 
+	// GroupPathSeparator is the separator used to join the elements of the path of a group when useGroupPath is enabled
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Path Separator",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	GroupPathSeparator *string `json:"groupPathSeparator,omitempty"`
+
 	// Insecure specifies whether to allow for unverified certificates to be used when communicating to Keycloak
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ignore SSL Verification",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	// +kubebuilder:validation:Optional
@@ -170,10 +1061,37 @@ type KeycloakProvider struct {
 	// +kubebuilder:validation:Optional
 	LoginRealm string `json:"loginRealm,omitempty"`
 
+	// PerformanceMode specifies whether brief representations of groups are retrieved so that only groups that are allowed to be
+	// synchronized are fully retrieved. Groups without members or subgroups are not synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Performance Mode",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	PerformanceMode bool `json:"performanceMode,omitempty"`
+
 	// Realm is the realm containing the groups to synchronize against
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Realm to Synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
-	// +kubebuilder:validation:Required
-	Realm string `json:"realm"`
+	// +kubebuilder:validation:Optional
+	Realm string `json:"realm,omitempty"`
+
+	// Realms represents a list of realms containing the groups to synchronize against
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Realms to Synchronize"
+	// +kubebuilder:validation:Optional
+	Realms []KeycloakRealm `json:"realms,omitempty"`
+
+	// RetryCount is the number of times requests to Keycloak are retried after a connection error or a server error. Requests are not retried by default
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retry Count",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	RetryCount *int `json:"retryCount,omitempty"`
+
+	// RetryMaxWaitTime is the maximum duration to wait between retries. Default is 2s
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retry Maximum Wait Time",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	RetryMaxWaitTime *metav1.Duration `json:"retryMaxWaitTime,omitempty"`
+
+	// RetryWaitTime is the initial duration to wait between retries, which increases exponentially with each retry. Default is 100ms
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retry Wait Time",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	RetryWaitTime *metav1.Duration `json:"retryWaitTime,omitempty"`
 
 	// Scope represents the depth for which groups will be synchronized
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Scope to synchronize against"
@@ -181,6 +1099,36 @@ type KeycloakProvider struct {
 	// +kubebuilder:validation:Enum=one;sub
 	Scope SyncScope `json:"scope,omitempty"`
 
+	// SyncGroups specifies whether the groups of the realm are synchronized. Default is true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Synchronize Groups",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	SyncGroups *bool `json:"syncGroups,omitempty"`
+
+	// SyncOrganizations specifies whether organizations are synchronized as groups containing the members of each organization. Requires Keycloak 26 or later
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Synchronize Organizations",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	SyncOrganizations bool `json:"syncOrganizations,omitempty"`
+
+	// SyncRealmRoles specifies whether realm roles are synchronized as groups containing the users granted each role
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Synchronize Realm Roles",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	SyncRealmRoles bool `json:"syncRealmRoles,omitempty"`
+
+	// UserNameAttributes are the fields to consider on the User object containing the username. Options are username, email or the name of a custom user attribute
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keycloak UserName Attributes",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	UserNameAttributes *[]string `json:"userNameAttributes,omitempty"`
+
+	// UseGroupPath specifies whether groups are named using the full path of the group, such as platform-team-admins for /platform/team/admins
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Use Group Path",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	UseGroupPath bool `json:"useGroupPath,omitempty"`
+
+	// Timeout is the maximum duration to wait for a response to each request to Keycloak
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
 	// URL is the location of the Keycloak server
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Keycloak URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Required
@@ -192,6 +1140,42 @@ type KeycloakProvider struct {
 	Prune bool `json:"prune"`
 }
 
+// KeycloakRealm represents a Keycloak realm containing groups to synchronize
+// +k8s:openapi-gen=true
+type KeycloakRealm struct {
+
+	// Name is the name of the realm
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Prefix is prepended to the names of groups synchronized from the realm
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Name Prefix",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// AttributeMapping represents the mapping of a group attribute to a label or annotation
+// +k8s:openapi-gen=true
+type AttributeMapping struct {
+
+	// Attribute is the name of the group attribute
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Attribute",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Attribute string `json:"attribute"`
+
+	// Name is the key of the label or annotation
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Target specifies whether the attribute is set as a label or annotation
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="annotation"
+	Target AttributeMappingTarget `json:"target,omitempty"`
+}
+
 // GitHubProvider represents integration with GitHub
 // +k8s:openapi-gen=true
 type GitHubProvider struct {
@@ -207,10 +1191,10 @@ type GitHubProvider struct {
 	// Deprecated: Use Ca instead.
 	CaSecret *ObjectRef `json:"caSecret,omitempty"`
 
-	// CredentialsSecret is a reference to a secret containing authentication details for the GitHub server
+	// CredentialsSecret is a reference to a secret containing authentication details for the GitHub server. Required unless the credentials source of the provider is specified
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Credentials",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
-	// +kubebuilder:validation:Required
-	CredentialsSecret *ObjectRef `json:"credentialsSecret"`
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *ObjectRef `json:"credentialsSecret,omitempty"`
 
 	// Insecure specifies whether to allow for unverified certificates to be used when communicating to GitHab
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ignore SSL Verification",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
@@ -222,11 +1206,16 @@ type GitHubProvider struct {
 	// +kubebuilder:validation:Optional
 	Organization string `json:"organization,omitempty"`
 
-	// Teams represents a filtered list of teams to synchronize
+	// Teams represents a filtered list of teams, glob patterns or regular expressions matching teams to synchronize
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Teams to Synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Optional
 	Teams []string `json:"teams,omitempty"`
 
+	// ExcludedGroups represents a list of teams or regular expressions matching teams that will not be synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Teams to Exclude"
+	// +kubebuilder:validation:Optional
+	ExcludedGroups []string `json:"excludedGroups,omitempty"`
+
 	// Map users by SCIM Id. This will usually match your IDP id, like UPN when using AAD.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Map users by SCIM-ID",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Optional
@@ -265,21 +1254,26 @@ type GitLabProvider struct {
 	// Deprecated: Use Ca instead.
 	CaSecret *ObjectRef `json:"caSecret,omitempty"`
 
-	// CredentialsSecret is a reference to a secret containing authentication details for the GitLab server
+	// CredentialsSecret is a reference to a secret containing authentication details for the GitLab server. Required unless the credentials source of the provider is specified
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Credentials",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
-	// +kubebuilder:validation:Required
-	CredentialsSecret *ObjectRef `json:"credentialsSecret"`
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *ObjectRef `json:"credentialsSecret,omitempty"`
 
 	// Insecure specifies whether to allow for unverified certificates to be used when communicating to GitLab
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ignore SSL Verification",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	// +kubebuilder:validation:Optional
 	Insecure bool `json:"insecure,omitempty"`
 
-	// Groups represents a filtered list of groups to synchronize
+	// Groups represents a filtered list of groups, glob patterns or regular expressions matching groups to synchronize
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups to Synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Optional
 	Groups []string `json:"groups,omitempty"`
 
+	// ExcludedGroups represents a list of groups or regular expressions matching groups that will not be synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups to Exclude"
+	// +kubebuilder:validation:Optional
+	ExcludedGroups []string `json:"excludedGroups,omitempty"`
+
 	// URL is the location of the GitLab server
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GitLab URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Optional
@@ -351,6 +1345,11 @@ type LdapProvider struct {
 	// +kubebuilder:validation:Optional
 	Blacklist *[]string `json:"blacklist,omitempty"`
 
+	// ExcludedGroups represents a list of names of OpenShift groups or regular expressions matching them that will not be synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups to Exclude"
+	// +kubebuilder:validation:Optional
+	ExcludedGroups []string `json:"excludedGroups,omitempty"`
+
 	// Prune Whether to prune groups that are no longer in LDAP. Default is false
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	// +kubebuilder:validation:Optional
@@ -365,10 +1364,20 @@ type AzureProvider struct {
 	// +kubebuilder:validation:Optional
 	BaseGroups []string `json:"baseGroups,omitempty"`
 
-	// CredentialsSecret is a reference to a secret containing authentication details for communicating to Azure
+	// AdministrativeUnits restricts the groups synchronized to those that are members of the specified Administrative Unit IDs
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Administrative Units",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	AdministrativeUnits []string `json:"administrativeUnits,omitempty"`
+
+	// GroupNamePrefixes restricts the groups synchronized to those whose display name starts with one of the specified prefixes
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Name Prefixes",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	GroupNamePrefixes []string `json:"groupNamePrefixes,omitempty"`
+
+	// CredentialsSecret is a reference to a secret containing authentication details for communicating to Azure. Required unless the credentials source of the provider is specified
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Credentials",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
-	// +kubebuilder:validation:Required
-	CredentialsSecret *ObjectRef `json:"credentialsSecret"`
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *ObjectRef `json:"credentialsSecret,omitempty"`
 
 	// Filter allows for limiting the results from the groups response using the Filter feature of the Azure Graph API
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Filter",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
@@ -380,21 +1389,89 @@ type AzureProvider struct {
 	// +kubebuilder:validation:Optional
 	Insecure bool `json:"insecure,omitempty"`
 
-	// Groups represents a filtered list of groups to synchronize
+	// Groups represents a filtered list of groups, glob patterns or regular expressions matching groups to synchronize
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups to Synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Optional
 	Groups []string `json:"groups,omitempty"`
 
+	// ExcludedGroups represents a list of groups or regular expressions matching groups that will not be synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups to Exclude"
+	// +kubebuilder:validation:Optional
+	ExcludedGroups []string `json:"excludedGroups,omitempty"`
+
 	// AuthorityHost is the location of the Azure Active Directory endpoint
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Azure URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Optional
 	AuthorityHost *string `json:"authorityHost,omitempty"`
 
+	// GraphEndpoint is the location of the Microsoft Graph endpoint. Derived from the AuthorityHost when not specified
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Graph Endpoint",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	GraphEndpoint *string `json:"graphEndpoint,omitempty"`
+
 	// UserNameAttributes are the fields to consider on the User object containing the username
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Azure UserName Attributes",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Optional
 	UserNameAttributes *[]string `json:"userNameAttributes,omitempty"`
 
+	// GroupNameAttributes are the fields to consider on the Group object containing the group name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Azure Group Name Attributes",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	GroupNameAttributes *[]string `json:"groupNameAttributes,omitempty"`
+
+	// GroupTypes restricts the groups synchronized to the specified types of groups
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Types",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	GroupTypes []AzureGroupType `json:"groupTypes,omitempty"`
+
+	// ExcludeGuests specifies whether guest (B2B) users should be excluded from group membership
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exclude Guest Users",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	ExcludeGuests bool `json:"excludeGuests,omitempty"`
+
+	// ExcludeDisabledUsers specifies whether users whose account is not enabled should be excluded from group membership
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Exclude Disabled Users",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	ExcludeDisabledUsers bool `json:"excludeDisabledUsers,omitempty"`
+
+	// MemberScope determines whether only direct members or all transitive members of a group are synchronized. Default is transitive
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Member Scope"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=direct;transitive
+	MemberScope AzureMemberScope `json:"memberScope,omitempty"`
+
+	// MaxNestingDepth limits how many levels of nested groups are traversed when resolving transitive members
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Nesting Depth",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxNestingDepth *int `json:"maxNestingDepth,omitempty"`
+
+	// IncludeServicePrincipals specifies whether service principals should be included in group membership
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Include Service Principals",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	IncludeServicePrincipals bool `json:"includeServicePrincipals,omitempty"`
+
+	// ServicePrincipalNameAttribute is the field on the Service Principal object used as the username. Default is appId
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Principal Name Attribute"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=appId;displayName
+	ServicePrincipalNameAttribute AzureServicePrincipalNameAttribute `json:"servicePrincipalNameAttribute,omitempty"`
+
+	// IncludeDevices specifies whether devices should be included in group membership using their display name. Default is false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Include Devices",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	IncludeDevices bool `json:"includeDevices,omitempty"`
+
+	// Proxy is the HTTP or HTTPS proxy used to communicate with Azure
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Proxy"
+	// +kubebuilder:validation:Optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+
+	// SyncTimeout is the maximum duration of a synchronization after which requests to Azure are cancelled. Deprecated: use the timeout of the provider, which takes precedence when set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sync Timeout",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	SyncTimeout *metav1.Duration `json:"syncTimeout,omitempty"`
+
 	// Prune Whether to prune groups that are no longer in Azure. Default is false
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	// +kubebuilder:validation:Optional
@@ -404,14 +1481,18 @@ type AzureProvider struct {
 // OktaProvider represents integration with Okta
 // +k8s:openapi-gen=true
 type OktaProvider struct {
-	// CredentialsSecret is a reference to a secret containing authentication details for the Okta server
+	// CredentialsSecret is a reference to a secret containing authentication details for the Okta server. Required unless the credentials source of the provider is specified
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Credentials",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
-	// +kubebuilder:validation:Required
-	CredentialsSecret *ObjectRef `json:"credentialsSecret"`
-	// Groups represents a filtered list of groups to synchronize
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *ObjectRef `json:"credentialsSecret,omitempty"`
+	// Groups represents a filtered list of groups, glob patterns or regular expressions matching groups to synchronize
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups to Synchronize",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Optional
 	Groups []string `json:"groups,omitempty"`
+	// ExcludedGroups represents a list of groups or regular expressions matching groups that will not be synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups to Exclude"
+	// +kubebuilder:validation:Optional
+	ExcludedGroups []string `json:"excludedGroups,omitempty"`
 	// URL is the location of the Okta domain server
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Okta URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +kubebuilder:validation:Required
@@ -439,6 +1520,117 @@ type OktaProvider struct {
 	Prune bool `json:"prune"`
 }
 
+// ExecProvider represents integration with a group source implemented by a command of the operator image or of a volume mounted in the operator. The command receives the request of the provider plugin protocol on its standard input and prints the groups on its standard output
+// +k8s:openapi-gen=true
+type ExecProvider struct {
+	// Command is the absolute path of the command, which must be located in the exec provider directory of the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Command",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Command string `json:"command"`
+
+	// Args are the arguments of the command
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Arguments"
+	// +kubebuilder:validation:Optional
+	Args []string `json:"args,omitempty"`
+
+	// Env are the environment variables of the command. Commands do not inherit the environment of the operator, and variables controlling the dynamic loader or the shell, such as PATH, LD_PRELOAD or BASH_ENV, are rejected
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Environment Variables"
+	// +kubebuilder:validation:Optional
+	Env map[string]string `json:"env,omitempty"`
+
+	// CredentialsSecret is a reference to a secret whose entries are passed to the command as the credentials of the group source. Optional unless required by the command
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Credentials",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *ObjectRef `json:"credentialsSecret,omitempty"`
+
+	// Parameters are passed to the command to configure the groups it returns
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Parameters"
+	// +kubebuilder:validation:Optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Timeout is the maximum duration of the command, which is killed once exceeded. Default is 5m
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Prune Whether to prune groups that are no longer returned by the command. Default is false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Prune bool `json:"prune"`
+}
+
+// FakeProvider represents a provider returning the groups specified inline rather than the groups of a group source, so that the behavior of the operator can be tested without the credentials of a group source
+// +k8s:openapi-gen=true
+type FakeProvider struct {
+	// Groups are the groups returned by the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Groups"
+	// +kubebuilder:validation:Optional
+	Groups []FakeGroup `json:"groups,omitempty"`
+
+	// Prune Whether to prune groups that are no longer returned by the provider. Default is false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Prune bool `json:"prune"`
+}
+
+// FakeGroup represents a group returned by a fake provider
+// +k8s:openapi-gen=true
+type FakeGroup struct {
+	// Name is the name of the group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// ID is the identifier of the group, recorded in the sync.source.uid annotation of the group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ID",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	ID string `json:"id,omitempty"`
+
+	// Users are the names of the members of the group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Users"
+	// +kubebuilder:validation:Optional
+	Users []string `json:"users,omitempty"`
+
+	// Attributes are the metadata of the group available to the metadata mappings of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Attributes"
+	// +kubebuilder:validation:Optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// PluginProvider represents integration with a group source implemented by a plugin, such as a sidecar of the operator, serving the provider plugin gRPC protocol
+// +k8s:openapi-gen=true
+type PluginProvider struct {
+	// Address is the location of the plugin in the form host:port or unix:///path/to/socket
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Plugin Address",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Address string `json:"address"`
+
+	// Ca is a reference to a Secret or ConfigMap containing a CA certificate to communicate to the plugin
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Containing the CA Certificate",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Optional
+	Ca *ObjectRef `json:"ca,omitempty"`
+
+	// CredentialsSecret is a reference to a secret whose entries are passed to the plugin as the credentials of the group source. Optional unless required by the plugin
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret Containing the Credentials",xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +kubebuilder:validation:Optional
+	CredentialsSecret *ObjectRef `json:"credentialsSecret,omitempty"`
+
+	// Plaintext disables TLS when communicating to the plugin, such as a sidecar listening on localhost or a unix socket
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Plaintext",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Plaintext bool `json:"plaintext,omitempty"`
+
+	// Parameters are passed to the plugin to configure the groups it returns
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Parameters"
+	// +kubebuilder:validation:Optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Prune Whether to prune groups that are no longer returned by the plugin. Default is false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Prune bool `json:"prune"`
+}
+
 // ObjectRef represents a reference to an item within a Secret
 // +k8s:openapi-gen=true
 type ObjectRef struct {
@@ -466,6 +1658,51 @@ type ObjectRef struct {
 	Kind ObjectRefKind `json:"kind,omitempty"`
 }
 
+// ConfigMapRef represents a reference to a key within a ConfigMap
+// +k8s:openapi-gen=true
+type ConfigMapRef struct {
+
+	// Key represents the key containing the CA certificates. Defaults to ca.crt or, when not found, ca-bundle.crt
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Key",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Key string `json:"key,omitempty"`
+
+	// Name represents the name of the ConfigMap
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace represents the namespace containing the ConfigMap
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Namespace",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+}
+
+// ObjectRef returns the reference to the ConfigMap as an ObjectRef
+func (c *ConfigMapRef) ObjectRef() *ObjectRef {
+	return &ObjectRef{Key: c.Key, Name: c.Name, Namespace: c.Namespace, Kind: ConfigMapObjectRefKind}
+}
+
+// SecretRef represents a reference to a Secret
+// +k8s:openapi-gen=true
+type SecretRef struct {
+
+	// Name represents the name of the Secret
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace represents the namespace containing the Secret
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Namespace",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+}
+
+// ObjectRef returns the reference to the Secret as an ObjectRef
+func (s *SecretRef) ObjectRef() *ObjectRef {
+	return &ObjectRef{Name: s.Name, Namespace: s.Namespace, Kind: SecretMapObjectRefKind}
+}
+
 func (g *GroupSync) GetConditions() []metav1.Condition {
 	return g.Status.Conditions
 }
@@ -0,0 +1,135 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ProtectedGroupSkipReason      = "ProtectedGroup"
+	OtherProviderGroupSkipReason  = "ManagedByOtherProvider"
+	OtherGroupSyncGroupSkipReason = "OwnedByOtherGroupSync"
+	EmptyGroupSkipReason          = "EmptyGroup"
+)
+
+// GroupSyncReportStatus represents the outcome of the last synchronization of a GroupSync
+// +k8s:openapi-gen=true
+type GroupSyncReportStatus struct {
+	// GroupSync is the name of the GroupSync that was synchronized
+	GroupSync string `json:"groupSync"`
+
+	// SyncTime represents the time the synchronization completed
+	// +kubebuilder:validation:Optional
+	SyncTime *metav1.Time `json:"syncTime,omitempty"`
+
+	// SyncRunID identifies the synchronization in the logs and events of the operator
+	// +kubebuilder:validation:Optional
+	SyncRunID string `json:"syncRunID,omitempty"`
+
+	// ObservedGeneration is the generation of the GroupSync that was synchronized
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// DryRun indicates that the changes were not applied
+	// +kubebuilder:validation:Optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Providers contains the outcome of the synchronization of each provider
+	// +kubebuilder:validation:Optional
+	Providers []ProviderReport `json:"providers,omitempty"`
+
+	// Errors contains the errors encountered during the synchronization
+	// +kubebuilder:validation:Optional
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ProviderReport represents the outcome of the synchronization of a provider
+// +k8s:openapi-gen=true
+type ProviderReport struct {
+	// Name is the name of the provider
+	Name string `json:"name"`
+
+	// Groups is the number of groups returned by the provider
+	Groups int `json:"groups"`
+
+	// Created is the number of groups created
+	Created int `json:"created"`
+
+	// Updated is the number of groups updated
+	Updated int `json:"updated"`
+
+	// Unchanged is the number of groups that did not change
+	Unchanged int `json:"unchanged"`
+
+	// Pruned is the number of groups pruned
+	Pruned int `json:"pruned"`
+
+	// UsersAdded is the number of users added across all groups
+	UsersAdded int `json:"usersAdded"`
+
+	// UsersRemoved is the number of users removed across all groups
+	UsersRemoved int `json:"usersRemoved"`
+
+	// SkippedGroups are the groups returned by the provider that were not synchronized. At most 100 groups are listed
+	// +kubebuilder:validation:Optional
+	SkippedGroups []SkippedGroup `json:"skippedGroups,omitempty"`
+
+	// Error is the error encountered while synchronizing the provider
+	// +kubebuilder:validation:Optional
+	Error string `json:"error,omitempty"`
+}
+
+// SkippedGroup represents a group that was not synchronized
+// +k8s:openapi-gen=true
+type SkippedGroup struct {
+	// Name is the name of the group
+	Name string `json:"name"`
+
+	// Reason is the reason the group was skipped
+	Reason string `json:"reason"`
+}
+
+// +kubebuilder:object:root=true
+
+// GroupSyncReport is the Schema for the groupsyncreports API
+// +operator-sdk:csv:customresourcedefinitions:displayName="Group Sync Report"
+// +kubebuilder:resource:path=groupsyncreports,scope=Namespaced
+// +kubebuilder:printcolumn:name="Group Sync",type=string,JSONPath=`.status.groupSync`
+// +kubebuilder:printcolumn:name="Dry Run",type=boolean,JSONPath=`.status.dryRun`
+// +kubebuilder:printcolumn:name="Sync Time",type=date,JSONPath=`.status.syncTime`
+// +k8s:openapi-gen=true
+type GroupSyncReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status GroupSyncReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GroupSyncReportList contains a list of GroupSyncReport
+// +k8s:openapi-gen=true
+type GroupSyncReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GroupSyncReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GroupSyncReport{}, &GroupSyncReportList{})
+}
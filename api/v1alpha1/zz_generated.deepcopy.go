@@ -25,8 +25,24 @@ import (
 	legacyconfigv1 "github.com/openshift/api/legacyconfig/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttributeMapping) DeepCopyInto(out *AttributeMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttributeMapping.
+func (in *AttributeMapping) DeepCopy() *AttributeMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(AttributeMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureProvider) DeepCopyInto(out *AzureProvider) {
 	*out = *in
@@ -35,6 +51,16 @@ func (in *AzureProvider) DeepCopyInto(out *AzureProvider) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdministrativeUnits != nil {
+		in, out := &in.AdministrativeUnits, &out.AdministrativeUnits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupNamePrefixes != nil {
+		in, out := &in.GroupNamePrefixes, &out.GroupNamePrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.CredentialsSecret != nil {
 		in, out := &in.CredentialsSecret, &out.CredentialsSecret
 		*out = new(ObjectRef)
@@ -45,11 +71,21 @@ func (in *AzureProvider) DeepCopyInto(out *AzureProvider) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExcludedGroups != nil {
+		in, out := &in.ExcludedGroups, &out.ExcludedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.AuthorityHost != nil {
 		in, out := &in.AuthorityHost, &out.AuthorityHost
 		*out = new(string)
 		**out = **in
 	}
+	if in.GraphEndpoint != nil {
+		in, out := &in.GraphEndpoint, &out.GraphEndpoint
+		*out = new(string)
+		**out = **in
+	}
 	if in.UserNameAttributes != nil {
 		in, out := &in.UserNameAttributes, &out.UserNameAttributes
 		*out = new([]string)
@@ -59,6 +95,35 @@ func (in *AzureProvider) DeepCopyInto(out *AzureProvider) {
 			copy(*out, *in)
 		}
 	}
+	if in.GroupNameAttributes != nil {
+		in, out := &in.GroupNameAttributes, &out.GroupNameAttributes
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.GroupTypes != nil {
+		in, out := &in.GroupTypes, &out.GroupTypes
+		*out = make([]AzureGroupType, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxNestingDepth != nil {
+		in, out := &in.MaxNestingDepth, &out.MaxNestingDepth
+		*out = new(int)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(Proxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncTimeout != nil {
+		in, out := &in.SyncTimeout, &out.SyncTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureProvider.
@@ -72,199 +137,244 @@ func (in *AzureProvider) DeepCopy() *AzureProvider {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitHubProvider) DeepCopyInto(out *GitHubProvider) {
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 	*out = *in
-	if in.Ca != nil {
-		in, out := &in.Ca, &out.Ca
-		*out = new(ObjectRef)
-		**out = **in
-	}
-	if in.CaSecret != nil {
-		in, out := &in.CaSecret, &out.CaSecret
-		*out = new(ObjectRef)
-		**out = **in
-	}
-	if in.CredentialsSecret != nil {
-		in, out := &in.CredentialsSecret, &out.CredentialsSecret
-		*out = new(ObjectRef)
-		**out = **in
-	}
-	if in.Teams != nil {
-		in, out := &in.Teams, &out.Teams
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.URL != nil {
-		in, out := &in.URL, &out.URL
-		*out = new(string)
-		**out = **in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.V4URL != nil {
-		in, out := &in.V4URL, &out.V4URL
-		*out = new(string)
-		**out = **in
+	if in.LastSyncSuccessTime != nil {
+		in, out := &in.LastSyncSuccessTime, &out.LastSyncSuccessTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubProvider.
-func (in *GitHubProvider) DeepCopy() *GitHubProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GitHubProvider)
+	out := new(ClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitLabProvider) DeepCopyInto(out *GitLabProvider) {
+func (in *ConfigMapRef) DeepCopyInto(out *ConfigMapRef) {
 	*out = *in
-	if in.Ca != nil {
-		in, out := &in.Ca, &out.Ca
-		*out = new(ObjectRef)
-		**out = **in
-	}
-	if in.CaSecret != nil {
-		in, out := &in.CaSecret, &out.CaSecret
-		*out = new(ObjectRef)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapRef.
+func (in *ConfigMapRef) DeepCopy() *ConfigMapRef {
+	if in == nil {
+		return nil
 	}
-	if in.CredentialsSecret != nil {
-		in, out := &in.CredentialsSecret, &out.CredentialsSecret
-		*out = new(ObjectRef)
+	out := new(ConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsSource) DeepCopyInto(out *CredentialsSource) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(FileCredentialsSource)
 		**out = **in
 	}
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.URL != nil {
-		in, out := &in.URL, &out.URL
-		*out = new(string)
-		**out = **in
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultCredentialsSource)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabProvider.
-func (in *GitLabProvider) DeepCopy() *GitLabProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsSource.
+func (in *CredentialsSource) DeepCopy() *CredentialsSource {
 	if in == nil {
 		return nil
 	}
-	out := new(GitLabProvider)
+	out := new(CredentialsSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupSync) DeepCopyInto(out *GroupSync) {
+func (in *DryRunProviderStatus) DeepCopyInto(out *DryRunProviderStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.CreatedGroups != nil {
+		in, out := &in.CreatedGroups, &out.CreatedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpdatedGroups != nil {
+		in, out := &in.UpdatedGroups, &out.UpdatedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrunedGroups != nil {
+		in, out := &in.PrunedGroups, &out.PrunedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSync.
-func (in *GroupSync) DeepCopy() *GroupSync {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunProviderStatus.
+func (in *DryRunProviderStatus) DeepCopy() *DryRunProviderStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupSync)
+	out := new(DryRunProviderStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GroupSync) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupSyncList) DeepCopyInto(out *GroupSyncList) {
+func (in *DryRunStatus) DeepCopyInto(out *DryRunStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GroupSync, len(*in))
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]DryRunProviderStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastDryRunTime != nil {
+		in, out := &in.LastDryRunTime, &out.LastDryRunTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncList.
-func (in *GroupSyncList) DeepCopy() *GroupSyncList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunStatus.
+func (in *DryRunStatus) DeepCopy() *DryRunStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupSyncList)
+	out := new(DryRunStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GroupSyncList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecProvider) DeepCopyInto(out *ExecProvider) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	return nil
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecProvider.
+func (in *ExecProvider) DeepCopy() *ExecProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecProvider)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupSyncSpec) DeepCopyInto(out *GroupSyncSpec) {
+func (in *FakeGroup) DeepCopyInto(out *FakeGroup) {
 	*out = *in
-	if in.Providers != nil {
-		in, out := &in.Providers, &out.Providers
-		*out = make([]Provider, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncSpec.
-func (in *GroupSyncSpec) DeepCopy() *GroupSyncSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FakeGroup.
+func (in *FakeGroup) DeepCopy() *FakeGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupSyncSpec)
+	out := new(FakeGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupSyncStatus) DeepCopyInto(out *GroupSyncStatus) {
+func (in *FakeProvider) DeepCopyInto(out *FakeProvider) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]FakeGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.LastSyncSuccessTime != nil {
-		in, out := &in.LastSyncSuccessTime, &out.LastSyncSuccessTime
-		*out = (*in).DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FakeProvider.
+func (in *FakeProvider) DeepCopy() *FakeProvider {
+	if in == nil {
+		return nil
 	}
+	out := new(FakeProvider)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncStatus.
-func (in *GroupSyncStatus) DeepCopy() *GroupSyncStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileCredentialsSource) DeepCopyInto(out *FileCredentialsSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileCredentialsSource.
+func (in *FileCredentialsSource) DeepCopy() *FileCredentialsSource {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupSyncStatus)
+	out := new(FileCredentialsSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeycloakProvider) DeepCopyInto(out *KeycloakProvider) {
+func (in *GitHubProvider) DeepCopyInto(out *GitHubProvider) {
 	*out = *in
 	if in.Ca != nil {
 		in, out := &in.Ca, &out.Ca
@@ -281,25 +391,40 @@ func (in *KeycloakProvider) DeepCopyInto(out *KeycloakProvider) {
 		*out = new(ObjectRef)
 		**out = **in
 	}
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExcludedGroups != nil {
+		in, out := &in.ExcludedGroups, &out.ExcludedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.V4URL != nil {
+		in, out := &in.V4URL, &out.V4URL
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakProvider.
-func (in *KeycloakProvider) DeepCopy() *KeycloakProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubProvider.
+func (in *GitHubProvider) DeepCopy() *GitHubProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(KeycloakProvider)
+	out := new(GitHubProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LdapProvider) DeepCopyInto(out *LdapProvider) {
+func (in *GitLabProvider) DeepCopyInto(out *GitLabProvider) {
 	*out = *in
 	if in.Ca != nil {
 		in, out := &in.Ca, &out.Ca
@@ -316,9 +441,590 @@ func (in *LdapProvider) DeepCopyInto(out *LdapProvider) {
 		*out = new(ObjectRef)
 		**out = **in
 	}
-	if in.LDAPGroupUIDToOpenShiftGroupNameMapping != nil {
-		in, out := &in.LDAPGroupUIDToOpenShiftGroupNameMapping, &out.LDAPGroupUIDToOpenShiftGroupNameMapping
-		*out = make(map[string]string, len(*in))
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedGroups != nil {
+		in, out := &in.ExcludedGroups, &out.ExcludedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabProvider.
+func (in *GitLabProvider) DeepCopy() *GitLabProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupMembershipChange) DeepCopyInto(out *GroupMembershipChange) {
+	*out = *in
+	if in.AddedUsers != nil {
+		in, out := &in.AddedUsers, &out.AddedUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovedUsers != nil {
+		in, out := &in.RemovedUsers, &out.RemovedUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupMembershipChange.
+func (in *GroupMembershipChange) DeepCopy() *GroupMembershipChange {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupMembershipChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupMembershipDifference) DeepCopyInto(out *GroupMembershipDifference) {
+	*out = *in
+	if in.MissingUsers != nil {
+		in, out := &in.MissingUsers, &out.MissingUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalUsers != nil {
+		in, out := &in.AdditionalUsers, &out.AdditionalUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupMembershipDifference.
+func (in *GroupMembershipDifference) DeepCopy() *GroupMembershipDifference {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupMembershipDifference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupNameRewrite) DeepCopyInto(out *GroupNameRewrite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupNameRewrite.
+func (in *GroupNameRewrite) DeepCopy() *GroupNameRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupNameRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupNamespaces) DeepCopyInto(out *GroupNamespaces) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupNamespaces.
+func (in *GroupNamespaces) DeepCopy() *GroupNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupRoleBinding) DeepCopyInto(out *GroupRoleBinding) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.RoleRef = in.RoleRef
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupRoleBinding.
+func (in *GroupRoleBinding) DeepCopy() *GroupRoleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupRoleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupRoleRef) DeepCopyInto(out *GroupRoleRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupRoleRef.
+func (in *GroupRoleRef) DeepCopy() *GroupRoleRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupRoleRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSync) DeepCopyInto(out *GroupSync) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSync.
+func (in *GroupSync) DeepCopy() *GroupSync {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupSync) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSyncList) DeepCopyInto(out *GroupSyncList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GroupSync, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncList.
+func (in *GroupSyncList) DeepCopy() *GroupSyncList {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSyncList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupSyncList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSyncReport) DeepCopyInto(out *GroupSyncReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncReport.
+func (in *GroupSyncReport) DeepCopy() *GroupSyncReport {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSyncReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupSyncReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSyncReportList) DeepCopyInto(out *GroupSyncReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GroupSyncReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncReportList.
+func (in *GroupSyncReportList) DeepCopy() *GroupSyncReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSyncReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupSyncReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSyncReportStatus) DeepCopyInto(out *GroupSyncReportStatus) {
+	*out = *in
+	if in.SyncTime != nil {
+		in, out := &in.SyncTime, &out.SyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]ProviderReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncReportStatus.
+func (in *GroupSyncReportStatus) DeepCopy() *GroupSyncReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSyncReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSyncSpec) DeepCopyInto(out *GroupSyncSpec) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]Provider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(Migration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PruneMaxDelete != nil {
+		in, out := &in.PruneMaxDelete, &out.PruneMaxDelete
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.RetryInterval != nil {
+		in, out := &in.RetryInterval, &out.RetryInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxRetryInterval != nil {
+		in, out := &in.MaxRetryInterval, &out.MaxRetryInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PruneGracePeriod != nil {
+		in, out := &in.PruneGracePeriod, &out.PruneGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RoleBindings != nil {
+		in, out := &in.RoleBindings, &out.RoleBindings
+		*out = make([]GroupRoleBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RoleMappings != nil {
+		in, out := &in.RoleMappings, &out.RoleMappings
+		*out = make([]RoleMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GroupNamespaces != nil {
+		in, out := &in.GroupNamespaces, &out.GroupNamespaces
+		*out = new(GroupNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]RemoteCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncSpec.
+func (in *GroupSyncSpec) DeepCopy() *GroupSyncSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSyncSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupSyncStatus) DeepCopyInto(out *GroupSyncStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncSuccessTime != nil {
+		in, out := &in.LastSyncSuccessTime, &out.LastSyncSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastMissedRunTime != nil {
+		in, out := &in.LastMissedRunTime, &out.LastMissedRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(MigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]ProviderStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(DryRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MembershipChanges != nil {
+		in, out := &in.MembershipChanges, &out.MembershipChanges
+		*out = make([]GroupMembershipChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupSyncStatus.
+func (in *GroupSyncStatus) DeepCopy() *GroupSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakProvider) DeepCopyInto(out *KeycloakProvider) {
+	*out = *in
+	if in.AttributeMappings != nil {
+		in, out := &in.AttributeMappings, &out.AttributeMappings
+		*out = make([]AttributeMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ca != nil {
+		in, out := &in.Ca, &out.Ca
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.CaSecret != nil {
+		in, out := &in.CaSecret, &out.CaSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContextPath != nil {
+		in, out := &in.ContextPath, &out.ContextPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.ExcludedGroups != nil {
+		in, out := &in.ExcludedGroups, &out.ExcludedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeServiceAccounts != nil {
+		in, out := &in.ExcludeServiceAccounts, &out.ExcludeServiceAccounts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupPathSeparator != nil {
+		in, out := &in.GroupPathSeparator, &out.GroupPathSeparator
+		*out = new(string)
+		**out = **in
+	}
+	if in.Realms != nil {
+		in, out := &in.Realms, &out.Realms
+		*out = make([]KeycloakRealm, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryCount != nil {
+		in, out := &in.RetryCount, &out.RetryCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.RetryMaxWaitTime != nil {
+		in, out := &in.RetryMaxWaitTime, &out.RetryMaxWaitTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryWaitTime != nil {
+		in, out := &in.RetryWaitTime, &out.RetryWaitTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SyncGroups != nil {
+		in, out := &in.SyncGroups, &out.SyncGroups
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UserNameAttributes != nil {
+		in, out := &in.UserNameAttributes, &out.UserNameAttributes
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakProvider.
+func (in *KeycloakProvider) DeepCopy() *KeycloakProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakRealm) DeepCopyInto(out *KeycloakRealm) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakRealm.
+func (in *KeycloakRealm) DeepCopy() *KeycloakRealm {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakRealm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapProvider) DeepCopyInto(out *LdapProvider) {
+	*out = *in
+	if in.Ca != nil {
+		in, out := &in.Ca, &out.Ca
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.CaSecret != nil {
+		in, out := &in.CaSecret, &out.CaSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.LDAPGroupUIDToOpenShiftGroupNameMapping != nil {
+		in, out := &in.LDAPGroupUIDToOpenShiftGroupNameMapping, &out.LDAPGroupUIDToOpenShiftGroupNameMapping
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
@@ -361,14 +1067,110 @@ func (in *LdapProvider) DeepCopyInto(out *LdapProvider) {
 			copy(*out, *in)
 		}
 	}
+	if in.ExcludedGroups != nil {
+		in, out := &in.ExcludedGroups, &out.ExcludedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapProvider.
+func (in *LdapProvider) DeepCopy() *LdapProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MappingWebhook) DeepCopyInto(out *MappingWebhook) {
+	*out = *in
+	if in.Ca != nil {
+		in, out := &in.Ca, &out.Ca
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MappingWebhook.
+func (in *MappingWebhook) DeepCopy() *MappingWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(MappingWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataMapping) DeepCopyInto(out *MetadataMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataMapping.
+func (in *MetadataMapping) DeepCopy() *MetadataMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Migration) DeepCopyInto(out *Migration) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Migration.
+func (in *Migration) DeepCopy() *Migration {
+	if in == nil {
+		return nil
+	}
+	out := new(Migration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationStatus) DeepCopyInto(out *MigrationStatus) {
+	*out = *in
+	if in.Comparisons != nil {
+		in, out := &in.Comparisons, &out.Comparisons
+		*out = make([]ProviderComparison, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastComparisonTime != nil {
+		in, out := &in.LastComparisonTime, &out.LastComparisonTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapProvider.
-func (in *LdapProvider) DeepCopy() *LdapProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStatus.
+func (in *MigrationStatus) DeepCopy() *MigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LdapProvider)
+	out := new(MigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -401,6 +1203,11 @@ func (in *OktaProvider) DeepCopyInto(out *OktaProvider) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExcludedGroups != nil {
+		in, out := &in.ExcludedGroups, &out.ExcludedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OktaProvider.
@@ -413,9 +1220,120 @@ func (in *OktaProvider) DeepCopy() *OktaProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginProvider) DeepCopyInto(out *PluginProvider) {
+	*out = *in
+	if in.Ca != nil {
+		in, out := &in.Ca, &out.Ca
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginProvider.
+func (in *PluginProvider) DeepCopy() *PluginProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Provider) DeepCopyInto(out *Provider) {
 	*out = *in
+	if in.MappingWebhook != nil {
+		in, out := &in.MappingWebhook, &out.MappingWebhook
+		*out = new(MappingWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
+	if in.UserProvisioning != nil {
+		in, out := &in.UserProvisioning, &out.UserProvisioning
+		*out = new(UserProvisioning)
+		**out = **in
+	}
+	if in.MinGroupSize != nil {
+		in, out := &in.MinGroupSize, &out.MinGroupSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxGroupSize != nil {
+		in, out := &in.MaxGroupSize, &out.MaxGroupSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.GroupNameRewrites != nil {
+		in, out := &in.GroupNameRewrites, &out.GroupNameRewrites
+		*out = make([]GroupNameRewrite, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupNameMappings != nil {
+		in, out := &in.GroupNameMappings, &out.GroupNameMappings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.GroupNameMappingsRef != nil {
+		in, out := &in.GroupNameMappingsRef, &out.GroupNameMappingsRef
+		*out = new(ObjectRef)
+		**out = **in
+	}
+	if in.UsernameRewrites != nil {
+		in, out := &in.UsernameRewrites, &out.UsernameRewrites
+		*out = make([]UsernameRewrite, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetadataMappings != nil {
+		in, out := &in.MetadataMappings, &out.MetadataMappings
+		*out = make([]MetadataMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialsSource != nil {
+		in, out := &in.CredentialsSource, &out.CredentialsSource
+		*out = new(CredentialsSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsKeys != nil {
+		in, out := &in.CredentialsKeys, &out.CredentialsKeys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CaBundleConfigMapRef != nil {
+		in, out := &in.CaBundleConfigMapRef, &out.CaBundleConfigMapRef
+		*out = new(ConfigMapRef)
+		**out = **in
+	}
+	if in.ClientCertificateSecretRef != nil {
+		in, out := &in.ClientCertificateSecretRef, &out.ClientCertificateSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
 	if in.ProviderType != nil {
 		in, out := &in.ProviderType, &out.ProviderType
 		*out = new(ProviderType)
@@ -433,6 +1351,88 @@ func (in *Provider) DeepCopy() *Provider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderComparison) DeepCopyInto(out *ProviderComparison) {
+	*out = *in
+	if in.MissingGroups != nil {
+		in, out := &in.MissingGroups, &out.MissingGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalGroups != nil {
+		in, out := &in.AdditionalGroups, &out.AdditionalGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MembershipDifferences != nil {
+		in, out := &in.MembershipDifferences, &out.MembershipDifferences
+		*out = make([]GroupMembershipDifference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderComparison.
+func (in *ProviderComparison) DeepCopy() *ProviderComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderReport) DeepCopyInto(out *ProviderReport) {
+	*out = *in
+	if in.SkippedGroups != nil {
+		in, out := &in.SkippedGroups, &out.SkippedGroups
+		*out = make([]SkippedGroup, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderReport.
+func (in *ProviderReport) DeepCopy() *ProviderReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderStatus) DeepCopyInto(out *ProviderStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncSuccessTime != nil {
+		in, out := &in.LastSyncSuccessTime, &out.LastSyncSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastConnectivityCheckTime != nil {
+		in, out := &in.LastConnectivityCheckTime, &out.LastConnectivityCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderStatus.
+func (in *ProviderStatus) DeepCopy() *ProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderType) DeepCopyInto(out *ProviderType) {
 	*out = *in
@@ -466,6 +1466,21 @@ func (in *ProviderType) DeepCopyInto(out *ProviderType) {
 		*out = new(OktaProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(ExecProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fake != nil {
+		in, out := &in.Fake, &out.Fake
+		*out = new(FakeProvider)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderType.
@@ -477,3 +1492,158 @@ func (in *ProviderType) DeepCopy() *ProviderType {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Proxy) DeepCopyInto(out *Proxy) {
+	*out = *in
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Proxy.
+func (in *Proxy) DeepCopy() *Proxy {
+	if in == nil {
+		return nil
+	}
+	out := new(Proxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCluster) DeepCopyInto(out *RemoteCluster) {
+	*out = *in
+	if in.KubeConfigSecret != nil {
+		in, out := &in.KubeConfigSecret, &out.KubeConfigSecret
+		*out = new(ObjectRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCluster.
+func (in *RemoteCluster) DeepCopy() *RemoteCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleMapping) DeepCopyInto(out *RoleMapping) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMapping.
+func (in *RoleMapping) DeepCopy() *RoleMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRef) DeepCopyInto(out *SecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRef.
+func (in *SecretRef) DeepCopy() *SecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkippedGroup) DeepCopyInto(out *SkippedGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkippedGroup.
+func (in *SkippedGroup) DeepCopy() *SkippedGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(SkippedGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserProvisioning) DeepCopyInto(out *UserProvisioning) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProvisioning.
+func (in *UserProvisioning) DeepCopy() *UserProvisioning {
+	if in == nil {
+		return nil
+	}
+	out := new(UserProvisioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsernameRewrite) DeepCopyInto(out *UsernameRewrite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsernameRewrite.
+func (in *UsernameRewrite) DeepCopy() *UsernameRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(UsernameRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCredentialsSource) DeepCopyInto(out *VaultCredentialsSource) {
+	*out = *in
+	if in.Ca != nil {
+		in, out := &in.Ca, &out.Ca
+		*out = new(ObjectRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCredentialsSource.
+func (in *VaultCredentialsSource) DeepCopy() *VaultCredentialsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCredentialsSource)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,188 @@
+package v1beta1
+
+import (
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this GroupSync to the Hub version (v1alpha1)
+func (src *GroupSync) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*redhatcopv1alpha1.GroupSync)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Schedule = src.Spec.Schedule
+	dst.Spec.MissedRunPolicy = src.Spec.MissedRunPolicy
+	dst.Spec.Migration = src.Spec.Migration
+	dst.Spec.PruneMaxDelete = src.Spec.PruneMaxDelete
+	dst.Spec.RetryInterval = src.Spec.RetryInterval
+	dst.Spec.MaxRetryInterval = src.Spec.MaxRetryInterval
+	dst.Spec.PruneGracePeriod = src.Spec.PruneGracePeriod
+	dst.Spec.PruneMode = src.Spec.PruneMode
+	dst.Spec.DryRun = src.Spec.DryRun
+	dst.Spec.DeletionPolicy = src.Spec.DeletionPolicy
+	dst.Spec.MembershipChangesUserLimit = src.Spec.MembershipChangesUserLimit
+	dst.Spec.GroupMergeStrategy = src.Spec.GroupMergeStrategy
+	dst.Spec.OwnershipConflictPolicy = src.Spec.OwnershipConflictPolicy
+	dst.Spec.RoleBindings = src.Spec.RoleBindings
+	dst.Spec.RoleBindingsOnly = src.Spec.RoleBindingsOnly
+	dst.Spec.RoleMappings = src.Spec.RoleMappings
+	dst.Spec.GroupNamespaces = src.Spec.GroupNamespaces
+	dst.Spec.Clusters = src.Spec.Clusters
+	dst.Spec.Output = src.Spec.Output
+
+	dst.Spec.Providers = nil
+	for _, provider := range src.Spec.Providers {
+		dst.Spec.Providers = append(dst.Spec.Providers, convertProviderTo(provider))
+	}
+
+	dst.Status = src.Status
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *GroupSync) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*redhatcopv1alpha1.GroupSync)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Schedule = src.Spec.Schedule
+	dst.Spec.MissedRunPolicy = src.Spec.MissedRunPolicy
+	dst.Spec.Migration = src.Spec.Migration
+	dst.Spec.PruneMaxDelete = src.Spec.PruneMaxDelete
+	dst.Spec.RetryInterval = src.Spec.RetryInterval
+	dst.Spec.MaxRetryInterval = src.Spec.MaxRetryInterval
+	dst.Spec.PruneGracePeriod = src.Spec.PruneGracePeriod
+	dst.Spec.PruneMode = src.Spec.PruneMode
+	dst.Spec.DryRun = src.Spec.DryRun
+	dst.Spec.DeletionPolicy = src.Spec.DeletionPolicy
+	dst.Spec.MembershipChangesUserLimit = src.Spec.MembershipChangesUserLimit
+	dst.Spec.GroupMergeStrategy = src.Spec.GroupMergeStrategy
+	dst.Spec.OwnershipConflictPolicy = src.Spec.OwnershipConflictPolicy
+	dst.Spec.RoleBindings = src.Spec.RoleBindings
+	dst.Spec.RoleBindingsOnly = src.Spec.RoleBindingsOnly
+	dst.Spec.RoleMappings = src.Spec.RoleMappings
+	dst.Spec.GroupNamespaces = src.Spec.GroupNamespaces
+	dst.Spec.Clusters = src.Spec.Clusters
+	dst.Spec.Output = src.Spec.Output
+
+	dst.Spec.Providers = nil
+	for _, provider := range src.Spec.Providers {
+		dst.Spec.Providers = append(dst.Spec.Providers, convertProviderFrom(provider))
+	}
+
+	dst.Status = src.Status
+
+	return nil
+}
+
+func convertProviderTo(src Provider) redhatcopv1alpha1.Provider {
+
+	dst := redhatcopv1alpha1.Provider{
+		Name:                       src.Name,
+		Schedule:                   src.Schedule,
+		Timeout:                    src.Timeout,
+		RateLimit:                  src.RateLimit,
+		PreserveUnmanagedUsers:     src.PreserveUnmanagedUsers,
+		EmptyGroupPolicy:           src.EmptyGroupPolicy,
+		UserProvisioning:           src.UserProvisioning,
+		CredentialsSource:          src.CredentialsSource,
+		CredentialsKeys:            src.CredentialsKeys,
+		CaBundleConfigMapRef:       src.CaBundleConfigMapRef,
+		ClientCertificateSecretRef: src.ClientCertificateSecretRef,
+		LogLevel:                   src.LogLevel,
+		ProviderType:               src.ProviderType,
+	}
+
+	if src.Filters != nil {
+		dst.GroupFilterExpression = src.Filters.GroupExpression
+		dst.UserFilterExpression = src.Filters.UserExpression
+		dst.MinGroupSize = src.Filters.MinGroupSize
+		dst.MaxGroupSize = src.Filters.MaxGroupSize
+	}
+
+	if src.Transforms != nil {
+		dst.MappingWebhook = src.Transforms.MappingWebhook
+
+		if groupNames := src.Transforms.GroupNames; groupNames != nil {
+			dst.GroupNamePrefix = groupNames.Prefix
+			dst.GroupNameSuffix = groupNames.Suffix
+			dst.GroupNameTemplate = groupNames.Template
+			dst.GroupNameRewrites = groupNames.Rewrites
+			dst.LowercaseGroupNames = groupNames.Lowercase
+			dst.GroupNameMappings = groupNames.Mappings
+			dst.GroupNameMappingsRef = groupNames.MappingsRef
+		}
+
+		if usernames := src.Transforms.Usernames; usernames != nil {
+			dst.UsernameCase = usernames.Case
+			dst.UsernameRewrites = usernames.Rewrites
+		}
+
+		dst.MetadataMappings = src.Transforms.MetadataMappings
+		dst.HierarchyMetadata = src.Transforms.HierarchyMetadata
+	}
+
+	return dst
+}
+
+func convertProviderFrom(src redhatcopv1alpha1.Provider) Provider {
+
+	dst := Provider{
+		Name:                       src.Name,
+		Schedule:                   src.Schedule,
+		Timeout:                    src.Timeout,
+		RateLimit:                  src.RateLimit,
+		PreserveUnmanagedUsers:     src.PreserveUnmanagedUsers,
+		EmptyGroupPolicy:           src.EmptyGroupPolicy,
+		UserProvisioning:           src.UserProvisioning,
+		CredentialsSource:          src.CredentialsSource,
+		CredentialsKeys:            src.CredentialsKeys,
+		CaBundleConfigMapRef:       src.CaBundleConfigMapRef,
+		ClientCertificateSecretRef: src.ClientCertificateSecretRef,
+		LogLevel:                   src.LogLevel,
+		ProviderType:               src.ProviderType,
+	}
+
+	if src.GroupFilterExpression != "" || src.UserFilterExpression != "" || src.MinGroupSize != nil || src.MaxGroupSize != nil {
+		dst.Filters = &Filters{
+			GroupExpression: src.GroupFilterExpression,
+			UserExpression:  src.UserFilterExpression,
+			MinGroupSize:    src.MinGroupSize,
+			MaxGroupSize:    src.MaxGroupSize,
+		}
+	}
+
+	groupNames := &GroupNameTransforms{
+		Prefix:      src.GroupNamePrefix,
+		Suffix:      src.GroupNameSuffix,
+		Template:    src.GroupNameTemplate,
+		Rewrites:    src.GroupNameRewrites,
+		Lowercase:   src.LowercaseGroupNames,
+		Mappings:    src.GroupNameMappings,
+		MappingsRef: src.GroupNameMappingsRef,
+	}
+
+	usernames := &UsernameTransforms{
+		Case:     src.UsernameCase,
+		Rewrites: src.UsernameRewrites,
+	}
+
+	transforms := &Transforms{MappingWebhook: src.MappingWebhook, MetadataMappings: src.MetadataMappings, HierarchyMetadata: src.HierarchyMetadata}
+
+	if groupNames.Prefix != "" || groupNames.Suffix != "" || groupNames.Template != "" || len(groupNames.Rewrites) > 0 || groupNames.Lowercase ||
+		len(groupNames.Mappings) > 0 || groupNames.MappingsRef != nil {
+		transforms.GroupNames = groupNames
+	}
+
+	if usernames.Case != "" || len(usernames.Rewrites) > 0 {
+		transforms.Usernames = usernames
+	}
+
+	if transforms.MappingWebhook != nil || transforms.GroupNames != nil || transforms.Usernames != nil || len(transforms.MetadataMappings) > 0 || transforms.HierarchyMetadata {
+		dst.Transforms = transforms
+	}
+
+	return dst
+}
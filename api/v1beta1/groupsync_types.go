@@ -0,0 +1,367 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// GroupSyncSpec defines the desired state of GroupSync
+// +k8s:openapi-gen=true
+type GroupSyncSpec struct {
+
+	// List of Providers that can be mounted by containers belonging to the pod.
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Providers"
+	Providers []Provider `json:"providers,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name" protobuf:"bytes,1,rep,name=providers"`
+
+	// Schedule represents a cron based configuration for synchronization
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// MissedRunPolicy determines whether a single synchronization is performed or skipped when scheduled synchronizations were missed, such as while the operator was not running
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Missed Run Policy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=runOnce;skip
+	MissedRunPolicy redhatcopv1alpha1.MissedRunPolicy `json:"missedRunPolicy,omitempty"`
+
+	// Migration enables dual synchronization of multiple providers while migrating between identity providers
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Migration"
+	// +kubebuilder:validation:Optional
+	Migration *redhatcopv1alpha1.Migration `json:"migration,omitempty"`
+
+	// PruneMaxDelete is the maximum number of groups pruned for a provider in a single synchronization, either as an absolute number or as a percentage of the groups managed by the provider. Pruning is skipped and the provider is marked as degraded when exceeded
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune Max Delete",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XIntOrString
+	PruneMaxDelete *intstr.IntOrString `json:"pruneMaxDelete,omitempty"`
+
+	// RetryInterval is the time waited before retrying a failed synchronization. The time waited doubles after each consecutive failure up to the max retry interval. Failed synchronizations are retried by the rate limiter of the operator when not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retry Interval",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
+
+	// MaxRetryInterval is the maximum time waited before retrying a failed synchronization when a retry interval is set. Default is 1h
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max Retry Interval",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	MaxRetryInterval *metav1.Duration `json:"maxRetryInterval,omitempty"`
+
+	// PruneGracePeriod is the time groups no longer returned by a provider are kept before being pruned. Groups returned again by the provider within the grace period are kept
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune Grace Period",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	PruneGracePeriod *metav1.Duration `json:"pruneGracePeriod,omitempty"`
+
+	// PruneMode determines whether pruned groups are deleted (delete) or quarantined (quarantine). Quarantined groups have their users removed and are labeled as orphaned, preserving the RoleBindings referencing them. Default is delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prune Mode"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=delete;quarantine
+	// +kubebuilder:default:=delete
+	PruneMode redhatcopv1alpha1.PruneMode `json:"pruneMode,omitempty"`
+
+	// DryRun retrieves groups from the providers and reports the groups that would be created, updated and pruned without making any changes
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Dry Run",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DeletionPolicy determines whether the groups synchronized by the providers are deleted (Delete) or kept (Retain) when the GroupSync is deleted. Default is Retain
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Deletion Policy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default:=Retain
+	DeletionPolicy redhatcopv1alpha1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// MembershipChangesUserLimit is the maximum number of added and removed users listed for each group in the membership changes reported in the status. Users are not listed when 0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Membership Changes User Limit",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MembershipChangesUserLimit int `json:"membershipChangesUserLimit,omitempty"`
+
+	// GroupMergeStrategy determines how groups with the same name returned by multiple providers are handled. Groups are either merged into a single group containing the users of all providers (union), taken from the provider declared first (priority) or rejected (error). Default is priority
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Merge Strategy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=union;priority;error
+	GroupMergeStrategy redhatcopv1alpha1.GroupMergeStrategy `json:"groupMergeStrategy,omitempty"`
+
+	// OwnershipConflictPolicy determines how groups returned by a provider that are already synchronized by another GroupSync or provider are handled. Groups are either left to their first owner (firstOwnerWins) or fail the synchronization of the provider (error). Conflicts are recorded in the OwnershipConflict condition of the provider. Default is firstOwnerWins
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ownership Conflict Policy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=firstOwnerWins;error
+	OwnershipConflictPolicy redhatcopv1alpha1.OwnershipConflictPolicy `json:"ownershipConflictPolicy,omitempty"`
+
+	// RoleBindings bind a Role or ClusterRole to the synchronized groups in namespaces. The RoleBindings are maintained by the operator and deleted when no longer specified
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Role Bindings"
+	// +kubebuilder:validation:Optional
+	RoleBindings []redhatcopv1alpha1.GroupRoleBinding `json:"roleBindings,omitempty"`
+
+	// RoleBindingsOnly maintains the RoleBindings of the synchronized groups without writing the groups, such as when the groups of users are provided by the identity provider of the cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Role Bindings Only",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	RoleBindingsOnly bool `json:"roleBindingsOnly,omitempty"`
+
+	// RoleMappings map the synchronized groups to ClusterRoles. A ClusterRoleBinding binding each ClusterRole to the groups mapped to it is maintained by the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Role Mappings"
+	// +kubebuilder:validation:Optional
+	RoleMappings []redhatcopv1alpha1.RoleMapping `json:"roleMappings,omitempty"`
+
+	// GroupNamespaces creates a namespace for each of the synchronized groups in which the group is bound to a ClusterRole
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Namespaces"
+	// +kubebuilder:validation:Optional
+	GroupNamespaces *redhatcopv1alpha1.GroupNamespaces `json:"groupNamespaces,omitempty"`
+
+	// Clusters are remote clusters to which the groups synchronized by the GroupSync are distributed
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Clusters"
+	// +kubebuilder:validation:Optional
+	Clusters []redhatcopv1alpha1.RemoteCluster `json:"clusters,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Output determines whether the synchronized groups are written as OpenShift Groups (Group) or to a ConfigMap in the namespace of the GroupSync (ConfigMap), which does not require the user.openshift.io API. Default is Group
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Output"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Group;ConfigMap
+	// +kubebuilder:default:=Group
+	Output redhatcopv1alpha1.GroupOutput `json:"output,omitempty"`
+}
+
+// Provider represents the container for a single provider. Options common to all providers are grouped by purpose
+// +k8s:openapi-gen=true
+type Provider struct {
+	// Name represents the name of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name of the Provider"
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Schedule represents a cron based configuration for synchronizing the provider independently of the schedule of the GroupSync
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Timeout is the maximum duration of binding to the provider and retrieving its groups. The synchronization of the provider fails once exceeded so that an unresponsive provider does not block the synchronization of other GroupSyncs
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// RateLimit limits the requests issued to the provider so that synchronizing many groups does not exceed the rate limits of the provider. Not supported by LDAP providers
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rate Limit"
+	// +kubebuilder:validation:Optional
+	RateLimit *redhatcopv1alpha1.RateLimit `json:"rateLimit,omitempty"`
+
+	// PreserveUnmanagedUsers only adds and removes the users previously synchronized by the provider so that users added manually to the groups are kept
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Preserve Unmanaged Users",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	PreserveUnmanagedUsers bool `json:"preserveUnmanagedUsers,omitempty"`
+
+	// EmptyGroupPolicy determines how groups without users are handled. Empty groups are created and updated (create), are not created while existing groups are updated (skip) or are not created while existing groups are deleted (prune). Default is create
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Empty Group Policy"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=create;skip;prune
+	EmptyGroupPolicy redhatcopv1alpha1.EmptyGroupPolicy `json:"emptyGroupPolicy,omitempty"`
+
+	// UserProvisioning creates the OpenShift Users, and optionally their Identities, of the members of the groups synchronized by the provider that do not exist yet
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="User Provisioning"
+	// +kubebuilder:validation:Optional
+	UserProvisioning *redhatcopv1alpha1.UserProvisioning `json:"userProvisioning,omitempty"`
+
+	// Filters restrict the groups and users of the provider that are synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Filters"
+	// +kubebuilder:validation:Optional
+	Filters *Filters `json:"filters,omitempty"`
+
+	// Transforms change the groups and users of the provider before they are synchronized
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Transforms"
+	// +kubebuilder:validation:Optional
+	Transforms *Transforms `json:"transforms,omitempty"`
+
+	// CredentialsSource loads the credentials of the provider from a source other than the credentials secret of the provider, such as mounted files, environment variables or HashiCorp Vault
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credentials Source"
+	// +kubebuilder:validation:Optional
+	CredentialsSource *redhatcopv1alpha1.CredentialsSource `json:"credentialsSource,omitempty"`
+
+	// CredentialsKeys maps the keys of the credentials expected by the provider, such as AZURE_TENANT_ID, to the keys of the credentials secret or credentials source containing their values, so that credentials provisioned using other naming conventions can be used without being copied
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Credentials Keys"
+	// +kubebuilder:validation:Optional
+	CredentialsKeys map[string]string `json:"credentialsKeys,omitempty"`
+
+	// CaBundleConfigMapRef references a ConfigMap containing a bundle of CA certificates trusted when communicating with the provider in addition to the certificate referenced by the provider and the bundle configured for the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA Bundle ConfigMap"
+	// +kubebuilder:validation:Optional
+	CaBundleConfigMapRef *redhatcopv1alpha1.ConfigMapRef `json:"caBundleConfigMapRef,omitempty"`
+
+	// ClientCertificateSecretRef references a Secret of type kubernetes.io/tls containing the client certificate and key presented to providers requiring mutual TLS
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Client Certificate Secret"
+	// +kubebuilder:validation:Optional
+	ClientCertificateSecretRef *redhatcopv1alpha1.SecretRef `json:"clientCertificateSecretRef,omitempty"`
+
+	// LogLevel overrides the verbosity of the operator for the lines logged while synchronizing the provider, logging only errors (error), informational messages (info) or also debugging messages (debug)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Log Level"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=error;info;debug
+	LogLevel redhatcopv1alpha1.LogLevel `json:"logLevel,omitempty"`
+
+	*redhatcopv1alpha1.ProviderType `json:",inline"`
+}
+
+// Filters represents the CEL expressions restricting the groups and users that are synchronized
+// +k8s:openapi-gen=true
+type Filters struct {
+	// GroupExpression is a CEL expression evaluated against each group of the provider. Only groups for which the expression evaluates to true are synchronized. The group is available as the group variable with the name, id, attributes and memberCount fields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Expression",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	GroupExpression string `json:"groupExpression,omitempty"`
+
+	// UserExpression is a CEL expression evaluated against each user of the groups of the provider. Only users for which the expression evaluates to true are synchronized. The user is available as the user variable with the name and group fields
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="User Expression",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	UserExpression string `json:"userExpression,omitempty"`
+
+	// MinGroupSize is the minimum number of users of the groups of the provider that are synchronized. Groups with fewer users are skipped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minimum Group Size",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MinGroupSize *int `json:"minGroupSize,omitempty"`
+
+	// MaxGroupSize is the maximum number of users of the groups of the provider that are synchronized. Groups with more users are skipped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maximum Group Size",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxGroupSize *int `json:"maxGroupSize,omitempty"`
+}
+
+// Transforms represents the changes made to the groups and users of a provider
+// +k8s:openapi-gen=true
+type Transforms struct {
+	// MappingWebhook is an optional external service invoked to transform the groups returned by the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Mapping Webhook"
+	// +kubebuilder:validation:Optional
+	MappingWebhook *redhatcopv1alpha1.MappingWebhook `json:"mappingWebhook,omitempty"`
+
+	// GroupNames transform the names of the groups of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Group Names"
+	// +kubebuilder:validation:Optional
+	GroupNames *GroupNameTransforms `json:"groupNames,omitempty"`
+
+	// Usernames transform the names of the users of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Usernames"
+	// +kubebuilder:validation:Optional
+	Usernames *UsernameTransforms `json:"usernames,omitempty"`
+
+	// MetadataMappings set labels or annotations on the synchronized groups from the metadata of the groups in the provider, such as their description, email or path
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metadata Mappings"
+	// +kubebuilder:validation:Optional
+	MetadataMappings []redhatcopv1alpha1.MetadataMapping `json:"metadataMappings,omitempty"`
+
+	// HierarchyMetadata records the parent and child groups of the groups of the provider as annotations and their depth in the hierarchy of groups as a label
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Hierarchy Metadata",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	HierarchyMetadata bool `json:"hierarchyMetadata,omitempty"`
+}
+
+// GroupNameTransforms represents the transformations applied to the names of groups
+// +k8s:openapi-gen=true
+type GroupNameTransforms struct {
+	// Prefix is prepended to the names of the groups of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Prefix",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Suffix is appended to the names of the groups of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Suffix",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Suffix string `json:"suffix,omitempty"`
+
+	// Template is a Go template rendering the names of the groups of the provider. The template has access to the .Provider name and the .Group with the Name, ID and Attributes fields. Sprig functions are available
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Template",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Optional
+	Template string `json:"template,omitempty"`
+
+	// Rewrites are regular expression based rules applied in order to the names of the groups of the provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rewrites"
+	// +kubebuilder:validation:Optional
+	Rewrites []redhatcopv1alpha1.GroupNameRewrite `json:"rewrites,omitempty"`
+
+	// Lowercase converts the names of the groups of the provider to lowercase after the rewrites are applied
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Lowercase",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +kubebuilder:validation:Optional
+	Lowercase bool `json:"lowercase,omitempty"`
+
+	// Mappings map the names or identifiers of groups of the provider to the names of the groups in the cluster. Mapped names are not transformed further
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Mappings"
+	// +kubebuilder:validation:Optional
+	Mappings map[string]string `json:"mappings,omitempty"`
+
+	// MappingsRef is a reference to a ConfigMap or Secret whose entries map the names or identifiers of groups of the provider to the names of the groups in the cluster. Entries of mappings take precedence
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Containing the Mappings"
+	// +kubebuilder:validation:Optional
+	MappingsRef *redhatcopv1alpha1.ObjectRef `json:"mappingsRef,omitempty"`
+}
+
+// UsernameTransforms represents the transformations applied to the names of users
+// +k8s:openapi-gen=true
+type UsernameTransforms struct {
+	// Case converts the names of the users of the provider to lowercase (lower) or uppercase (upper), such as to match the names of the users logging in to the cluster. Default is preserve
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Case"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=lower;upper;preserve
+	Case redhatcopv1alpha1.UsernameCase `json:"case,omitempty"`
+
+	// Rewrites are regular expression based rules applied in order to the names of the users of the provider before the case is converted
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rewrites"
+	// +kubebuilder:validation:Optional
+	Rewrites []redhatcopv1alpha1.UsernameRewrite `json:"rewrites,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// GroupSync is the Schema for the groupsyncs API
+// +operator-sdk:csv:customresourcedefinitions:displayName="Group Sync"
+// +kubebuilder:resource:path=groupsyncs,scope=Namespaced
+// +k8s:openapi-gen=true
+type GroupSync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GroupSyncSpec                     `json:"spec,omitempty"`
+	Status redhatcopv1alpha1.GroupSyncStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GroupSyncList contains a list of GroupSync
+// +k8s:openapi-gen=true
+type GroupSyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GroupSync `json:"items"`
+}
+
+func (g *GroupSync) GetConditions() []metav1.Condition {
+	return g.Status.Conditions
+}
+
+func (g *GroupSync) SetConditions(conditions []metav1.Condition) {
+	g.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&GroupSync{}, &GroupSyncList{})
+}
@@ -0,0 +1,394 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// group-sync runs the providers of a GroupSync locally and prints the resulting groups, or the changes to a set of existing groups,
+// without a cluster
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/plan"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
+)
+
+const usage = `Run the providers of a GroupSync locally and print the resulting groups without a cluster
+
+Usage:
+  group-sync -f <groupsync.yaml> [--credentials <file>]... [--groups <file>] [-n namespace] [-o yaml|json]
+
+Flags:
+`
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(redhatcopv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(redhatcopv1beta1.AddToScheme(scheme))
+	utilruntime.Must(userv1.AddToScheme(scheme))
+}
+
+// files collects the paths of a flag specified several times
+type files []string
+
+func (f *files) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *files) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func main() {
+
+	flags := flag.NewFlagSet("group-sync", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flags.PrintDefaults()
+	}
+	var groupSyncFile string
+	flags.StringVar(&groupSyncFile, "f", "", "File containing the GroupSync to run.")
+	flags.StringVar(&groupSyncFile, "filename", "", "File containing the GroupSync to run.")
+	var credentialsFiles files
+	flags.Var(&credentialsFiles, "credentials", "File containing the secrets and config maps referenced by the GroupSync, such as credentials and CA certificates. May be specified several times.")
+	var groupsFile string
+	flags.StringVar(&groupsFile, "groups", "", "File containing the existing groups, such as the output of 'oc get groups -o yaml'. The changes to the existing groups are printed instead of the groups when specified.")
+	var namespace string
+	flags.StringVar(&namespace, "n", "default", "The namespace of the GroupSync and of the secrets and config maps not specifying a namespace.")
+	flags.StringVar(&namespace, "namespace", "default", "The namespace of the GroupSync and of the secrets and config maps not specifying a namespace.")
+	var output string
+	flags.StringVar(&output, "o", "yaml", "The format the groups are printed in. One of yaml or json.")
+	flags.StringVar(&output, "output", "yaml", "The format the groups are printed in. One of yaml or json.")
+	var caBundleConfigMap string
+	flags.StringVar(&caBundleConfigMap, "ca-bundle-configmap", "", "ConfigMap containing CA certificates trusted by all providers, matching the configuration of the operator. The ConfigMap must be contained in a credentials file.")
+	var verbose bool
+	flags.BoolVar(&verbose, "v", false, "Log the messages of the providers to the standard error.")
+	flags.Parse(os.Args[1:])
+
+	if groupSyncFile == "" || flags.NArg() != 0 || (output != "yaml" && output != "json") {
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	if verbose {
+		ctrl.SetLogger(zap.New(zap.UseDevMode(true), zap.WriteTo(os.Stderr)))
+	}
+
+	if err := run(context.Background(), groupSyncFile, credentialsFiles, groupsFile, namespace, output, caBundleConfigMap); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(context context.Context, groupSyncFile string, credentialsFiles []string, groupsFile string, namespace string, output string, caBundleConfigMap string) error {
+
+	instance, err := readGroupSync(groupSyncFile, namespace)
+
+	if err != nil {
+		return err
+	}
+
+	caBundle, err := syncer.ParseCaBundleConfigMapRef(caBundleConfigMap)
+
+	if err != nil {
+		return err
+	}
+
+	// The secrets and config maps referenced by the GroupSync, along with the existing groups, are served by an in-memory client in
+	// place of the cluster
+	objects := []runtime.Object{}
+
+	for _, path := range append(append([]string{}, credentialsFiles...), groupsFile) {
+		if path == "" {
+			continue
+		}
+
+		fileObjects, err := readObjects(path, namespace)
+
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, fileObjects...)
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+	reconcilerBase := util.NewReconcilerBase(c, scheme, nil, &record.FakeRecorder{}, c)
+
+	groupSyncMgr, err := syncer.GetGroupSyncMgr(context, instance, reconcilerBase, caBundle)
+
+	if err != nil {
+		return err
+	}
+
+	// Defaults are applied in the same way as the operator
+	groupSyncMgr.SetDefaults()
+
+	if _, err := groupSyncMgr.Validate(); err != nil {
+		return err
+	}
+
+	providerNames := []string{}
+	providerGroups := [][]userv1.Group{}
+
+	for _, groupSyncer := range groupSyncMgr.GroupSyncers {
+
+		if err := groupSyncer.Bind(); err != nil {
+			return fmt.Errorf("Failed to bind provider '%s': %w", groupSyncer.GetProviderName(), err)
+		}
+
+		groups, err := groupSyncer.Sync()
+
+		if err != nil {
+			return fmt.Errorf("Failed to synchronize provider '%s': %w", groupSyncer.GetProviderName(), err)
+		}
+
+		providerNames = append(providerNames, groupSyncer.GetProviderName())
+		providerGroups = append(providerGroups, groups)
+	}
+
+	// Groups returned by several providers are merged in the same way as the operator
+	providerGroups, conflicts := plan.MergeProviderGroups(instance, providerNames, providerGroups, nil)
+
+	for _, providerName := range providerNames {
+		if conflict, found := conflicts[providerName]; found {
+			return fmt.Errorf("Failed to synchronize provider '%s': %w", providerName, conflict)
+		}
+	}
+
+	syncedGroups := map[string][]userv1.Group{}
+	for i, providerName := range providerNames {
+		groups := providerGroups[i]
+		sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+		syncedGroups[providerName] = groups
+	}
+
+	if groupsFile != "" {
+		return diff(context, c, groupSyncMgr, providerNames, providerGroups)
+	}
+
+	return printGroups(groupSyncMgr, syncedGroups, output)
+}
+
+// printGroups prints the groups of each provider as a list labeled in the same way as the groups synchronized by the operator
+func printGroups(groupSyncMgr syncer.GroupSyncMgr, syncedGroups map[string][]userv1.Group, output string) error {
+
+	groupList := &userv1.GroupList{}
+	groupList.APIVersion = userv1.SchemeGroupVersion.String()
+	groupList.Kind = "GroupList"
+
+	for _, groupSyncer := range groupSyncMgr.GroupSyncers {
+		for _, group := range syncedGroups[groupSyncer.GetProviderName()] {
+			group.APIVersion = userv1.SchemeGroupVersion.String()
+			group.Kind = "Group"
+
+			if group.Labels == nil {
+				group.Labels = map[string]string{}
+			}
+			group.Labels[constants.SyncProvider] = fmt.Sprintf("%s_%s", groupSyncMgr.GroupSync.Name, groupSyncer.GetProviderName())
+
+			groupList.Items = append(groupList.Items, group)
+		}
+	}
+
+	var data []byte
+	var err error
+
+	if output == "json" {
+		data, err = json.MarshalIndent(groupList, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = sigsyaml.Marshal(groupList)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(data)
+
+	return err
+}
+
+// diff compares the groups of each provider with the existing groups in the same way as the diff command of the kubectl plugin.
+// Protected groups are not taken into account
+func diff(context context.Context, c client.Client, groupSyncMgr syncer.GroupSyncMgr, providerNames []string, providerGroups [][]userv1.Group) error {
+
+	existingGroups := &userv1.GroupList{}
+
+	if err := c.List(context, existingGroups); err != nil {
+		return err
+	}
+
+	pruneProviders := map[string]bool{}
+	for _, groupSyncer := range groupSyncMgr.GroupSyncers {
+		pruneProviders[groupSyncer.GetProviderName()] = groupSyncer.GetPrune()
+	}
+
+	changes := plan.GetChanges(groupSyncMgr.GroupSync, providerNames, providerGroups, pruneProviders, existingGroups.Items, ctrl.Log)
+
+	for i, providerName := range providerNames {
+		plan.PrintChanges(os.Stdout, providerName, changes[i])
+	}
+
+	return nil
+}
+
+// readGroupSync reads the GroupSync contained in the file. GroupSyncs of the v1beta1 API are converted to the v1alpha1 API used by the
+// syncers
+func readGroupSync(path string, namespace string) (*redhatcopv1alpha1.GroupSync, error) {
+
+	objects, err := readObjects(path, namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(objects) != 1 {
+		return nil, fmt.Errorf("Expected a single GroupSync in '%s', found %d objects", path, len(objects))
+	}
+
+	switch groupSync := objects[0].(type) {
+	case *redhatcopv1alpha1.GroupSync:
+		return groupSync, nil
+	case *redhatcopv1beta1.GroupSync:
+		instance := &redhatcopv1alpha1.GroupSync{}
+		if err := groupSync.ConvertTo(instance); err != nil {
+			return nil, err
+		}
+		return instance, nil
+	}
+
+	return nil, fmt.Errorf("Expected a GroupSync in '%s', found %s", path, objects[0].GetObjectKind().GroupVersionKind().Kind)
+}
+
+// readObjects reads the objects of each YAML or JSON document contained in the file. Items of lists are read as separate objects and
+// namespaced objects not specifying a namespace are placed in the namespace
+func readObjects(path string, namespace string) ([]runtime.Object, error) {
+
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	objects := []runtime.Object{}
+	reader := yaml.NewYAMLReader(bufio.NewReader(file))
+
+	for {
+		document, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("Failed to read '%s': %w", path, err)
+		}
+
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		documentObjects, err := decodeObjects(document)
+
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode '%s': %w", path, err)
+		}
+
+		objects = append(objects, documentObjects...)
+	}
+
+	for _, object := range objects {
+		if accessor, err := meta.Accessor(object); err == nil && accessor.GetNamespace() == "" && isNamespaced(object) {
+			accessor.SetNamespace(namespace)
+		}
+	}
+
+	return objects, nil
+}
+
+func decodeObjects(data []byte) ([]runtime.Object, error) {
+
+	object, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Items of generic lists, such as the output of 'oc get -o yaml', are decoded according to their own kind
+	if list, ok := object.(*corev1.List); ok {
+		objects := []runtime.Object{}
+
+		for _, item := range list.Items {
+			itemObjects, err := decodeObjects(item.Raw)
+
+			if err != nil {
+				return nil, err
+			}
+
+			objects = append(objects, itemObjects...)
+		}
+
+		return objects, nil
+	}
+
+	if meta.IsListType(object) {
+		return meta.ExtractList(object)
+	}
+
+	return []runtime.Object{object}, nil
+}
+
+func isNamespaced(object runtime.Object) bool {
+
+	switch object.(type) {
+	case *corev1.Secret, *corev1.ConfigMap, *redhatcopv1alpha1.GroupSync, *redhatcopv1beta1.GroupSync:
+		return true
+	}
+
+	return false
+}
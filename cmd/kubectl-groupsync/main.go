@@ -0,0 +1,382 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-groupsync is a kubectl and oc plugin to validate, compare, trigger and inspect the synchronizations of GroupSyncs
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/plan"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	"github.com/redhat-cop/group-sync-operator/pkg/trigger"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+const usage = `Validate, compare, trigger and inspect the synchronizations of GroupSyncs
+
+Usage:
+  kubectl groupsync <command> <name> [-n namespace] [--ca-bundle-configmap namespace/name]
+
+Commands:
+  validate  Validate the GroupSync and bind to each of its providers
+  diff      Show the changes a synchronization would make to the groups of the cluster
+  sync      Trigger an immediate synchronization
+  status    Show the status of the GroupSync and of each of its providers
+`
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(redhatcopv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(userv1.AddToScheme(scheme))
+}
+
+func main() {
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(1)
+	}
+
+	commands := map[string]func(context.Context, *plugin, *redhatcopv1alpha1.GroupSync) error{
+		"validate": validate,
+		"diff":     diff,
+		"sync":     sync,
+		"status":   status,
+	}
+
+	command, found := commands[os.Args[1]]
+
+	if !found {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(1)
+	}
+
+	flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	var namespace string
+	flags.StringVar(&namespace, "n", "", "The namespace of the GroupSync. Defaults to the namespace of the current context.")
+	flags.StringVar(&namespace, "namespace", "", "The namespace of the GroupSync. Defaults to the namespace of the current context.")
+	var caBundleConfigMap string
+	flags.StringVar(&caBundleConfigMap, "ca-bundle-configmap", "", "ConfigMap containing CA certificates trusted by all providers, matching the configuration of the operator.")
+	// Allow the namespace to be specified after the name of the GroupSync
+	args := reorderArgs(os.Args[2:])
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(1)
+	}
+
+	context := context.Background()
+
+	p, err := newPlugin(namespace, caBundleConfigMap)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
+	instance := &redhatcopv1alpha1.GroupSync{}
+
+	if err := p.client.Get(context, types.NamespacedName{Namespace: p.namespace, Name: flags.Arg(0)}, instance); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err := command(context, p, instance); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// plugin holds the clients used to communicate with the cluster
+type plugin struct {
+	client         client.Client
+	reconcilerBase util.ReconcilerBase
+	namespace      string
+	caBundle       *redhatcopv1alpha1.ConfigMapRef
+}
+
+func newPlugin(namespace string, caBundleConfigMap string) (*plugin, error) {
+
+	caBundle, err := syncer.ParseCaBundleConfigMapRef(caBundleConfigMap)
+
+	if err != nil {
+		return nil, err
+	}
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+
+	config, err := clientConfig.ClientConfig()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if namespace == "" {
+		if namespace, _, err = clientConfig.Namespace(); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := client.New(config, client.Options{Scheme: scheme})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &plugin{
+		client:         c,
+		reconcilerBase: util.NewReconcilerBase(c, scheme, config, &record.FakeRecorder{}, c),
+		namespace:      namespace,
+		caBundle:       caBundle,
+	}, nil
+}
+
+// validate validates the GroupSync and binds to each of its providers using the credentials referenced by the GroupSync
+func validate(context context.Context, p *plugin, instance *redhatcopv1alpha1.GroupSync) error {
+
+	groupSyncMgr, err := getGroupSyncMgr(context, p, instance)
+
+	if err != nil {
+		return err
+	}
+
+	providerErrors, err := groupSyncMgr.Validate()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tVALIDATED\tBOUND\tMESSAGE")
+
+	failed := err != nil
+
+	for _, groupSyncer := range groupSyncMgr.GroupSyncers {
+		if providerErr := providerErrors[groupSyncer.GetProviderName()]; providerErr != nil {
+			fmt.Fprintf(w, "%s\tFalse\tUnknown\t%s\n", groupSyncer.GetProviderName(), providerErr)
+			continue
+		}
+
+		if bindErr := groupSyncer.Bind(); bindErr != nil {
+			failed = true
+			fmt.Fprintf(w, "%s\tTrue\tFalse\t%s\n", groupSyncer.GetProviderName(), bindErr)
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\tTrue\tTrue\t\n", groupSyncer.GetProviderName())
+	}
+
+	w.Flush()
+
+	if failed {
+		return fmt.Errorf("GroupSync '%s' is not valid", instance.Name)
+	}
+
+	return nil
+}
+
+// diff retrieves the groups of each provider and compares them with the groups of the cluster without making any changes. Protected
+// groups are not taken into account
+func diff(context context.Context, p *plugin, instance *redhatcopv1alpha1.GroupSync) error {
+
+	groupSyncMgr, err := getGroupSyncMgr(context, p, instance)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := groupSyncMgr.Validate(); err != nil {
+		return err
+	}
+
+	providerNames := []string{}
+	providerGroups := [][]userv1.Group{}
+	pruneProviders := map[string]bool{}
+
+	for _, groupSyncer := range groupSyncMgr.GroupSyncers {
+
+		if err := groupSyncer.Bind(); err != nil {
+			return fmt.Errorf("Failed to bind provider '%s': %w", groupSyncer.GetProviderName(), err)
+		}
+
+		groups, err := groupSyncer.Sync()
+
+		if err != nil {
+			return fmt.Errorf("Failed to synchronize provider '%s': %w", groupSyncer.GetProviderName(), err)
+		}
+
+		providerNames = append(providerNames, groupSyncer.GetProviderName())
+		providerGroups = append(providerGroups, groups)
+		pruneProviders[groupSyncer.GetProviderName()] = groupSyncer.GetPrune()
+	}
+
+	// Groups returned by several providers are merged in the same way as the operator
+	providerGroups, conflicts := plan.MergeProviderGroups(instance, providerNames, providerGroups, nil)
+
+	for _, providerName := range providerNames {
+		if conflict, found := conflicts[providerName]; found {
+			return fmt.Errorf("Failed to synchronize provider '%s': %w", providerName, conflict)
+		}
+	}
+
+	existingGroups := &userv1.GroupList{}
+
+	if err := p.client.List(context, existingGroups); err != nil {
+		return err
+	}
+
+	changes := plan.GetChanges(instance, providerNames, providerGroups, pruneProviders, existingGroups.Items, logf.Log)
+
+	for i, providerName := range providerNames {
+		plan.PrintChanges(os.Stdout, providerName, changes[i])
+	}
+
+	return nil
+}
+
+// sync requests an immediate synchronization of the GroupSync
+func sync(context context.Context, p *plugin, instance *redhatcopv1alpha1.GroupSync) error {
+
+	if err := trigger.RequestSync(context, p.client, instance); err != nil {
+		return err
+	}
+
+	fmt.Printf("Synchronization of GroupSync '%s' requested\n", instance.Name)
+
+	return nil
+}
+
+// status prints the status of the GroupSync along with the conditions of each provider and the outcome of the last synchronization
+func status(context context.Context, p *plugin, instance *redhatcopv1alpha1.GroupSync) error {
+
+	fmt.Printf("Name:\t\t%s\n", instance.Name)
+	fmt.Printf("Namespace:\t%s\n", instance.Namespace)
+	fmt.Printf("Last Sync:\t%s\n", formatTime(instance.Status.LastSyncSuccessTime))
+	if instance.Spec.DryRun {
+		fmt.Printf("Dry Run:\ttrue\n")
+	}
+	for _, condition := range instance.Status.Conditions {
+		fmt.Printf("%s:\t%s %s\n", condition.Type, condition.Status, condition.Message)
+	}
+	fmt.Println()
+
+	report := &redhatcopv1alpha1.GroupSyncReport{}
+	if err := p.client.Get(context, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}, report); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	providerReports := map[string]redhatcopv1alpha1.ProviderReport{}
+	for _, providerReport := range report.Status.Providers {
+		providerReports[providerReport.Name] = providerReport
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tVALIDATED\tBOUND\tSYNCED\tDEGRADED\tLAST SYNC\tGROUPS\tCREATED\tUPDATED\tPRUNED\tMESSAGE")
+
+	for _, providerStatus := range instance.Status.Providers {
+		providerReport := providerReports[providerStatus.Name]
+
+		message := providerReport.Error
+		if degraded := meta.FindStatusCondition(providerStatus.Conditions, redhatcopv1alpha1.DegradedProviderCondition); degraded != nil && degraded.Message != "" {
+			message = degraded.Message
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\n", providerStatus.Name,
+			getConditionStatus(providerStatus, redhatcopv1alpha1.ValidatedProviderCondition),
+			getConditionStatus(providerStatus, redhatcopv1alpha1.BoundProviderCondition),
+			getConditionStatus(providerStatus, redhatcopv1alpha1.SyncedProviderCondition),
+			getConditionStatus(providerStatus, redhatcopv1alpha1.DegradedProviderCondition),
+			formatTime(providerStatus.LastSyncSuccessTime),
+			providerReport.Groups, providerReport.Created, providerReport.Updated, providerReport.Pruned,
+			strings.ReplaceAll(message, "\n", " "))
+	}
+
+	return w.Flush()
+}
+
+func getGroupSyncMgr(context context.Context, p *plugin, instance *redhatcopv1alpha1.GroupSync) (syncer.GroupSyncMgr, error) {
+
+	groupSyncMgr, err := syncer.GetGroupSyncMgr(context, instance, p.reconcilerBase, p.caBundle)
+
+	if err != nil {
+		return groupSyncMgr, err
+	}
+
+	// Defaults are applied locally in the same way as the operator without updating the GroupSync
+	groupSyncMgr.SetDefaults()
+
+	return groupSyncMgr, nil
+}
+
+func getConditionStatus(providerStatus redhatcopv1alpha1.ProviderStatus, conditionType string) string {
+
+	if condition := meta.FindStatusCondition(providerStatus.Conditions, conditionType); condition != nil {
+		return string(condition.Status)
+	}
+
+	return "Unknown"
+}
+
+func formatTime(t *metav1.Time) string {
+
+	if t.IsZero() {
+		return "Never"
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// reorderArgs moves the flags before the positional arguments as the flag package stops parsing at the first positional argument
+func reorderArgs(args []string) []string {
+
+	flags := []string{}
+	positional := []string{}
+
+	for i := 0; i < len(args); i++ {
+		if strings.HasPrefix(args[i], "-") {
+			flags = append(flags, args[i])
+			if !strings.Contains(args[i], "=") && i+1 < len(args) {
+				flags = append(flags, args[i+1])
+				i++
+			}
+			continue
+		}
+		positional = append(positional, args[i])
+	}
+
+	return append(flags, positional...)
+}
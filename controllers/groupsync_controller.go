@@ -19,23 +19,35 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	userv1 "github.com/openshift/api/user/v1"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/common/log"
+	"github.com/redhat-cop/group-sync-operator/pkg/audit"
 	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/plan"
+	"github.com/redhat-cop/group-sync-operator/pkg/scheduler"
 	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	"github.com/redhat-cop/group-sync-operator/pkg/tracing"
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	"github.com/robfig/cron"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kubeclock "k8s.io/apimachinery/pkg/util/clock"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
 )
@@ -46,15 +58,56 @@ var clock kubeclock.Clock = &kubeclock.RealClock{}
 type GroupSyncReconciler struct {
 	Log logr.Logger
 	util.ReconcilerBase
+	// Scheduler triggers scheduled synchronizations
+	Scheduler *scheduler.Scheduler
+	// ProtectedGroupPrefixes contains the Group name prefixes that will never be created, updated or pruned
+	ProtectedGroupPrefixes []string
+	// MaxConcurrentProviderSyncs is the maximum number of providers of a GroupSync that are synchronized concurrently
+	MaxConcurrentProviderSyncs int
+	// CaBundle references a ConfigMap containing CA certificates trusted by all providers
+	CaBundle *redhatcopv1alpha1.ConfigMapRef
+	// Shard selects the GroupSyncs reconciled by the operator. All GroupSyncs are reconciled when nil
+	Shard *Shard
+	// AuditLog records the users added to and removed from groups. Changes are not recorded when nil
+	AuditLog *audit.Log
+	// ReferencePolicy authorizes the secrets and config maps referenced outside of the namespace of GroupSyncs. All references are
+	// authorized when nil
+	ReferencePolicy *ReferencePolicy
+	// referenceChanges records the GroupSyncs whose referenced secrets or config maps changed
+	referenceChanges *referenceChanges
 }
 
 // +kubebuilder:rbac:groups=redhatcop.redhat.io,resources=groupsyncs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=redhatcop.redhat.io,resources=groupsyncs/finalizers,verbs=update
 // +kubebuilder:rbac:groups=redhatcop.redhat.io,resources=groupsyncs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=redhatcop.redhat.io,resources=groupsyncreports,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=user.openshift.io,resources=groups,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=user.openshift.io,resources=users;identities,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=secrets;configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=create;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;clusterroles,verbs=bind
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=hnc.x-k8s.io,resources=subnamespaceanchors,verbs=get;list;watch;create;delete
 
 func (r *GroupSyncReconciler) Reconcile(context context.Context, req ctrl.Request) (ctrl.Result, error) {
-	logger := r.Log.WithValues("groupsync", req.NamespacedName)
+
+	// The lines logged by the syncers of the providers and the events recorded are correlated using the ID of the synchronization
+	context, syncRunID := withSyncRunID(context)
+	context = syncer.WithSyncLogValues(context, "groupsync", req.NamespacedName, "syncRunID", syncRunID)
+
+	// The spans of the phases of the providers and of the requests issued to the providers are children of the span of the reconciliation
+	context, span := tracing.Tracer().Start(context, "Reconcile", trace.WithAttributes(tracing.GroupSyncNamespaceAttribute.String(req.Namespace), tracing.GroupSyncNameAttribute.String(req.Name), tracing.SyncRunIDAttribute.String(syncRunID)))
+	defer span.End()
+
+	result, err := r.reconcileGroupSync(context, req)
+	tracing.RecordError(span, err)
+
+	return result, err
+}
+
+func (r *GroupSyncReconciler) reconcileGroupSync(context context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("groupsync", req.NamespacedName, "syncRunID", getSyncRunID(context))
 
 	// Fetch the GroupSync instance
 	instance := &redhatcopv1alpha1.GroupSync{}
@@ -64,14 +117,42 @@ func (r *GroupSyncReconciler) Reconcile(context context.Context, req ctrl.Reques
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			r.Scheduler.Unschedule(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, err
 	}
 
+	// GroupSyncs of other shards are reconciled by other replicas of the operator
+	if !r.Shard.Owns(instance) {
+		r.Scheduler.Unschedule(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	if util.IsBeingDeleted(instance) {
+		r.Scheduler.Unschedule(req.NamespacedName)
+
+		if err := r.deleteSyncedGroups(context, instance, logger); err != nil {
+			return r.ManageError(context, instance, err)
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	if updated, err := r.manageFinalizer(context, instance); err != nil {
+		return r.ManageError(context, instance, err)
+	} else if updated {
+		return reconcile.Result{}, nil
+	}
+
+	// Authorize References to Other Namespaces
+	if err := r.ReferencePolicy.Authorize(context, r.GetClient(), instance); err != nil {
+		return r.ManageError(context, instance, err)
+	}
+
 	// Get Group Sync Manager
-	groupSyncMgr, err := syncer.GetGroupSyncMgr(instance, r.ReconcilerBase)
+	groupSyncMgr, err := syncer.GetGroupSyncMgr(context, instance, r.ReconcilerBase, r.CaBundle)
 
 	if err != nil {
 		return r.ManageError(context, instance, err)
@@ -81,48 +162,241 @@ func (r *GroupSyncReconciler) Reconcile(context context.Context, req ctrl.Reques
 	if changed := groupSyncMgr.SetDefaults(); changed {
 		err := r.GetClient().Update(context, instance)
 		if err != nil {
-			log.Error(err, "unable to update instance", "instance", instance)
+			logger.Error(err, "unable to update instance", "instance", instance)
 			return r.ManageError(context, instance, err)
 		}
 		return reconcile.Result{}, nil
 	}
 
 	// Validate Providers
-	if err := groupSyncMgr.Validate(); err != nil {
+	providerValidationErrors, err := groupSyncMgr.Validate()
+
+	pruneProviderStatuses(instance)
+	pruneClusterStatuses(instance)
+	for _, groupSyncer := range groupSyncMgr.GroupSyncers {
+		setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.ValidatedProviderCondition, redhatcopv1alpha1.ValidationSucceededReason, redhatcopv1alpha1.ValidationFailedReason, providerValidationErrors[groupSyncer.GetProviderName()])
+	}
+
+	if err != nil {
 		return r.ManageError(context, instance, err)
 	}
 
-	// Execute Each Provider Syncer
+	var schedule cron.Schedule
+	providerSchedules := getProviderSchedules(instance)
+	syncRequired := true
+	syncNow, syncRequested := getSyncRequest(instance)
+	referencesChanged := r.referenceChanges.isChanged(req.NamespacedName)
+
+	if instance.Spec.Schedule != "" {
+		schedule, _ = cron.ParseStandard(instance.Spec.Schedule)
+
+		syncRequired, err = r.isSyncRequired(context, instance, schedule, logger)
+
+		if err != nil {
+			return r.ManageError(context, instance, err)
+		}
+	} else if len(providerSchedules) > 0 {
+		// Providers without a schedule are only synchronized when the GroupSync has never been synchronized or has changed
+		syncRequired = instance.Status.LastSyncSuccessTime == nil || instance.GetGeneration() != instance.Status.ObservedGeneration || isRetryDue(instance)
+	} else {
+		r.Scheduler.Unschedule(req.NamespacedName)
+	}
+
+	// Synchronizations requested using the annotation run for all providers regardless of their schedules
+	if syncRequested {
+		logger.Info("Synchronization Requested", "Annotation", constants.SyncNow, "Value", syncNow)
+		syncRequired = true
+	}
+
+	// Synchronizations also run for all providers when referenced secrets or config maps changed, such as after rotating credentials
+	if referencesChanged {
+		logger.Info("Synchronization Required as Referenced Objects Changed")
+		syncRequired = true
+	}
+
+	// Providers due for synchronization
+	groupSyncers := []syncer.GroupSyncer{}
 	for _, groupSyncer := range groupSyncMgr.GroupSyncers {
+		if providerSchedule, found := providerSchedules[groupSyncer.GetProviderName()]; found {
+			if syncRequested || referencesChanged || isProviderSyncRequired(instance, groupSyncer.GetProviderName(), providerSchedule) {
+				groupSyncers = append(groupSyncers, groupSyncer)
+			}
+		} else if syncRequired {
+			groupSyncers = append(groupSyncers, groupSyncer)
+		}
+	}
+
+	if len(groupSyncers) == 0 {
+		r.scheduleNextSync(instance, schedule, providerSchedules)
+		return ctrl.Result{}, nil
+	}
+
+	if syncRequested {
+		defer r.clearSyncRequest(context, req.NamespacedName, syncNow, logger)
+	}
+
+	// Objects changing while synchronizing require another synchronization
+	r.referenceChanges.clear(req.NamespacedName)
+
+	// Groups retrieved by providers participating in a migration
+	migrationGroups := map[string][]userv1.Group{}
+
+	// Changes that would have been made by a dry run
+	var dryRunStatus *redhatcopv1alpha1.DryRunStatus
+	if instance.Spec.DryRun {
+		dryRunStatus = &redhatcopv1alpha1.DryRunStatus{}
+	}
+
+	// Groups that would have been written by a dry run, including groups taken over from other providers
+	dryRunSyncedGroups := map[string]bool{}
+
+	// Groups whose members were changed
+	membershipChanges := []redhatcopv1alpha1.GroupMembershipChange{}
+
+	// Groups bound to roles along with their provider and parent group when groups are not written
+	roleBindingGroups := map[string]string{}
+	roleBindingGroupParents := map[string]string{}
 
-		logger.Info("Beginning Sync", "Provider", groupSyncer.GetProviderName())
+	// Groups written to the groups ConfigMap when groups are not written as OpenShift Groups
+	configMapGroups := []configMapGroup{}
+
+	// Retrieve the groups of each provider concurrently
+	providerResults := retrieveProviderGroups(context, &groupSyncMgr, groupSyncers, getProviderTimeouts(instance), r.MaxConcurrentProviderSyncs, logger)
+
+	// Resolve groups returned by multiple providers
+	providerGroups, groupConflicts := mergeProviderGroups(instance, groupSyncers, providerResults)
+
+	// Providers that could not be synchronized
+	providerErrors := []error{}
+
+	// Outcome of the synchronization recorded in the GroupSyncReport
+	report := newSyncReport(instance, getSyncRunID(context))
+
+	// Write the groups of each provider
+	for i, groupSyncer := range groupSyncers {
+
+		providerResult := providerResults[i]
+		writeStartTime := time.Now()
 
 		prometheusLabels := prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName(), METRICS_PROVIDER_LABEL: groupSyncer.GetProviderName()}
 
 		// Provider Label
 		providerLabel := fmt.Sprintf("%s_%s", instance.Name, groupSyncer.GetProviderName())
 
-		// Initialize Connection
-		if err := groupSyncer.Bind(); err != nil {
-			return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, err)
+		providerReport := getProviderReport(report, groupSyncer.GetProviderName())
+
+		setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.BoundProviderCondition, redhatcopv1alpha1.BindSucceededReason, redhatcopv1alpha1.BindFailedReason, providerResult.bindError)
+
+		if providerResult.bindError != nil {
+			logger.Error(providerResult.bindError, "Failed to Bind", "Provider", groupSyncer.GetProviderName())
+			recordUnsuccessfulSync(prometheusLabels)
+			providerReport.Error = providerResult.bindError.Error()
+			providerErrors = append(providerErrors, fmt.Errorf("Failed to bind provider '%s': %w", groupSyncer.GetProviderName(), providerResult.bindError))
+			continue
 		}
 
-		syncStartTime := ISO8601(time.Now())
-		// Perform Sync
-		groups, err := groupSyncer.Sync()
+		syncStartTime := providerResult.syncStartTime
+		groups := providerResult.groups
 
-		if err != nil {
-			logger.Error(err, "Failed to Complete Sync", "Provider", groupSyncer.GetProviderName())
-			return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, err)
+		if providerResult.syncError != nil {
+			logger.Error(providerResult.syncError, "Failed to Complete Sync", "Provider", groupSyncer.GetProviderName())
+			setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, providerResult.syncError)
+			recordUnsuccessfulSync(prometheusLabels)
+			providerReport.Error = providerResult.syncError.Error()
+			providerErrors = append(providerErrors, fmt.Errorf("Failed to synchronize provider '%s': %w", groupSyncer.GetProviderName(), providerResult.syncError))
+			continue
+		}
+
+		if conflict, found := groupConflicts[groupSyncer.GetProviderName()]; found {
+			logger.Error(conflict, "Groups Returned by Multiple Providers", "Provider", groupSyncer.GetProviderName())
+			setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.GroupConflictReason, conflict)
+			r.recordEvent(context, instance, "Warning", redhatcopv1alpha1.GroupConflictReason, fmt.Sprintf("Provider '%s': %s", groupSyncer.GetProviderName(), conflict.Error()))
+			recordUnsuccessfulSync(prometheusLabels)
+			providerReport.Error = conflict.Error()
+			providerErrors = append(providerErrors, fmt.Errorf("Failed to synchronize provider '%s': %w", groupSyncer.GetProviderName(), conflict))
+			continue
+		}
+
+		providerReport.Groups = len(groups)
+
+		if plan.IsMigrationProvider(instance, groupSyncer.GetProviderName()) {
+			migrationGroups[groupSyncer.GetProviderName()] = groups
+		}
+
+		// Groups from migration providers other than the source of truth are only compared
+		if plan.IsShadowProvider(instance, groupSyncer.GetProviderName()) {
+			logger.Info("Sync Completed Successfully for Migration Provider Without Writing Groups", "Provider", groupSyncer.GetProviderName(), "Source of Truth", instance.Spec.Migration.SourceOfTruth, "Groups Found", len(groups))
+			setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, nil)
+			setProviderHealthy(instance, groupSyncer.GetProviderName())
+			getProviderStatus(instance, groupSyncer.GetProviderName()).LastSyncSuccessTime = &metav1.Time{Time: clock.Now()}
+			recordSuccessfulSync(prometheusLabels, providerResult.duration+time.Since(writeStartTime))
+			continue
+		}
+
+		// Groups are not written when only the role bindings of the groups are maintained or when groups are written to a ConfigMap
+		if instance.Spec.RoleBindingsOnly || !isGroupOutput(instance) {
+			for _, group := range providerGroups[i] {
+
+				if !isGroupOutput(instance) {
+					if r.isProtectedGroup(group.Name) {
+						logger.Info("Warning: Skipping Protected Group", "Provider", groupSyncer.GetProviderName(), "Group Name", group.Name)
+						recordReportSkippedGroup(providerReport, group.Name, redhatcopv1alpha1.ProtectedGroupSkipReason)
+						continue
+					}
+
+					configMapGroups = append(configMapGroups, configMapGroup{Name: group.Name, Provider: groupSyncer.GetProviderName(), Users: plan.SortedUniqueStrings(group.Users)})
+				}
+
+				roleBindingGroups[group.Name] = groupSyncer.GetProviderName()
+
+				if parentGroupName := group.Annotations[constants.HierarchyParent]; parentGroupName != "" {
+					roleBindingGroupParents[group.Name] = parentGroupName
+				}
+			}
+
+			if !instance.Spec.DryRun {
+				if err := groupSyncMgr.SaveCheckpoints(groupSyncer.GetProviderName()); err != nil {
+					logger.Error(err, "Failed to Save Checkpoints")
+					setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, err)
+					return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, report, err)
+				}
+			}
+
+			logger.Info("Sync Completed Successfully Without Writing Groups", "Provider", groupSyncer.GetProviderName(), "Groups Found", len(providerGroups[i]))
+			setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, nil)
+			setProviderHealthy(instance, groupSyncer.GetProviderName())
+			getProviderStatus(instance, groupSyncer.GetProviderName()).LastSyncSuccessTime = &metav1.Time{Time: clock.Now()}
+			recordSuccessfulSync(prometheusLabels, providerResult.duration+time.Since(writeStartTime))
+			continue
 		}
 
 		updatedGroups := 0
 		prunedGroups := 0
+		var pruneErr error
+
+		dryRunProvider := redhatcopv1alpha1.DryRunProviderStatus{Name: groupSyncer.GetProviderName()}
+
+		// Existing groups without users deleted according to the empty group policy of the provider
+		emptyGroups := map[string]bool{}
+		emptyGroupPolicy := plan.GetEmptyGroupPolicy(instance, groupSyncer.GetProviderName())
 
-		for _, group := range groups {
+		// Members of the applied groups for which Users are provisioned
+		syncedUsers := []string{}
+
+		// Groups returned by the provider that are synchronized by another GroupSync or provider
+		ownershipConflicts := []string{}
+
+		for _, group := range providerGroups[i] {
+
+			if r.isProtectedGroup(group.Name) {
+				logger.Info("Warning: Skipping Protected Group", "Provider", groupSyncer.GetProviderName(), "Group Name", group.Name)
+				recordReportSkippedGroup(providerReport, group.Name, redhatcopv1alpha1.ProtectedGroupSkipReason)
+				continue
+			}
 
 			ocpGroup := &userv1.Group{}
 			err := r.GetClient().Get(context, types.NamespacedName{Name: group.Name, Namespace: ""}, ocpGroup)
+			groupExists := err == nil
 
 			if apierrors.IsNotFound(err) {
 
@@ -135,15 +409,30 @@ func (r *GroupSyncReconciler) Reconcile(context context.Context, req ctrl.Reques
 				ocpGroup.Name = group.Name
 
 			} else if err != nil {
-				return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, err)
+				setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, err)
+				return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, report, err)
 			} else {
 				// Verify this group is not managed by another provider
-				if groupProviderLabel, exists := ocpGroup.Labels[constants.SyncProvider]; !exists || (groupProviderLabel != providerLabel) {
-					log.Info("Group Provider Label Did Not Match Expected Provider Label", "Group Name", ocpGroup.Name, "Expected Label", providerLabel, "Found Label", groupProviderLabel)
+				if groupProviderLabel, exists := ocpGroup.Labels[constants.SyncProvider]; !exists || (groupProviderLabel != providerLabel && !isMigrationProviderLabel(instance, groupProviderLabel) && !isLowerPriorityProviderLabel(instance, groupSyncer.GetProviderName(), groupProviderLabel)) {
+					logger.Info("Group Provider Label Did Not Match Expected Provider Label", "Group Name", ocpGroup.Name, "Expected Label", providerLabel, "Found Label", groupProviderLabel)
+					if exists {
+						ownershipConflicts = append(ownershipConflicts, ocpGroup.Name)
+					}
+					recordReportSkippedGroup(providerReport, ocpGroup.Name, redhatcopv1alpha1.OtherProviderGroupSkipReason)
+					continue
+				}
+
+				// Verify this group is not managed by a GroupSync in another namespace
+				if isOwnedByOtherGroupSync(instance, ocpGroup) {
+					logger.Info("Group Is Owned By Another GroupSync", "Group Name", ocpGroup.Name, "GroupSync Namespace", ocpGroup.Labels[constants.GroupSyncNamespace])
+					ownershipConflicts = append(ownershipConflicts, ocpGroup.Name)
+					recordReportSkippedGroup(providerReport, ocpGroup.Name, redhatcopv1alpha1.OtherGroupSyncGroupSkipReason)
 					continue
 				}
 			}
 
+			existingGroup := ocpGroup.DeepCopy()
+
 			// Copy Annotations/Labels
 			ocpGroupLabels := map[string]string{}
 			ocpGroupAnnotations := map[string]string{}
@@ -158,102 +447,390 @@ func (r *GroupSyncReconciler) Reconcile(context context.Context, req ctrl.Reques
 			ocpGroup.SetLabels(mergeMap(ocpGroup.GetLabels(), ocpGroupLabels))
 			ocpGroup.SetAnnotations(mergeMap(ocpGroup.GetAnnotations(), ocpGroupAnnotations))
 
-			// Add Label for new resource
+			// Add Labels for new resource
 			ocpGroup.Labels[constants.SyncProvider] = providerLabel
+			setOwnershipLabels(ocpGroup, instance, groupSyncer.GetProviderName())
 
 			// Add Gloabl Annotations/Labels
 			ocpGroup.Annotations[constants.SyncTimestamp] = ISO8601(time.Now())
 
-			ocpGroup.Users = group.Users
+			// Users are deduplicated and sorted as providers may return the same user several times and in any order
+			users := plan.SortedUniqueStrings(group.Users)
+			ocpGroup.Users = plan.GetGroupUsers(instance, groupSyncer.GetProviderName(), existingGroup, users, logger)
+
+			// Keep users added manually to the group
+			if plan.IsPreservingUnmanagedUsers(instance, groupSyncer.GetProviderName()) {
+				if err := setSyncedUsers(ocpGroup, users); err != nil {
+					setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, err)
+					return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, report, err)
+				}
+			} else {
+				delete(ocpGroup.Annotations, constants.SyncUsers)
+			}
+
+			if len(ocpGroup.Users) == 0 && emptyGroupPolicy != redhatcopv1alpha1.CreateEmptyGroupPolicy {
+
+				if !groupExists {
+					logger.Info("Skipping Empty Group", "Provider", groupSyncer.GetProviderName(), "Group Name", ocpGroup.Name)
+					recordReportSkippedGroup(providerReport, ocpGroup.Name, redhatcopv1alpha1.EmptyGroupSkipReason)
+					continue
+				}
 
-			err = r.CreateOrUpdateResource(context, nil, "", ocpGroup)
+				if emptyGroupPolicy == redhatcopv1alpha1.PruneEmptyGroupPolicy {
+					emptyGroups[ocpGroup.Name] = true
+					continue
+				}
+			}
+
+			change := getDryRunChange(existingGroup, ocpGroup, groupExists)
+			membershipChange := getMembershipChange(groupSyncer.GetProviderName(), ocpGroup.Name, existingGroup.Users, ocpGroup.Users, instance.Spec.MembershipChangesUserLimit)
+
+			if instance.Spec.DryRun {
+				dryRunSyncedGroups[ocpGroup.Name] = true
+				recordDryRunChange(&dryRunProvider, ocpGroup.Name, change)
+				recordReportChange(providerReport, change)
+				recordReportMembershipChange(providerReport, membershipChange)
+				continue
+			}
+
+			err = r.applyGroup(context, ocpGroup, group)
 
 			if err != nil {
-				log.Error(err, "Failed to Create or Update OpenShift Group")
-				return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, err)
+				logger.Error(err, "Failed to Apply OpenShift Group")
+				setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, err)
+				return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, report, err)
+			}
+
+			// Groups returned again by the provider are no longer candidates for pruning nor quarantined
+			if hasPruneMarkers(existingGroup) {
+				if err := r.clearPruneMarkers(context, existingGroup); err != nil {
+					logger.Error(err, "Failed to Clear Prune Markers")
+					setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, err)
+					return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, report, err)
+				}
+			}
+
+			recordReportChange(providerReport, change)
+			recordReportMembershipChange(providerReport, membershipChange)
+
+			if membershipChange != nil {
+				r.auditMembershipChange(instance, groupSyncer.GetProviderName(), ocpGroup.Name, existingGroup.Users, ocpGroup.Users, audit.SynchronizedReason, logger)
+				r.recordEvent(context, instance, "Normal", membershipChangedEventReason, getMembershipChangeEventMessage(membershipChange))
+				membershipChanges = appendMembershipChange(membershipChanges, *membershipChange)
 			}
 
+			syncedUsers = append(syncedUsers, users...)
 			updatedGroups++
 		}
 
-		if groupSyncer.GetPrune() {
+		ownershipConflict := getOwnershipConflict(ownershipConflicts)
+		setOwnershipConflictCondition(instance, groupSyncer.GetProviderName(), ownershipConflict)
+
+		if ownershipConflict != nil {
+			r.recordEvent(context, instance, "Warning", redhatcopv1alpha1.OwnershipConflictReason, fmt.Sprintf("Provider '%s': %s", groupSyncer.GetProviderName(), ownershipConflict.Error()))
+
+			if getOwnershipConflictPolicy(instance) == redhatcopv1alpha1.ErrorOwnershipConflictPolicy {
+				logger.Error(ownershipConflict, "Groups Synchronized by Another GroupSync or Provider", "Provider", groupSyncer.GetProviderName())
+				setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.OwnershipConflictReason, ownershipConflict)
+				recordUnsuccessfulSync(prometheusLabels)
+				providerReport.Error = ownershipConflict.Error()
+				providerErrors = append(providerErrors, fmt.Errorf("Failed to synchronize provider '%s': %w", groupSyncer.GetProviderName(), ownershipConflict))
+				continue
+			}
+
+			logger.Info("Warning: Skipping Groups Synchronized by Another GroupSync or Provider", "Provider", groupSyncer.GetProviderName(), "Reason", ownershipConflict.Error())
+		}
+
+		if err := r.provisionUsers(context, instance, groupSyncer.GetProviderName(), syncedUsers, logger); err != nil {
+			logger.Error(err, "Failed to Provision Users")
+			setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, err)
+			return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, report, err)
+		}
+
+		if groupSyncer.GetPrune() || len(emptyGroups) > 0 {
 			logger.Info("Start Pruning Groups")
-			prunedGroups, err = r.pruneGroups(context, instance, providerLabel, syncStartTime, logger)
-			if err != nil {
-				log.Error(err, "Failed to Prune Group")
-				return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, err)
+			isStale := func(group userv1.Group) bool {
+				return emptyGroups[group.Name]
+			}
+
+			if groupSyncer.GetPrune() {
+				isStale = func(group userv1.Group) bool {
+					return emptyGroups[group.Name] || group.Annotations[constants.SyncTimestamp] < syncStartTime
+				}
+
+				// Timestamps are not updated by a dry run
+				if instance.Spec.DryRun {
+					isStale = func(group userv1.Group) bool {
+						return emptyGroups[group.Name] || !dryRunSyncedGroups[group.Name]
+					}
+				}
 			}
-			logger.Info("Pruning Completed")
+
+			prunedGroupNames, err := r.pruneGroups(context, instance, providerLabel, isStale, emptyGroups, logger)
+			prunedGroups = len(prunedGroupNames)
+			providerReport.Pruned = prunedGroups
+
+			if _, exceeded := err.(*pruneThresholdExceededError); exceeded {
+				logger.Info("Warning: Skipping Pruning", "Provider", groupSyncer.GetProviderName(), "Reason", err.Error())
+				r.recordEvent(context, instance, "Warning", redhatcopv1alpha1.PruneThresholdExceededReason, fmt.Sprintf("Provider '%s': %s", groupSyncer.GetProviderName(), err.Error()))
+				providerReport.Error = err.Error()
+				pruneErr = err
+			} else if err != nil {
+				logger.Error(err, "Failed to Prune Group")
+				setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, err)
+				return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, report, err)
+			} else {
+				logger.Info("Pruning Completed")
+			}
+
+			if instance.Spec.DryRun {
+				recordDryRunPrunes(&dryRunProvider, prunedGroupNames)
+			}
+		}
+
+		if instance.Spec.DryRun {
+			logger.Info("Dry Run Completed Successfully", "Provider", groupSyncer.GetProviderName(), "Groups Created", dryRunProvider.Created, "Groups Updated", dryRunProvider.Updated, "Groups Pruned", dryRunProvider.Pruned)
+			r.recordEvent(context, instance, "Normal", dryRunEventReason, getDryRunEventMessage(&dryRunProvider))
+			dryRunStatus.Providers = append(dryRunStatus.Providers, dryRunProvider)
+		} else {
+			logger.Info("Sync Completed Successfully", "Provider", groupSyncer.GetProviderName(), "Groups Created or Updated", updatedGroups, "Groups Pruned", prunedGroups)
 		}
 
-		logger.Info("Sync Completed Successfully", "Provider", groupSyncer.GetProviderName(), "Groups Created or Updated", updatedGroups, "Groups Pruned", prunedGroups)
+		if !instance.Spec.DryRun {
+			if err := groupSyncMgr.SaveCheckpoints(groupSyncer.GetProviderName()); err != nil {
+				logger.Error(err, "Failed to Save Checkpoints")
+				setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, err)
+				return r.wrapMetricsErrorWithMetrics(prometheusLabels, context, instance, report, err)
+			}
+		}
+
+		setProviderCondition(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.SyncedProviderCondition, redhatcopv1alpha1.SyncSucceededReason, redhatcopv1alpha1.SyncFailedReason, nil)
+		if pruneErr != nil {
+			setProviderDegraded(instance, groupSyncer.GetProviderName(), redhatcopv1alpha1.PruneThresholdExceededReason, pruneErr)
+		} else {
+			setProviderHealthy(instance, groupSyncer.GetProviderName())
+		}
+		getProviderStatus(instance, groupSyncer.GetProviderName()).LastSyncSuccessTime = &metav1.Time{Time: clock.Now()}
 
 		// Add Metrics
 
-		successfulGroupSyncs.With(prometheusLabels).Inc()
-		groupsSynchronized.With(prometheusLabels).Set(float64(updatedGroups))
-		groupSyncError.With(prometheusLabels).Set(0)
-		if groupSyncer.GetPrune() {
-			groupsPruned.With(prometheusLabels).Set(float64(prunedGroups))
+		recordSuccessfulSync(prometheusLabels, providerResult.duration+time.Since(writeStartTime))
+		if !instance.Spec.DryRun {
+			recordSyncedGroups(prometheusLabels, providerGroups[i])
+			recordSyncChanges(prometheusLabels, providerReport)
+			groupsSynchronized.With(prometheusLabels).Set(float64(updatedGroups))
+			if groupSyncer.GetPrune() {
+				groupsPruned.With(prometheusLabels).Set(float64(prunedGroups))
+			}
+		}
+	}
+
+	if dryRunStatus != nil {
+		dryRunStatus.LastDryRunTime = &metav1.Time{Time: clock.Now()}
+	}
+	instance.Status.DryRun = dryRunStatus
+
+	if !instance.Spec.DryRun {
+		instance.Status.MembershipChanges = membershipChanges
+	}
+
+	if len(providerErrors) > 0 {
+		r.writeSyncReport(context, instance, report, providerErrors)
+		return r.manageSyncError(context, instance, utilerrors.NewAggregate(providerErrors))
+	}
+
+	// The groups ConfigMap, group namespaces, role bindings, the ClusterRoleBindings of role mappings and the groups of remote clusters
+	// are not changed by a dry run
+	if !instance.Spec.DryRun {
+		if !isGroupOutput(instance) {
+			if err := r.writeGroupsConfigMap(context, instance, configMapGroups, logger); err != nil {
+				logger.Error(err, "Failed to Write Groups ConfigMap")
+				r.writeSyncReport(context, instance, report, []error{err})
+				return r.ManageError(context, instance, err)
+			}
+		} else if !instance.Spec.RoleBindingsOnly {
+			if roleBindingGroups, roleBindingGroupParents, err = r.getSyncedGroupProviders(context, instance); err != nil {
+				return r.ManageError(context, instance, err)
+			}
+		}
+
+		groupNamespaceRoleBindings, err := r.syncGroupNamespaces(context, instance, roleBindingGroups, roleBindingGroupParents, logger)
+
+		if err != nil {
+			logger.Error(err, "Failed to Synchronize Group Namespaces")
+			r.writeSyncReport(context, instance, report, []error{err})
+			return r.ManageError(context, instance, err)
+		}
+
+		if err := r.syncRoleBindings(context, instance, roleBindingGroups, groupNamespaceRoleBindings, logger); err != nil {
+			logger.Error(err, "Failed to Synchronize RoleBindings")
+			r.writeSyncReport(context, instance, report, []error{err})
+			return r.ManageError(context, instance, err)
+		}
+
+		if err := r.syncClusterRoleBindings(context, instance, roleBindingGroups, logger); err != nil {
+			logger.Error(err, "Failed to Synchronize ClusterRoleBindings")
+			r.writeSyncReport(context, instance, report, []error{err})
+			return r.ManageError(context, instance, err)
+		}
+
+		if err := r.distributeGroups(context, instance, logger); err != nil {
+			logger.Error(err, "Failed to Distribute Groups")
+			r.writeSyncReport(context, instance, report, []error{err})
+			return r.ManageError(context, instance, err)
 		}
 	}
 
+	if instance.Spec.Migration != nil {
+		// Providers synchronized on their own schedule may not have been synchronized
+		if len(migrationGroups) == len(instance.Spec.Migration.Providers) {
+			instance.Status.Migration = compareMigrationProviders(instance.Spec.Migration, migrationGroups)
+		}
+	} else {
+		instance.Status.Migration = nil
+	}
+
 	instance.Status.LastSyncSuccessTime = &metav1.Time{Time: clock.Now()}
+	instance.Status.ObservedGeneration = instance.GetGeneration()
+	resetRetries(instance)
 
-	successResult, err := r.ManageSuccess(context, instance)
+	r.writeSyncReport(context, instance, report, nil)
 
-	if err == nil && instance.Spec.Schedule != "" {
-		sched, _ := cron.ParseStandard(instance.Spec.Schedule)
+	successResult, err := r.ManageSuccess(context, instance)
 
-		currentTime := time.Now()
-		nextScheduledTime := sched.Next(currentTime)
-		nextScheduledSynchronization.With(prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName()}).Set(float64(nextScheduledTime.UTC().Unix()))
-		successResult.RequeueAfter = nextScheduledTime.Sub(currentTime)
+	if err == nil {
+		r.scheduleNextSync(instance, schedule, providerSchedules)
 	}
 
 	return successResult, err
 }
 
 func (r *GroupSyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
+
+	if r.Scheduler == nil {
+		r.Scheduler = scheduler.NewScheduler()
+	}
+
+	r.referenceChanges = newReferenceChanges()
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&redhatcopv1alpha1.GroupSync{}).
-		WithEventFilter(util.ResourceGenerationOrFinalizerChangedPredicate{}).
+		For(&redhatcopv1alpha1.GroupSync{}, builder.WithPredicates(predicate.Or(util.ResourceGenerationOrFinalizerChangedPredicate{}, syncRequestedPredicate{}, shardOwnershipChangedPredicate{shard: r.Shard}), predicate.NewPredicateFuncs(r.Shard.Owns))).
+		Watches(r.Scheduler.Source(), &handler.EnqueueRequestForObject{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.findGroupSyncsReferencingObject), builder.WithPredicates(referencedObjectChangedPredicate)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.findGroupSyncsReferencingObject), builder.WithPredicates(referencedObjectChangedPredicate)).
 		Complete(r)
 }
 
-func (r *GroupSyncReconciler) wrapMetricsErrorWithMetrics(prometheusLabels prometheus.Labels, context context.Context, obj client.Object, issue error) (ctrl.Result, error) {
+func (r *GroupSyncReconciler) wrapMetricsErrorWithMetrics(prometheusLabels prometheus.Labels, context context.Context, instance *redhatcopv1alpha1.GroupSync, report *redhatcopv1alpha1.GroupSyncReport, issue error) (ctrl.Result, error) {
+
+	recordUnsuccessfulSync(prometheusLabels)
+
+	getProviderReport(report, prometheusLabels[METRICS_PROVIDER_LABEL]).Error = issue.Error()
+	r.writeSyncReport(context, instance, report, []error{issue})
+
+	return r.manageSyncError(context, instance, issue)
+}
 
+func recordUnsuccessfulSync(prometheusLabels prometheus.Labels) {
 	unsuccessfulGroupSyncs.With(prometheusLabels).Inc()
+	syncErrors.With(prometheusLabels).Inc()
 	groupSyncError.With(prometheusLabels).Set(1)
-
-	return r.ManageError(context, obj, issue)
 }
 
-func (r *GroupSyncReconciler) pruneGroups(context context.Context, instance *redhatcopv1alpha1.GroupSync, providerLabel string, syncStartTime string, logger logr.Logger) (int, error) {
-	prunedGroups := 0
+// pruneGroups deletes or quarantines the stale groups managed by the provider and returns their names. When a prune grace period
+// is set, only stale groups whose grace period elapsed are pruned. When performing a dry run, the groups that would be pruned are returned
+// without being deleted
+func (r *GroupSyncReconciler) pruneGroups(context context.Context, instance *redhatcopv1alpha1.GroupSync, providerLabel string, isStale func(group userv1.Group) bool, emptyGroups map[string]bool, logger logr.Logger) ([]string, error) {
+	prunedGroups := []string{}
 	ocpGroups := &userv1.GroupList{}
 	opts := []client.ListOption{
 		client.InNamespace(""),
-		client.MatchingLabels{constants.SyncProvider: providerLabel},
+		getOwnershipSelector(instance, providerLabel),
 	}
 	err := r.GetClient().List(context, ocpGroups, opts...)
 	if err != nil {
 		return prunedGroups, err
 	}
 
+	managedGroups := 0
+	staleGroups := []userv1.Group{}
+
 	for _, group := range ocpGroups.Items {
-		if group.Annotations[constants.SyncTimestamp] < syncStartTime {
+		if r.isProtectedGroup(group.Name) {
+			logger.Info("pruneGroups", "Skip Protected Group", group.Name)
+			continue
+		}
+		// Quarantined groups are left untouched until returned again by the provider
+		if group.Labels[constants.Orphaned] == "true" {
+			continue
+		}
+		managedGroups++
+		if isStale(group) {
+			staleGroups = append(staleGroups, group)
+		}
+	}
+
+	staleGroups, err = r.getExpiredPruneCandidates(context, instance, staleGroups, emptyGroups, logger)
+	if err != nil {
+		return prunedGroups, err
+	}
+
+	if instance.Spec.PruneMaxDelete != nil {
+		pruneMaxDelete, err := intstr.GetValueFromIntOrPercent(instance.Spec.PruneMaxDelete, managedGroups, false)
+		if err != nil {
+			return prunedGroups, err
+		}
+
+		if len(staleGroups) > pruneMaxDelete {
+			return prunedGroups, &pruneThresholdExceededError{staleGroups: len(staleGroups), managedGroups: managedGroups, pruneMaxDelete: instance.Spec.PruneMaxDelete}
+		}
+	}
+
+	for _, group := range staleGroups {
+		prunedGroups = append(prunedGroups, group.Name)
+		if instance.Spec.DryRun {
+			continue
+		}
+		reason := audit.PrunedReason
+		if instance.Spec.PruneMode == redhatcopv1alpha1.QuarantinePruneMode {
+			logger.Info("pruneGroups", "Quarantine Group", group.Name)
+			reason = audit.QuarantinedReason
+			err = r.quarantineGroup(context, group.DeepCopy())
+		} else {
 			logger.Info("pruneGroups", "Delete Group", group.Name)
 			err = r.GetClient().Delete(context, &group)
-			prunedGroups++
-			if err != nil {
-				return prunedGroups, err
-			}
 		}
+		if err != nil {
+			return prunedGroups, err
+		}
+		r.auditRemovedGroup(instance, &group, reason, logger)
 	}
 	return prunedGroups, nil
 }
 
+// pruneThresholdExceededError indicates that pruning was skipped as more groups would have been deleted than allowed
+type pruneThresholdExceededError struct {
+	staleGroups    int
+	managedGroups  int
+	pruneMaxDelete *intstr.IntOrString
+}
+
+func (e *pruneThresholdExceededError) Error() string {
+	return fmt.Sprintf("Refusing to prune %d of %d groups as the prune max delete of '%s' would be exceeded", e.staleGroups, e.managedGroups, e.pruneMaxDelete.String())
+}
+
+// isProtectedGroup determines whether the name of a group matches one of the protected prefixes
+func (r *GroupSyncReconciler) isProtectedGroup(groupName string) bool {
+
+	for _, protectedGroupPrefix := range r.ProtectedGroupPrefixes {
+		if strings.HasPrefix(groupName, protectedGroupPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func ISO8601(t time.Time) string {
 	var tz string
 	if zone, offset := t.Zone(); zone == "UTC" {
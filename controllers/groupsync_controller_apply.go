@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// groupFieldManager is the field manager owning the fields of Groups applied by the operator
+const groupFieldManager = "group-sync-operator"
+
+// applyGroup applies the fields of the Group managed by the operator using server-side apply. Only the users along with the labels and
+// annotations set by the operator and the provider are owned by the operator so that labels and annotations added to the Group by other
+// controllers or administrators are preserved. Fields previously applied by the operator and no longer set are removed
+func (r *GroupSyncReconciler) applyGroup(context context.Context, ocpGroup *userv1.Group, providerGroup userv1.Group) error {
+
+	managedLabels := []string{constants.SyncProvider, constants.GroupSyncName, constants.GroupSyncNamespace, constants.ProviderName}
+	for label := range providerGroup.GetLabels() {
+		managedLabels = append(managedLabels, label)
+	}
+
+	managedAnnotations := []string{constants.SyncTimestamp, constants.SyncUsers}
+	for annotation := range providerGroup.GetAnnotations() {
+		managedAnnotations = append(managedAnnotations, annotation)
+	}
+
+	appliedGroup := &userv1.Group{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Group",
+			APIVersion: userv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ocpGroup.Name,
+			Labels:      selectKeys(ocpGroup.GetLabels(), managedLabels),
+			Annotations: selectKeys(ocpGroup.GetAnnotations(), managedAnnotations),
+		},
+		Users: ocpGroup.Users,
+	}
+
+	// Users are always applied so that the operator owns the membership of the Group even when it is empty
+	if appliedGroup.Users == nil {
+		appliedGroup.Users = userv1.OptionalNames{}
+	}
+
+	return r.GetClient().Patch(context, appliedGroup, client.Apply, client.FieldOwner(groupFieldManager), client.ForceOwnership)
+}
+
+// selectKeys returns the entries of the map whose key is one of the keys
+func selectKeys(values map[string]string, keys []string) map[string]string {
+
+	selected := map[string]string{}
+
+	for _, key := range keys {
+		if value, found := values[key]; found {
+			selected[key] = value
+		}
+	}
+
+	return selected
+}
@@ -0,0 +1,25 @@
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/audit"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/plan"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// auditMembershipChange records the users added to and removed from a group in the audit log. The group has already been written, so
+// that failing to record the change is logged rather than failing the synchronization
+func (r *GroupSyncReconciler) auditMembershipChange(instance *redhatcopv1alpha1.GroupSync, providerName string, groupName string, existingUsers []string, users []string, reason audit.Reason, logger logr.Logger) {
+
+	if err := r.AuditLog.RecordMembershipChange(instance.Namespace, instance.Name, providerName, groupName, plan.StringsDifference(users, existingUsers), plan.StringsDifference(existingUsers, users), reason); err != nil {
+		logger.Error(err, "Failed to Record Membership Change in Audit Log", "Group Name", groupName)
+	}
+}
+
+// auditRemovedGroup records the removal of all of the users of a group deleted or quarantined by the operator in the audit log
+func (r *GroupSyncReconciler) auditRemovedGroup(instance *redhatcopv1alpha1.GroupSync, group *userv1.Group, reason audit.Reason, logger logr.Logger) {
+	r.auditMembershipChange(instance, group.Labels[constants.ProviderName], group.Name, group.Users, []string{}, reason, logger)
+}
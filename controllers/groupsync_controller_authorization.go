@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+)
+
+// sharedWithAllNamespaces shares a secret or config map with the GroupSyncs of every namespace
+const sharedWithAllNamespaces = "*"
+
+// ReferencePolicy authorizes the secrets and config maps that GroupSyncs reference outside of their namespace, so that credentials can
+// be centralized in one namespace without allowing every GroupSync to read any secret of the cluster. A GroupSync may reference a
+// secret or config map of another namespace when its namespace is trusted or when the secret or config map is shared with its namespace
+// using the shared-namespaces annotation
+type ReferencePolicy struct {
+	// TrustedNamespaces contains the namespaces whose GroupSyncs may reference secrets and config maps of any namespace
+	TrustedNamespaces map[string]bool
+}
+
+// NewReferencePolicy returns the policy authorizing references to other namespaces, or nil when references are not restricted
+func NewReferencePolicy(restricted bool, trustedNamespaces string) *ReferencePolicy {
+
+	if !restricted {
+		return nil
+	}
+
+	policy := &ReferencePolicy{TrustedNamespaces: map[string]bool{}}
+
+	for _, namespace := range strings.Split(trustedNamespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			policy.TrustedNamespaces[namespace] = true
+		}
+	}
+
+	return policy
+}
+
+// Authorize verifies that the GroupSync may read each secret and config map it references outside of its namespace. All references are
+// authorized when references are not restricted
+func (p *ReferencePolicy) Authorize(context context.Context, c client.Client, instance *redhatcopv1alpha1.GroupSync) error {
+
+	if p == nil || p.TrustedNamespaces[instance.Namespace] {
+		return nil
+	}
+
+	for _, objectRef := range getObjectRefs(instance) {
+
+		if objectRef.Namespace == instance.Namespace {
+			continue
+		}
+
+		shared, err := isSharedWithNamespace(context, c, objectRef, instance.Namespace)
+
+		if err != nil {
+			return err
+		}
+
+		// Objects that do not exist are reported as not shared so that GroupSyncs cannot discover the objects of other namespaces
+		if !shared {
+			return fmt.Errorf("%s '%s' in namespace '%s' is not shared with namespace '%s'", getObjectRefKind(objectRef), objectRef.Name, objectRef.Namespace, instance.Namespace)
+		}
+	}
+
+	return nil
+}
+
+// isSharedWithNamespace determines whether the shared-namespaces annotation of the referenced secret or config map lists the namespace
+func isSharedWithNamespace(context context.Context, c client.Client, objectRef *redhatcopv1alpha1.ObjectRef, namespace string) (bool, error) {
+
+	var object client.Object = &corev1.Secret{}
+	if getObjectRefKind(objectRef) == redhatcopv1alpha1.ConfigMapObjectRefKind {
+		object = &corev1.ConfigMap{}
+	}
+
+	if err := c.Get(context, types.NamespacedName{Name: objectRef.Name, Namespace: objectRef.Namespace}, object); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, sharedNamespace := range strings.Split(object.GetAnnotations()[constants.SharedNamespaces], ",") {
+		if sharedNamespace = strings.TrimSpace(sharedNamespace); sharedNamespace == namespace || sharedNamespace == sharedWithAllNamespaces {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
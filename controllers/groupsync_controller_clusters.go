@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// distributeGroups distributes the groups synchronized by the GroupSync to each of its remote clusters. Failing to distribute the groups
+// to a remote cluster is recorded in the status of the cluster without preventing the distribution to the remaining clusters
+func (r *GroupSyncReconciler) distributeGroups(context context.Context, instance *redhatcopv1alpha1.GroupSync, logger logr.Logger) error {
+
+	if len(instance.Spec.Clusters) == 0 {
+		return nil
+	}
+
+	ocpGroups := &userv1.GroupList{}
+
+	if err := r.GetClient().List(context, ocpGroups, client.InNamespace(""), getGroupSyncSelector(instance)); err != nil {
+		return err
+	}
+
+	for i := range instance.Spec.Clusters {
+
+		cluster := &instance.Spec.Clusters[i]
+		clusterStatus := getClusterStatus(instance, cluster.Name)
+
+		condition := metav1.Condition{
+			Type:               redhatcopv1alpha1.DistributedClusterCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             redhatcopv1alpha1.DistributionSucceededReason,
+			ObservedGeneration: instance.GetGeneration(),
+		}
+
+		distributedGroups, err := r.distributeClusterGroups(context, instance, cluster, ocpGroups.Items, logger)
+
+		if err != nil {
+			logger.Error(err, "Failed to Distribute Groups", "Cluster", cluster.Name)
+			r.recordEvent(context, instance, "Warning", redhatcopv1alpha1.DistributionFailedReason, fmt.Sprintf("Cluster '%s': %s", cluster.Name, err.Error()))
+
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = redhatcopv1alpha1.DistributionFailedReason
+			condition.Message = err.Error()
+		} else {
+			logger.Info("Groups Distributed Successfully", "Cluster", cluster.Name, "Groups Distributed", distributedGroups)
+			clusterStatus.Groups = distributedGroups
+			clusterStatus.LastSyncSuccessTime = &metav1.Time{Time: clock.Now()}
+		}
+
+		meta.SetStatusCondition(&clusterStatus.Conditions, condition)
+	}
+
+	return nil
+}
+
+// distributeClusterGroups applies the groups to the remote cluster and deletes the groups previously distributed by the GroupSync that
+// are no longer provided. Existing groups of the remote cluster not distributed by the GroupSync are left untouched. Returns the number
+// of distributed groups
+func (r *GroupSyncReconciler) distributeClusterGroups(context context.Context, instance *redhatcopv1alpha1.GroupSync, cluster *redhatcopv1alpha1.RemoteCluster, groups []userv1.Group, logger logr.Logger) (int, error) {
+
+	remoteClient, err := r.getRemoteClusterClient(context, cluster)
+
+	if err != nil {
+		return 0, err
+	}
+
+	distributedGroups := map[string]bool{}
+
+	for i := range groups {
+
+		group := &groups[i]
+
+		existingGroup := &userv1.Group{}
+		err := remoteClient.Get(context, types.NamespacedName{Name: group.Name}, existingGroup)
+
+		if err != nil && !apierrors.IsNotFound(err) {
+			return 0, err
+		}
+
+		if err == nil && (existingGroup.Labels[constants.GroupSyncName] != instance.Name || existingGroup.Labels[constants.GroupSyncNamespace] != instance.Namespace) {
+			logger.Info("Warning: Skipping Remote Group Not Distributed By GroupSync", "Cluster", cluster.Name, "Group Name", group.Name)
+			continue
+		}
+
+		remoteGroup := &userv1.Group{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Group",
+				APIVersion: userv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        group.Name,
+				Labels:      group.Labels,
+				Annotations: group.Annotations,
+			},
+			Users: group.Users,
+		}
+
+		if remoteGroup.Users == nil {
+			remoteGroup.Users = userv1.OptionalNames{}
+		}
+
+		if err := remoteClient.Patch(context, remoteGroup, client.Apply, client.FieldOwner(groupFieldManager), client.ForceOwnership); err != nil {
+			return 0, err
+		}
+
+		distributedGroups[group.Name] = true
+	}
+
+	existingGroups := &userv1.GroupList{}
+
+	if err := remoteClient.List(context, existingGroups, client.InNamespace(""), getGroupSyncSelector(instance)); err != nil {
+		return 0, err
+	}
+
+	for i := range existingGroups.Items {
+
+		existingGroup := &existingGroups.Items[i]
+
+		if distributedGroups[existingGroup.Name] {
+			continue
+		}
+
+		logger.Info("distributeClusterGroups", "Cluster", cluster.Name, "Delete Group", existingGroup.Name)
+		if err := remoteClient.Delete(context, existingGroup); err != nil && !apierrors.IsNotFound(err) {
+			return 0, err
+		}
+	}
+
+	return len(distributedGroups), nil
+}
+
+// deleteDistributedGroups deletes the groups distributed by the GroupSync from each of its remote clusters. Remote clusters that cannot
+// be reached are skipped so that the GroupSync can still be deleted
+func (r *GroupSyncReconciler) deleteDistributedGroups(context context.Context, instance *redhatcopv1alpha1.GroupSync, logger logr.Logger) {
+
+	for i := range instance.Spec.Clusters {
+
+		cluster := &instance.Spec.Clusters[i]
+
+		if _, err := r.distributeClusterGroups(context, instance, cluster, []userv1.Group{}, logger); err != nil {
+			logger.Error(err, "Failed to Delete Distributed Groups", "Cluster", cluster.Name)
+		}
+	}
+}
+
+// getRemoteClusterClient creates a client for the remote cluster using the kubeconfig of its secret
+func (r *GroupSyncReconciler) getRemoteClusterClient(context context.Context, cluster *redhatcopv1alpha1.RemoteCluster) (client.Client, error) {
+
+	secret := &corev1.Secret{}
+
+	if err := r.GetClient().Get(context, types.NamespacedName{Name: cluster.KubeConfigSecret.Name, Namespace: cluster.KubeConfigSecret.Namespace}, secret); err != nil {
+		return nil, err
+	}
+
+	key := cluster.KubeConfigSecret.Key
+
+	if key == "" {
+		key = syncer.DefaultKubeConfigKey
+	}
+
+	kubeConfig, found := secret.Data[key]
+
+	if !found {
+		return nil, fmt.Errorf("Could not find '%s' key in kubeconfig secret '%s' in namespace '%s'", key, cluster.KubeConfigSecret.Name, cluster.KubeConfigSecret.Namespace)
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+
+	if err != nil {
+		return nil, fmt.Errorf("Invalid kubeconfig in secret '%s' in namespace '%s': %w", cluster.KubeConfigSecret.Name, cluster.KubeConfigSecret.Namespace, err)
+	}
+
+	return client.New(restConfig, client.Options{Scheme: r.GetScheme()})
+}
+
+// getClusterStatus returns the status of the remote cluster, adding it to the status of the GroupSync when not found
+func getClusterStatus(instance *redhatcopv1alpha1.GroupSync, clusterName string) *redhatcopv1alpha1.ClusterStatus {
+
+	for i := range instance.Status.Clusters {
+		if instance.Status.Clusters[i].Name == clusterName {
+			return &instance.Status.Clusters[i]
+		}
+	}
+
+	instance.Status.Clusters = append(instance.Status.Clusters, redhatcopv1alpha1.ClusterStatus{Name: clusterName})
+
+	return &instance.Status.Clusters[len(instance.Status.Clusters)-1]
+}
+
+// pruneClusterStatuses removes the status of remote clusters that are no longer configured
+func pruneClusterStatuses(instance *redhatcopv1alpha1.GroupSync) {
+
+	clusterStatuses := []redhatcopv1alpha1.ClusterStatus{}
+
+	for _, clusterStatus := range instance.Status.Clusters {
+		for _, cluster := range instance.Spec.Clusters {
+			if cluster.Name == clusterStatus.Name {
+				clusterStatuses = append(clusterStatuses, clusterStatus)
+				break
+			}
+		}
+	}
+
+	instance.Status.Clusters = clusterStatuses
+}
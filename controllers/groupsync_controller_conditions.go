@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// setProviderCondition records the outcome of a step in the synchronization of a provider. A failed step also marks the provider as
+// degraded using the same reason and message
+func setProviderCondition(instance *redhatcopv1alpha1.GroupSync, providerName string, conditionType string, successReason string, failureReason string, issue error) {
+
+	providerStatus := getProviderStatus(instance, providerName)
+
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             successReason,
+		ObservedGeneration: instance.GetGeneration(),
+	}
+
+	if issue != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = failureReason
+		condition.Message = issue.Error()
+
+		setProviderDegraded(instance, providerName, failureReason, issue)
+	}
+
+	meta.SetStatusCondition(&providerStatus.Conditions, condition)
+}
+
+// setProviderDegraded marks a provider as degraded
+func setProviderDegraded(instance *redhatcopv1alpha1.GroupSync, providerName string, reason string, issue error) {
+
+	providerStatus := getProviderStatus(instance, providerName)
+
+	meta.SetStatusCondition(&providerStatus.Conditions, metav1.Condition{
+		Type:               redhatcopv1alpha1.DegradedProviderCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            issue.Error(),
+		ObservedGeneration: instance.GetGeneration(),
+	})
+}
+
+// setProviderHealthy marks a provider that completed a synchronization as no longer degraded
+func setProviderHealthy(instance *redhatcopv1alpha1.GroupSync, providerName string) {
+
+	providerStatus := getProviderStatus(instance, providerName)
+
+	meta.SetStatusCondition(&providerStatus.Conditions, metav1.Condition{
+		Type:               redhatcopv1alpha1.DegradedProviderCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             redhatcopv1alpha1.AsExpectedReason,
+		ObservedGeneration: instance.GetGeneration(),
+	})
+}
+
+// getProviderStatus returns the status of the provider, adding it to the status of the GroupSync when not found
+func getProviderStatus(instance *redhatcopv1alpha1.GroupSync, providerName string) *redhatcopv1alpha1.ProviderStatus {
+
+	for i := range instance.Status.Providers {
+		if instance.Status.Providers[i].Name == providerName {
+			return &instance.Status.Providers[i]
+		}
+	}
+
+	instance.Status.Providers = append(instance.Status.Providers, redhatcopv1alpha1.ProviderStatus{Name: providerName})
+
+	return &instance.Status.Providers[len(instance.Status.Providers)-1]
+}
+
+// pruneProviderStatuses removes the status of providers that are no longer configured
+func pruneProviderStatuses(instance *redhatcopv1alpha1.GroupSync) {
+
+	providerStatuses := []redhatcopv1alpha1.ProviderStatus{}
+
+	for _, providerStatus := range instance.Status.Providers {
+		for _, provider := range instance.Spec.Providers {
+			if provider.Name == providerStatus.Name {
+				providerStatuses = append(providerStatuses, providerStatus)
+				break
+			}
+		}
+	}
+
+	instance.Status.Providers = providerStatuses
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redhat-cop/group-sync-operator/pkg/plan"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// ownershipConflictError indicates that groups returned by a provider are synchronized by another GroupSync or provider
+type ownershipConflictError struct {
+	groupNames []string
+}
+
+func (e *ownershipConflictError) Error() string {
+	return fmt.Sprintf("Groups synchronized by another GroupSync or provider: %s", strings.Join(e.groupNames, ", "))
+}
+
+// getOwnershipConflict returns the conflict of the groups synchronized by another GroupSync or provider, if any
+func getOwnershipConflict(groupNames []string) error {
+
+	if len(groupNames) == 0 {
+		return nil
+	}
+
+	return &ownershipConflictError{groupNames: plan.SortedUniqueStrings(groupNames)}
+}
+
+// setOwnershipConflictCondition records whether groups returned by the provider are synchronized by another GroupSync or provider
+func setOwnershipConflictCondition(instance *redhatcopv1alpha1.GroupSync, providerName string, conflict error) {
+
+	providerStatus := getProviderStatus(instance, providerName)
+
+	condition := metav1.Condition{
+		Type:               redhatcopv1alpha1.OwnershipConflictProviderCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             redhatcopv1alpha1.NoOwnershipConflictReason,
+		ObservedGeneration: instance.GetGeneration(),
+	}
+
+	if conflict != nil {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = redhatcopv1alpha1.OwnershipConflictReason
+		condition.Message = conflict.Error()
+	}
+
+	meta.SetStatusCondition(&providerStatus.Conditions, condition)
+}
+
+func getOwnershipConflictPolicy(instance *redhatcopv1alpha1.GroupSync) redhatcopv1alpha1.OwnershipConflictPolicy {
+
+	if instance.Spec.OwnershipConflictPolicy == "" {
+		return redhatcopv1alpha1.FirstOwnerWinsOwnershipConflictPolicy
+	}
+
+	return instance.Spec.OwnershipConflictPolicy
+}
@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// ProviderCheckPath is the path of the endpoint reporting whether the providers of the GroupSyncs can be bound
+const ProviderCheckPath = "/providerz"
+
+// defaultProviderCheckTimeout is the time waited for a provider to bind when the provider does not specify a timeout
+const defaultProviderCheckTimeout = 30 * time.Second
+
+// ProviderChecker periodically binds to the providers of the GroupSyncs between synchronizations so that expired or revoked
+// credentials are reported before the next synchronization fails. The outcome is recorded in the Bound condition of each provider,
+// exposed as a metric and served by an endpoint returning an error while any provider cannot be bound
+type ProviderChecker struct {
+	// Reconciler provides the client, CA bundle and shard of the GroupSyncs checked
+	Reconciler *GroupSyncReconciler
+	// Interval is the time between checks
+	Interval time.Duration
+	Log      logr.Logger
+	// failures contains the errors binding providers during the last check by GroupSync and provider
+	failures map[string]string
+	mutex    sync.RWMutex
+}
+
+// Start checks the providers every interval until the context is done
+func (c *ProviderChecker) Start(context context.Context) error {
+
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		c.check(context)
+
+		select {
+		case <-context.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection ensures that only the leader binds to the providers and updates the status of the GroupSyncs
+func (c *ProviderChecker) NeedLeaderElection() bool {
+	return true
+}
+
+// ServeHTTP reports the providers that could not be bound during the last check
+func (c *ProviderChecker) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if len(c.failures) == 0 {
+		writer.WriteHeader(http.StatusOK)
+		fmt.Fprintln(writer, "ok")
+		return
+	}
+
+	providers := []string{}
+	for provider := range c.failures {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	writer.WriteHeader(http.StatusServiceUnavailable)
+	for _, provider := range providers {
+		fmt.Fprintf(writer, "%s: %s\n", provider, c.failures[provider])
+	}
+}
+
+// check binds to the providers of each GroupSync of the shard
+func (c *ProviderChecker) check(context context.Context) {
+
+	groupSyncs := &redhatcopv1alpha1.GroupSyncList{}
+
+	if err := c.Reconciler.GetClient().List(context, groupSyncs); err != nil {
+		c.Log.Error(err, "Failed to List GroupSyncs")
+		return
+	}
+
+	failures := map[string]string{}
+
+	for i := range groupSyncs.Items {
+		instance := &groupSyncs.Items[i]
+
+		if !c.Reconciler.Shard.Owns(instance) || instance.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		bindErrors := c.checkProviders(context, instance)
+
+		for providerName, bindError := range bindErrors {
+			if bindError != nil {
+				failures[fmt.Sprintf("%s/%s/%s", instance.Namespace, instance.Name, providerName)] = bindError.Error()
+			}
+		}
+
+		if len(bindErrors) == 0 {
+			continue
+		}
+
+		if err := c.updateStatus(context, client.ObjectKeyFromObject(instance), bindErrors); err != nil {
+			c.Log.Error(err, "Failed to Record Provider Connectivity", "groupsync", client.ObjectKeyFromObject(instance))
+		}
+	}
+
+	c.mutex.Lock()
+	c.failures = failures
+	c.mutex.Unlock()
+}
+
+// checkProviders binds to each valid provider of the GroupSync and returns the errors binding the providers by provider name
+func (c *ProviderChecker) checkProviders(context context.Context, instance *redhatcopv1alpha1.GroupSync) map[string]error {
+
+	logger := c.Log.WithValues("groupsync", client.ObjectKeyFromObject(instance))
+	bindErrors := map[string]error{}
+
+	if err := c.Reconciler.ReferencePolicy.Authorize(context, c.Reconciler.GetClient(), instance); err != nil {
+		logger.Info("Warning: Skipping Connectivity Check of GroupSync With Unauthorized References", "Error", err.Error())
+		return bindErrors
+	}
+
+	groupSyncMgr, err := syncer.GetGroupSyncMgr(context, instance, c.Reconciler.ReconcilerBase, c.Reconciler.CaBundle)
+
+	if err != nil {
+		logger.Info("Warning: Skipping Connectivity Check of GroupSync That Could Not Be Initialized", "Error", err.Error())
+		return bindErrors
+	}
+
+	// Defaults are only applied to the copy of the GroupSync checked and are persisted by the reconciler
+	groupSyncMgr.SetDefaults()
+
+	providerValidationErrors, _ := groupSyncMgr.Validate()
+	timeouts := getProviderTimeouts(instance)
+
+	for _, groupSyncer := range groupSyncMgr.GroupSyncers {
+
+		if providerValidationErrors[groupSyncer.GetProviderName()] != nil {
+			continue
+		}
+
+		timeout, found := timeouts[groupSyncer.GetProviderName()]
+		if !found {
+			timeout = defaultProviderCheckTimeout
+		}
+
+		bindError := bindWithTimeout(context, &groupSyncMgr, groupSyncer.GetProviderName(), timeout)
+
+		if bindError != nil {
+			logger.Error(bindError, "Failed to Bind During Connectivity Check", "Provider", groupSyncer.GetProviderName())
+		}
+
+		prometheusLabels := prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName(), METRICS_PROVIDER_LABEL: groupSyncer.GetProviderName()}
+		recordProviderConnectivity(prometheusLabels, bindError)
+
+		bindErrors[groupSyncer.GetProviderName()] = bindError
+	}
+
+	return bindErrors
+}
+
+// updateStatus records the outcome of binding to the providers in the status of the GroupSync, retrying when the GroupSync was
+// updated concurrently by the reconciler
+func (c *ProviderChecker) updateStatus(context context.Context, key client.ObjectKey, bindErrors map[string]error) error {
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+
+		instance := &redhatcopv1alpha1.GroupSync{}
+
+		if err := c.Reconciler.GetClient().Get(context, key, instance); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		checkTime := metav1.Time{Time: clock.Now()}
+
+		for providerName, bindError := range bindErrors {
+			setProviderCondition(instance, providerName, redhatcopv1alpha1.BoundProviderCondition, redhatcopv1alpha1.BindSucceededReason, redhatcopv1alpha1.BindFailedReason, bindError)
+			getProviderStatus(instance, providerName).LastConnectivityCheckTime = &checkTime
+		}
+
+		return c.Reconciler.GetClient().Status().Update(context, instance)
+	})
+}
+
+// bindWithTimeout validates and binds to the provider using a new syncer whose context expires once the timeout elapsed, giving up once
+// the timeout elapsed as clients of providers that are not context aware cannot be cancelled
+func bindWithTimeout(checkContext context.Context, groupSyncMgr *syncer.GroupSyncMgr, providerName string, timeout time.Duration) error {
+
+	timeoutContext, cancel := context.WithTimeout(checkContext, timeout)
+	defer cancel()
+
+	// Buffered so that an abandoned provider does not block once it completes
+	result := make(chan error, 1)
+
+	go func() {
+		groupSyncer, err := groupSyncMgr.GetProviderSyncer(timeoutContext, providerName)
+		if err == nil {
+			err = groupSyncer.Validate()
+		}
+		if err == nil {
+			err = groupSyncer.Bind()
+		}
+		result <- err
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-timeoutContext.Done():
+		return fmt.Errorf("Timed out after %s binding to the provider", timeout.String())
+	}
+}
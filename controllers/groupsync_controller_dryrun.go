@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"fmt"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+const (
+	dryRunEventReason    = "DryRun"
+	dryRunMaxGroupNames  = 100
+	dryRunGroupCreated   = "created"
+	dryRunGroupUpdated   = "updated"
+	dryRunGroupUnchanged = ""
+)
+
+// getDryRunChange determines whether a group would be created or updated by comparing the existing group with the desired group
+func getDryRunChange(existingGroup *userv1.Group, desiredGroup *userv1.Group, exists bool) string {
+
+	if !exists {
+		return dryRunGroupCreated
+	}
+
+	if !equalStrings(existingGroup.Users, desiredGroup.Users) || !equalMaps(existingGroup.GetLabels(), desiredGroup.GetLabels()) || !equalMaps(withoutSyncTimestamp(existingGroup.GetAnnotations()), withoutSyncTimestamp(desiredGroup.GetAnnotations())) {
+		return dryRunGroupUpdated
+	}
+
+	return dryRunGroupUnchanged
+}
+
+// recordDryRunChange records the change that would be made to a group for a provider
+func recordDryRunChange(dryRunProvider *redhatcopv1alpha1.DryRunProviderStatus, groupName string, change string) {
+
+	switch change {
+	case dryRunGroupCreated:
+		dryRunProvider.Created++
+		dryRunProvider.CreatedGroups = appendDryRunGroupName(dryRunProvider.CreatedGroups, groupName)
+	case dryRunGroupUpdated:
+		dryRunProvider.Updated++
+		dryRunProvider.UpdatedGroups = appendDryRunGroupName(dryRunProvider.UpdatedGroups, groupName)
+	}
+}
+
+// recordDryRunPrunes records the groups that would be pruned for a provider
+func recordDryRunPrunes(dryRunProvider *redhatcopv1alpha1.DryRunProviderStatus, groupNames []string) {
+
+	dryRunProvider.Pruned = len(groupNames)
+
+	for _, groupName := range groupNames {
+		dryRunProvider.PrunedGroups = appendDryRunGroupName(dryRunProvider.PrunedGroups, groupName)
+	}
+}
+
+func appendDryRunGroupName(groupNames []string, groupName string) []string {
+
+	if len(groupNames) >= dryRunMaxGroupNames {
+		return groupNames
+	}
+
+	return append(groupNames, groupName)
+}
+
+func getDryRunEventMessage(dryRunProvider *redhatcopv1alpha1.DryRunProviderStatus) string {
+	return fmt.Sprintf("Provider '%s' would create %d, update %d and prune %d groups", dryRunProvider.Name, dryRunProvider.Created, dryRunProvider.Updated, dryRunProvider.Pruned)
+}
+
+func withoutSyncTimestamp(annotations map[string]string) map[string]string {
+
+	result := map[string]string{}
+
+	for key, value := range annotations {
+		if key != constants.SyncTimestamp {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+func equalStrings(s1, s2 []string) bool {
+
+	if len(s1) != len(s2) {
+		return false
+	}
+
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalMaps(m1, m2 map[string]string) bool {
+
+	if len(m1) != len(m2) {
+		return false
+	}
+
+	for key, value := range m1 {
+		if otherValue, found := m2[key]; !found || otherValue != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/audit"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// manageFinalizer adds the finalizer when the groups of the GroupSync are deleted along with it and removes the finalizer otherwise.
+// Returns whether the GroupSync was updated
+func (r *GroupSyncReconciler) manageFinalizer(context context.Context, instance *redhatcopv1alpha1.GroupSync) (bool, error) {
+
+	hasFinalizer := util.HasFinalizer(instance, constants.GroupSyncFinalizer)
+
+	if instance.Spec.DeletionPolicy == redhatcopv1alpha1.DeleteDeletionPolicy && !hasFinalizer {
+		util.AddFinalizer(instance, constants.GroupSyncFinalizer)
+	} else if instance.Spec.DeletionPolicy != redhatcopv1alpha1.DeleteDeletionPolicy && hasFinalizer {
+		util.RemoveFinalizer(instance, constants.GroupSyncFinalizer)
+	} else {
+		return false, nil
+	}
+
+	return true, r.GetClient().Update(context, instance)
+}
+
+// deleteSyncedGroups deletes the groups synchronized by the providers of the GroupSync, or their ConfigMap, along with the RoleBindings and
+// ClusterRoleBindings maintained for the GroupSync, the pruned group namespaces and the groups distributed to remote clusters, before
+// removing its finalizer. Groups are retained when running in dry run mode
+func (r *GroupSyncReconciler) deleteSyncedGroups(context context.Context, instance *redhatcopv1alpha1.GroupSync, logger logr.Logger) error {
+
+	if !util.HasFinalizer(instance, constants.GroupSyncFinalizer) {
+		return nil
+	}
+
+	if instance.Spec.DeletionPolicy == redhatcopv1alpha1.DeleteDeletionPolicy && !instance.Spec.DryRun {
+		if isGroupOutput(instance) {
+			for _, provider := range instance.Spec.Providers {
+				if err := r.deleteProviderGroups(context, instance, fmt.Sprintf("%s_%s", instance.Name, provider.Name), logger); err != nil {
+					return err
+				}
+			}
+		} else if err := r.deleteGroupsConfigMap(context, instance, logger); err != nil {
+			return err
+		}
+
+		r.deleteDistributedGroups(context, instance, logger)
+
+		if err := r.pruneRoleBindings(context, instance, map[types.NamespacedName]bool{}, logger); err != nil {
+			return err
+		}
+
+		if err := r.pruneClusterRoleBindings(context, instance, map[string]bool{}, logger); err != nil {
+			return err
+		}
+
+		if instance.Spec.GroupNamespaces != nil && instance.Spec.GroupNamespaces.Prune {
+			if err := r.pruneSubnamespaceAnchors(context, instance, map[types.NamespacedName]bool{}, logger); err != nil {
+				return err
+			}
+
+			if err := r.pruneGroupNamespaces(context, instance, map[string]bool{}, logger); err != nil {
+				return err
+			}
+		}
+	}
+
+	util.RemoveFinalizer(instance, constants.GroupSyncFinalizer)
+
+	return r.GetClient().Update(context, instance)
+}
+
+func (r *GroupSyncReconciler) deleteProviderGroups(context context.Context, instance *redhatcopv1alpha1.GroupSync, providerLabel string, logger logr.Logger) error {
+
+	ocpGroups := &userv1.GroupList{}
+	opts := []client.ListOption{
+		client.InNamespace(""),
+		getOwnershipSelector(instance, providerLabel),
+	}
+
+	if err := r.GetClient().List(context, ocpGroups, opts...); err != nil {
+		return err
+	}
+
+	for i := range ocpGroups.Items {
+		if r.isProtectedGroup(ocpGroups.Items[i].Name) {
+			logger.Info("deleteProviderGroups", "Skip Protected Group", ocpGroups.Items[i].Name)
+			continue
+		}
+
+		logger.Info("deleteProviderGroups", "Delete Group", ocpGroups.Items[i].Name)
+		if err := r.GetClient().Delete(context, &ocpGroups.Items[i]); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		r.auditRemovedGroup(instance, &ocpGroups.Items[i], audit.DeletedReason, logger)
+	}
+
+	return nil
+}
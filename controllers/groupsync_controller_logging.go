@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// syncRunIDKey is the key of the ID of a synchronization in contexts
+type syncRunIDKey struct{}
+
+// withSyncRunID returns a context identifying the synchronization using a new ID, so that the lines logged and the events recorded
+// while reconciling a GroupSync can be correlated when several GroupSyncs and providers are synchronized concurrently
+func withSyncRunID(parent context.Context) (context.Context, string) {
+
+	syncRunID := string(uuid.NewUUID())
+
+	return context.WithValue(parent, syncRunIDKey{}, syncRunID), syncRunID
+}
+
+// getSyncRunID returns the ID of the synchronization of the context, or an empty string when the context identifies no synchronization
+func getSyncRunID(parent context.Context) string {
+
+	syncRunID, _ := parent.Value(syncRunIDKey{}).(string)
+
+	return syncRunID
+}
+
+// recordEvent records an event of the GroupSync annotated with the ID of the synchronization of the context
+func (r *GroupSyncReconciler) recordEvent(context context.Context, instance *redhatcopv1alpha1.GroupSync, eventType string, reason string, message string) {
+
+	annotations := map[string]string{}
+
+	if syncRunID := getSyncRunID(context); syncRunID != "" {
+		annotations[constants.SyncRunID] = syncRunID
+	}
+
+	r.GetRecorder().AnnotatedEventf(instance, annotations, eventType, reason, "%s", message)
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/plan"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+const (
+	membershipChangedEventReason = "MembershipChanged"
+	membershipChangesMaxGroups   = 100
+)
+
+// getMembershipChange compares the existing and synchronized members of a group. Nil is returned when the members did not change
+func getMembershipChange(providerName string, groupName string, existingUsers []string, users []string, userLimit int) *redhatcopv1alpha1.GroupMembershipChange {
+
+	addedUsers := plan.StringsDifference(users, existingUsers)
+	removedUsers := plan.StringsDifference(existingUsers, users)
+
+	if len(addedUsers) == 0 && len(removedUsers) == 0 {
+		return nil
+	}
+
+	membershipChange := &redhatcopv1alpha1.GroupMembershipChange{
+		Name:     groupName,
+		Provider: providerName,
+		Added:    len(addedUsers),
+		Removed:  len(removedUsers),
+	}
+
+	if userLimit > 0 {
+		membershipChange.AddedUsers = limitStrings(addedUsers, userLimit)
+		membershipChange.RemovedUsers = limitStrings(removedUsers, userLimit)
+	}
+
+	return membershipChange
+}
+
+// appendMembershipChange appends the change unless the maximum number of groups reported in the status has been reached
+func appendMembershipChange(membershipChanges []redhatcopv1alpha1.GroupMembershipChange, membershipChange redhatcopv1alpha1.GroupMembershipChange) []redhatcopv1alpha1.GroupMembershipChange {
+
+	if len(membershipChanges) >= membershipChangesMaxGroups {
+		return membershipChanges
+	}
+
+	return append(membershipChanges, membershipChange)
+}
+
+func getMembershipChangeEventMessage(membershipChange *redhatcopv1alpha1.GroupMembershipChange) string {
+	return fmt.Sprintf("Group '%s' synchronized by provider '%s': %d users added, %d users removed", membershipChange.Name, membershipChange.Provider, membershipChange.Added, membershipChange.Removed)
+}
+
+func limitStrings(values []string, limit int) []string {
+
+	if len(values) > limit {
+		return values[:limit]
+	}
+
+	return values
+}
+
+// setSyncedUsers records the users synchronized by the provider in an annotation of the group
+func setSyncedUsers(group *userv1.Group, users []string) error {
+
+	syncedUsers, err := json.Marshal(users)
+
+	if err != nil {
+		return err
+	}
+
+	group.Annotations[constants.SyncUsers] = string(syncedUsers)
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"fmt"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/plan"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// mergeProviderGroups resolves the groups returned by more than one provider according to the group merge strategy and returns the
+// groups to write for each provider. Providers that failed are not considered
+func mergeProviderGroups(instance *redhatcopv1alpha1.GroupSync, groupSyncers []syncer.GroupSyncer, providerResults []providerResult) ([][]userv1.Group, map[string]error) {
+
+	providerNames := make([]string, len(providerResults))
+	providerGroups := make([][]userv1.Group, len(providerResults))
+	failedProviders := map[string]bool{}
+
+	for i, result := range providerResults {
+		providerNames[i] = groupSyncers[i].GetProviderName()
+		providerGroups[i] = result.groups
+
+		if result.bindError != nil || result.syncError != nil {
+			failedProviders[providerNames[i]] = true
+		}
+	}
+
+	return plan.MergeProviderGroups(instance, providerNames, providerGroups, failedProviders)
+}
+
+// isLowerPriorityProviderLabel determines whether a group is managed by a provider declared after the provider so that ownership of the
+// group can be transferred to the provider
+func isLowerPriorityProviderLabel(instance *redhatcopv1alpha1.GroupSync, providerName string, groupProviderLabel string) bool {
+
+	if plan.GetGroupMergeStrategy(instance) == redhatcopv1alpha1.ErrorGroupMergeStrategy {
+		return false
+	}
+
+	declared := false
+
+	for _, provider := range instance.Spec.Providers {
+		if provider.Name == providerName {
+			declared = true
+		} else if declared && groupProviderLabel == fmt.Sprintf("%s_%s", instance.Name, provider.Name) {
+			return true
+		}
+	}
+
+	return false
+}
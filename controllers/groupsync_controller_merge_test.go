@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// namedSyncer is a syncer only providing the name of a provider
+type namedSyncer struct {
+	syncer.GroupSyncer
+	name string
+}
+
+func (s *namedSyncer) GetProviderName() string {
+	return s.name
+}
+
+func newGroup(name string, users ...string) userv1.Group {
+	return userv1.Group{ObjectMeta: metav1.ObjectMeta{Name: name}, Users: users}
+}
+
+func TestMergeProviderGroups(t *testing.T) {
+
+	groupSyncers := []syncer.GroupSyncer{&namedSyncer{name: "first"}, &namedSyncer{name: "second"}}
+
+	tests := []struct {
+		name              string
+		strategy          redhatcopv1alpha1.GroupMergeStrategy
+		migration         *redhatcopv1alpha1.Migration
+		results           []providerResult
+		expectedGroups    [][]userv1.Group
+		expectedConflicts []string
+	}{
+		{
+			name: "distinct groups are kept",
+			results: []providerResult{
+				{groups: []userv1.Group{newGroup("a", "alice")}},
+				{groups: []userv1.Group{newGroup("b", "bob")}},
+			},
+			expectedGroups: [][]userv1.Group{{newGroup("a", "alice")}, {newGroup("b", "bob")}},
+		},
+		{
+			name: "priority is the default strategy",
+			results: []providerResult{
+				{groups: []userv1.Group{newGroup("shared", "alice")}},
+				{groups: []userv1.Group{newGroup("shared", "bob"), newGroup("b", "bob")}},
+			},
+			expectedGroups: [][]userv1.Group{{newGroup("shared", "alice")}, {newGroup("b", "bob")}},
+		},
+		{
+			name:     "priority keeps the group of the provider declared first",
+			strategy: redhatcopv1alpha1.PriorityGroupMergeStrategy,
+			results: []providerResult{
+				{groups: []userv1.Group{newGroup("shared", "alice")}},
+				{groups: []userv1.Group{newGroup("shared", "bob")}},
+			},
+			expectedGroups: [][]userv1.Group{{newGroup("shared", "alice")}, {}},
+		},
+		{
+			name:     "union merges the users into the provider declared first",
+			strategy: redhatcopv1alpha1.UnionGroupMergeStrategy,
+			results: []providerResult{
+				{groups: []userv1.Group{newGroup("shared", "alice", "bob")}},
+				{groups: []userv1.Group{newGroup("shared", "bob", "carol")}},
+			},
+			expectedGroups: [][]userv1.Group{{newGroup("shared", "alice", "bob", "carol")}, {}},
+		},
+		{
+			name:     "error reports a conflict for each provider",
+			strategy: redhatcopv1alpha1.ErrorGroupMergeStrategy,
+			results: []providerResult{
+				{groups: []userv1.Group{newGroup("shared", "alice")}},
+				{groups: []userv1.Group{newGroup("shared", "bob")}},
+			},
+			expectedGroups:    [][]userv1.Group{{newGroup("shared", "alice")}, {newGroup("shared", "bob")}},
+			expectedConflicts: []string{"first", "second"},
+		},
+		{
+			name:     "failed providers are not merged",
+			strategy: redhatcopv1alpha1.UnionGroupMergeStrategy,
+			results: []providerResult{
+				{groups: []userv1.Group{newGroup("shared", "alice")}, syncError: errors.New("failed")},
+				{groups: []userv1.Group{newGroup("shared", "bob")}},
+			},
+			expectedGroups: [][]userv1.Group{{newGroup("shared", "alice")}, {newGroup("shared", "bob")}},
+		},
+		{
+			name:      "shadow providers are not merged",
+			strategy:  redhatcopv1alpha1.ErrorGroupMergeStrategy,
+			migration: &redhatcopv1alpha1.Migration{Providers: []string{"first", "second"}, SourceOfTruth: "second"},
+			results: []providerResult{
+				{groups: []userv1.Group{newGroup("shared", "alice")}},
+				{groups: []userv1.Group{newGroup("shared", "bob")}},
+			},
+			expectedGroups: [][]userv1.Group{{newGroup("shared", "alice")}, {newGroup("shared", "bob")}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			instance := &redhatcopv1alpha1.GroupSync{
+				ObjectMeta: metav1.ObjectMeta{Name: "merge"},
+				Spec:       redhatcopv1alpha1.GroupSyncSpec{GroupMergeStrategy: test.strategy, Migration: test.migration},
+			}
+
+			groups, conflicts := mergeProviderGroups(instance, groupSyncers, test.results)
+
+			for i := range test.expectedGroups {
+				if len(groups[i]) != len(test.expectedGroups[i]) || (len(groups[i]) > 0 && !reflect.DeepEqual(groups[i], test.expectedGroups[i])) {
+					t.Errorf("expected groups %v for provider '%s', found %v", test.expectedGroups[i], groupSyncers[i].GetProviderName(), groups[i])
+				}
+			}
+
+			if len(conflicts) != len(test.expectedConflicts) {
+				t.Fatalf("expected conflicts for providers %v, found %v", test.expectedConflicts, conflicts)
+			}
+
+			for _, providerName := range test.expectedConflicts {
+				if conflicts[providerName] == nil {
+					t.Errorf("expected a conflict for provider '%s'", providerName)
+				}
+			}
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package controllers
 
 import (
+	"time"
+
+	userv1 "github.com/openshift/api/user/v1"
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
 )
 
 const (
@@ -47,14 +52,181 @@ var (
 		},
 		[]string{METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
 
+	missedScheduledSynchronizations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "group_sync_missed_scheduled_syncs_count",
+			Help: "Number of Missed Scheduled Synchronizations",
+		},
+		[]string{METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
 	groupSyncError = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "group_sync_error",
 			Help: "Error Occurred During Group Synchronization",
 		},
 		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	syncDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "groupsync_sync_duration_seconds",
+			Help:    "Duration of Synchronizations in Seconds",
+			Buckets: []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800},
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	groupsSynced = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "groupsync_groups_synced",
+			Help: "Number of Groups Synchronized During the Last Synchronization",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	usersSynced = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "groupsync_users_synced",
+			Help: "Number of Distinct Users Synchronized During the Last Synchronization",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	syncErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "groupsync_sync_errors_total",
+			Help: "Number of Errors Occurred During Synchronizations",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	lastSuccessfulSync = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "groupsync_last_successful_sync_timestamp",
+			Help: "Time of the Last Successful Synchronization in Seconds Since the Epoch",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	usersAdded = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "groupsync_users_added",
+			Help: "Number of Users Added to Groups During the Last Synchronization",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	usersRemoved = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "groupsync_users_removed",
+			Help: "Number of Users Removed from Groups During the Last Synchronization",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	groupsCreated = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "groupsync_groups_created",
+			Help: "Number of Groups Created During the Last Synchronization",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	groupsPrunedLastSync = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "groupsync_groups_pruned",
+			Help: "Number of Groups Pruned During the Last Synchronization",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	usersAddedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "groupsync_users_added_total",
+			Help: "Number of Users Added to Groups",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	usersRemovedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "groupsync_users_removed_total",
+			Help: "Number of Users Removed from Groups",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	groupsCreatedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "groupsync_groups_created_total",
+			Help: "Number of Groups Created",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	groupsPrunedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "groupsync_groups_pruned_total",
+			Help: "Number of Groups Pruned",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	providerConnected = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "groupsync_provider_connected",
+			Help: "Whether the Provider Could Be Bound During the Last Connectivity Check",
+		},
+		[]string{METRICS_PROVIDER_LABEL, METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	syncAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "groupsync_sync_age_seconds",
+			Help: "Time Elapsed Since the Last Successful Synchronization in Seconds",
+		},
+		[]string{METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
+
+	syncStale = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "groupsync_sync_stale",
+			Help: "Whether the Last Successful Synchronization Is Older Than the Stale Sync Intervals",
+		},
+		[]string{METRICS_CR_NAMESPACE_LABEL, METRICS_CR_NAME_LABEL})
 )
 
 func init() {
-	metrics.Registry.MustRegister(successfulGroupSyncs, unsuccessfulGroupSyncs, groupsSynchronized, nextScheduledSynchronization, groupSyncError)
+	metrics.Registry.MustRegister(successfulGroupSyncs, unsuccessfulGroupSyncs, groupsSynchronized, groupsPruned, nextScheduledSynchronization, missedScheduledSynchronizations, groupSyncError,
+		syncDuration, groupsSynced, usersSynced, syncErrors, lastSuccessfulSync, providerConnected,
+		usersAdded, usersRemoved, groupsCreated, groupsPrunedLastSync, usersAddedTotal, usersRemovedTotal, groupsCreatedTotal, groupsPrunedTotal,
+		syncAge, syncStale)
+}
+
+func recordSuccessfulSync(prometheusLabels prometheus.Labels, duration time.Duration) {
+	successfulGroupSyncs.With(prometheusLabels).Inc()
+	groupSyncError.With(prometheusLabels).Set(0)
+	syncDuration.With(prometheusLabels).Observe(duration.Seconds())
+}
+
+func recordSyncedGroups(prometheusLabels prometheus.Labels, groups []userv1.Group) {
+
+	users := map[string]bool{}
+	for _, group := range groups {
+		for _, user := range group.Users {
+			users[user] = true
+		}
+	}
+
+	groupsSynced.With(prometheusLabels).Set(float64(len(groups)))
+	usersSynced.With(prometheusLabels).Set(float64(len(users)))
+	lastSuccessfulSync.With(prometheusLabels).SetToCurrentTime()
+}
+
+// recordSyncChanges records the changes made by the synchronization of a provider so that unusually large changes can be alerted on
+func recordSyncChanges(prometheusLabels prometheus.Labels, providerReport *redhatcopv1alpha1.ProviderReport) {
+
+	usersAdded.With(prometheusLabels).Set(float64(providerReport.UsersAdded))
+	usersRemoved.With(prometheusLabels).Set(float64(providerReport.UsersRemoved))
+	groupsCreated.With(prometheusLabels).Set(float64(providerReport.Created))
+	groupsPrunedLastSync.With(prometheusLabels).Set(float64(providerReport.Pruned))
+
+	usersAddedTotal.With(prometheusLabels).Add(float64(providerReport.UsersAdded))
+	usersRemovedTotal.With(prometheusLabels).Add(float64(providerReport.UsersRemoved))
+	groupsCreatedTotal.With(prometheusLabels).Add(float64(providerReport.Created))
+	groupsPrunedTotal.With(prometheusLabels).Add(float64(providerReport.Pruned))
+}
+
+func recordProviderConnectivity(prometheusLabels prometheus.Labels, bindError error) {
+
+	if bindError != nil {
+		providerConnected.With(prometheusLabels).Set(0)
+		return
+	}
+
+	providerConnected.With(prometheusLabels).Set(1)
 }
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/pkg/plan"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// isMigrationProviderLabel determines whether a group is managed by a migration provider so that ownership can be transferred when the source of truth changes
+func isMigrationProviderLabel(instance *redhatcopv1alpha1.GroupSync, groupProviderLabel string) bool {
+
+	if instance.Spec.Migration == nil {
+		return false
+	}
+
+	for _, migrationProvider := range instance.Spec.Migration.Providers {
+		if groupProviderLabel == fmt.Sprintf("%s_%s", instance.Name, migrationProvider) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compareMigrationProviders compares the groups of each migration provider against the source of truth
+func compareMigrationProviders(migration *redhatcopv1alpha1.Migration, providerGroups map[string][]userv1.Group) *redhatcopv1alpha1.MigrationStatus {
+
+	migrationStatus := &redhatcopv1alpha1.MigrationStatus{
+		SourceOfTruth:      migration.SourceOfTruth,
+		Comparisons:        []redhatcopv1alpha1.ProviderComparison{},
+		LastComparisonTime: &metav1.Time{Time: clock.Now()},
+	}
+
+	sourceGroups := groupUsersByName(providerGroups[migration.SourceOfTruth])
+
+	for _, providerName := range migration.Providers {
+
+		if providerName == migration.SourceOfTruth {
+			continue
+		}
+
+		migrationStatus.Comparisons = append(migrationStatus.Comparisons, compareGroups(providerName, sourceGroups, groupUsersByName(providerGroups[providerName])))
+	}
+
+	return migrationStatus
+}
+
+func compareGroups(providerName string, sourceGroups, providerGroups map[string][]string) redhatcopv1alpha1.ProviderComparison {
+
+	comparison := redhatcopv1alpha1.ProviderComparison{
+		Provider: providerName,
+	}
+
+	for _, groupName := range sortedKeys(sourceGroups) {
+
+		providerUsers, found := providerGroups[groupName]
+
+		if !found {
+			comparison.MissingGroups = append(comparison.MissingGroups, groupName)
+			continue
+		}
+
+		missingUsers := plan.StringsDifference(sourceGroups[groupName], providerUsers)
+		additionalUsers := plan.StringsDifference(providerUsers, sourceGroups[groupName])
+
+		if len(missingUsers) > 0 || len(additionalUsers) > 0 {
+			comparison.MembershipDifferences = append(comparison.MembershipDifferences, redhatcopv1alpha1.GroupMembershipDifference{
+				Name:            groupName,
+				MissingUsers:    missingUsers,
+				AdditionalUsers: additionalUsers,
+			})
+		}
+	}
+
+	for _, groupName := range sortedKeys(providerGroups) {
+		if _, found := sourceGroups[groupName]; !found {
+			comparison.AdditionalGroups = append(comparison.AdditionalGroups, groupName)
+		}
+	}
+
+	return comparison
+}
+
+func groupUsersByName(groups []userv1.Group) map[string][]string {
+
+	groupUsers := map[string][]string{}
+
+	for _, group := range groups {
+		groupUsers[group.Name] = append(groupUsers[group.Name], group.Users...)
+	}
+
+	return groupUsers
+}
+
+func sortedKeys(m map[string][]string) []string {
+
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,322 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+const (
+	// hncSubnamespaceOf annotates the subnamespaces created by HNC with the name of their parent namespace
+	hncSubnamespaceOf = "hnc.x-k8s.io/subnamespace-of"
+	// hncInheritedFrom labels the objects propagated by HNC from an ancestor namespace
+	hncInheritedFrom = "hnc.x-k8s.io/inherited-from"
+)
+
+var subnamespaceAnchorGVK = schema.GroupVersionKind{Group: "hnc.x-k8s.io", Version: "v1alpha2", Kind: "SubnamespaceAnchor"}
+
+// syncGroupNamespaces creates the namespaces of the synchronized groups and binds the ClusterRole of the group namespaces to the
+// groups in their namespace. When the hierarchy of groups is mirrored, the namespaces of groups with a parent group are created as HNC
+// subnamespaces of the namespace of the parent group. The RoleBindings maintained in the namespaces are returned so that they are
+// retained when pruning the role bindings of the GroupSync
+func (r *GroupSyncReconciler) syncGroupNamespaces(context context.Context, instance *redhatcopv1alpha1.GroupSync, groupProviders map[string]string, groupParents map[string]string, logger logr.Logger) (map[types.NamespacedName]bool, error) {
+
+	roleBindings := map[types.NamespacedName]bool{}
+
+	if instance.Spec.GroupNamespaces == nil {
+		return roleBindings, nil
+	}
+
+	renderer, err := syncer.NewGroupNamespaceRenderer(instance.Spec.GroupNamespaces)
+
+	if err != nil {
+		return roleBindings, err
+	}
+
+	// Several groups may be rendered to the same namespace
+	namespaceGroups := map[string][]string{}
+	groupNamespaceNames := map[string]string{}
+
+	for groupName, providerName := range groupProviders {
+
+		namespaceName, found, err := renderer.GetNamespaceName(groupName, providerName)
+
+		if err != nil {
+			logger.Error(err, "Skipping Group Namespace", "Group Name", groupName)
+			continue
+		}
+
+		if found {
+			namespaceGroups[namespaceName] = append(namespaceGroups[namespaceName], groupName)
+			groupNamespaceNames[groupName] = namespaceName
+		}
+	}
+
+	parentNamespaces := map[string]string{}
+
+	if instance.Spec.GroupNamespaces.Hierarchy {
+		parentNamespaces = getParentNamespaces(namespaceGroups, groupNamespaceNames, groupParents)
+	}
+
+	namespaces := map[string]bool{}
+	subnamespaceAnchors := map[types.NamespacedName]bool{}
+
+	for _, namespaceName := range getOrderedNamespaces(namespaceGroups, parentNamespaces) {
+
+		groupNames := namespaceGroups[namespaceName]
+
+		var managed bool
+
+		if parentNamespace, found := parentNamespaces[namespaceName]; found {
+
+			if !namespaces[parentNamespace] {
+				logger.Info("Warning: Skipping Subnamespace of Unavailable Parent Namespace", "Namespace", namespaceName, "Parent Namespace", parentNamespace)
+				continue
+			}
+
+			subnamespaceAnchors[types.NamespacedName{Name: namespaceName, Namespace: parentNamespace}] = true
+			managed, err = r.ensureGroupSubnamespace(context, instance, parentNamespace, namespaceName, logger)
+		} else {
+			managed, err = r.ensureGroupNamespace(context, instance, namespaceName, logger)
+		}
+
+		if err != nil {
+			return roleBindings, err
+		}
+
+		if !managed {
+			continue
+		}
+
+		// RoleBindings are named after their namespace as HNC propagates the RoleBindings of parent namespaces to their subnamespaces
+		groupRoleBinding := &redhatcopv1alpha1.GroupRoleBinding{
+			Name: fmt.Sprintf("%s-%s-%s", instance.Name, renderer.GetClusterRole(), namespaceName),
+			RoleRef: redhatcopv1alpha1.GroupRoleRef{
+				Kind: redhatcopv1alpha1.ClusterRoleRoleRefKind,
+				Name: renderer.GetClusterRole(),
+			},
+		}
+
+		namespaces[namespaceName] = true
+		roleBindings[types.NamespacedName{Name: groupRoleBinding.Name, Namespace: namespaceName}] = true
+
+		if err := r.applyRoleBinding(context, instance, groupRoleBinding, namespaceName, getGroupSubjects(groupNames), logger); err != nil {
+			return roleBindings, err
+		}
+	}
+
+	if instance.Spec.GroupNamespaces.Prune {
+		if err := r.pruneSubnamespaceAnchors(context, instance, subnamespaceAnchors, logger); err != nil {
+			return roleBindings, err
+		}
+
+		return roleBindings, r.pruneGroupNamespaces(context, instance, namespaces, logger)
+	}
+
+	return roleBindings, nil
+}
+
+// ensureGroupNamespace creates the namespace when it does not exist. Returns whether the namespace is managed by the GroupSync as
+// existing namespaces created otherwise are left untouched
+func (r *GroupSyncReconciler) ensureGroupNamespace(context context.Context, instance *redhatcopv1alpha1.GroupSync, namespaceName string, logger logr.Logger) (bool, error) {
+
+	namespace := &corev1.Namespace{}
+	err := r.GetClient().Get(context, types.NamespacedName{Name: namespaceName}, namespace)
+
+	if apierrors.IsNotFound(err) {
+		logger.Info("Creating Group Namespace", "Namespace", namespaceName)
+
+		namespace = &corev1.Namespace{}
+		namespace.Name = namespaceName
+		namespace.Labels = getGroupSyncSelector(instance)
+
+		return true, r.GetClient().Create(context, namespace)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if namespace.Labels[constants.GroupSyncName] != instance.Name || namespace.Labels[constants.GroupSyncNamespace] != instance.Namespace {
+		logger.Info("Warning: Skipping Namespace Not Created By GroupSync", "Namespace", namespaceName)
+		return false, nil
+	}
+
+	// Namespaces being deleted cannot contain new RoleBindings
+	return namespace.DeletionTimestamp == nil, nil
+}
+
+// ensureGroupSubnamespace creates the SubnamespaceAnchor of the subnamespace in its parent namespace when it does not exist. As HNC
+// creates subnamespaces asynchronously, the subnamespace is only managed once HNC created it for the anchor
+func (r *GroupSyncReconciler) ensureGroupSubnamespace(context context.Context, instance *redhatcopv1alpha1.GroupSync, parentNamespace string, namespaceName string, logger logr.Logger) (bool, error) {
+
+	subnamespaceAnchor := &unstructured.Unstructured{}
+	subnamespaceAnchor.SetGroupVersionKind(subnamespaceAnchorGVK)
+	err := r.GetClient().Get(context, types.NamespacedName{Name: namespaceName, Namespace: parentNamespace}, subnamespaceAnchor)
+
+	if apierrors.IsNotFound(err) {
+		logger.Info("Creating SubnamespaceAnchor", "Namespace", namespaceName, "Parent Namespace", parentNamespace)
+
+		subnamespaceAnchor = &unstructured.Unstructured{}
+		subnamespaceAnchor.SetGroupVersionKind(subnamespaceAnchorGVK)
+		subnamespaceAnchor.SetName(namespaceName)
+		subnamespaceAnchor.SetNamespace(parentNamespace)
+		subnamespaceAnchor.SetLabels(getGroupSyncSelector(instance))
+
+		if err := r.GetClient().Create(context, subnamespaceAnchor); err != nil {
+			return false, err
+		}
+	} else if err != nil {
+		return false, err
+	} else if subnamespaceAnchor.GetLabels()[constants.GroupSyncName] != instance.Name || subnamespaceAnchor.GetLabels()[constants.GroupSyncNamespace] != instance.Namespace {
+		logger.Info("Warning: Skipping SubnamespaceAnchor Not Created By GroupSync", "Namespace", namespaceName, "Parent Namespace", parentNamespace)
+		return false, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	err = r.GetClient().Get(context, types.NamespacedName{Name: namespaceName}, namespace)
+
+	if apierrors.IsNotFound(err) {
+		logger.Info("Waiting for HNC to Create Subnamespace", "Namespace", namespaceName, "Parent Namespace", parentNamespace)
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if namespace.Annotations[hncSubnamespaceOf] != parentNamespace {
+		logger.Info("Warning: Skipping Namespace Not Created For SubnamespaceAnchor", "Namespace", namespaceName, "Parent Namespace", parentNamespace)
+		return false, nil
+	}
+
+	return namespace.DeletionTimestamp == nil, nil
+}
+
+// pruneSubnamespaceAnchors deletes the SubnamespaceAnchors created for the GroupSync other than the provided SubnamespaceAnchors. HNC
+// deletes the subnamespace of each deleted anchor
+func (r *GroupSyncReconciler) pruneSubnamespaceAnchors(context context.Context, instance *redhatcopv1alpha1.GroupSync, subnamespaceAnchors map[types.NamespacedName]bool, logger logr.Logger) error {
+
+	existingSubnamespaceAnchors := &unstructured.UnstructuredList{}
+	existingSubnamespaceAnchors.SetGroupVersionKind(subnamespaceAnchorGVK.GroupVersion().WithKind(subnamespaceAnchorGVK.Kind + "List"))
+
+	if err := r.GetClient().List(context, existingSubnamespaceAnchors, getGroupSyncSelector(instance)); err != nil {
+		// No SubnamespaceAnchors exist when HNC is not installed
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for i := range existingSubnamespaceAnchors.Items {
+
+		subnamespaceAnchor := &existingSubnamespaceAnchors.Items[i]
+
+		if subnamespaceAnchors[types.NamespacedName{Name: subnamespaceAnchor.GetName(), Namespace: subnamespaceAnchor.GetNamespace()}] {
+			continue
+		}
+
+		logger.Info("pruneSubnamespaceAnchors", "Delete SubnamespaceAnchor", subnamespaceAnchor.GetName(), "Namespace", subnamespaceAnchor.GetNamespace())
+		if err := r.GetClient().Delete(context, subnamespaceAnchor); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pruneGroupNamespaces deletes the namespaces created for the GroupSync other than the provided namespaces
+func (r *GroupSyncReconciler) pruneGroupNamespaces(context context.Context, instance *redhatcopv1alpha1.GroupSync, namespaces map[string]bool, logger logr.Logger) error {
+
+	existingNamespaces := &corev1.NamespaceList{}
+
+	if err := r.GetClient().List(context, existingNamespaces, getGroupSyncSelector(instance)); err != nil {
+		return err
+	}
+
+	for i := range existingNamespaces.Items {
+
+		namespace := &existingNamespaces.Items[i]
+
+		if namespaces[namespace.Name] || namespace.DeletionTimestamp != nil {
+			continue
+		}
+
+		logger.Info("pruneGroupNamespaces", "Delete Namespace", namespace.Name)
+		if err := r.GetClient().Delete(context, namespace); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getParentNamespaces returns the namespace of the parent group of the groups of each namespace. The parent of the first group sorted
+// by name having a parent group in another namespace is used when several groups are rendered to the same namespace
+func getParentNamespaces(namespaceGroups map[string][]string, groupNamespaceNames map[string]string, groupParents map[string]string) map[string]string {
+
+	parentNamespaces := map[string]string{}
+
+	for namespaceName, groupNames := range namespaceGroups {
+
+		sort.Strings(groupNames)
+
+		for _, groupName := range groupNames {
+			if parentNamespace, found := groupNamespaceNames[groupParents[groupName]]; found && parentNamespace != namespaceName {
+				parentNamespaces[namespaceName] = parentNamespace
+				break
+			}
+		}
+	}
+
+	return parentNamespaces
+}
+
+// getOrderedNamespaces returns the namespaces ordered by their depth in the hierarchy so that parent namespaces are created before
+// their subnamespaces. Namespaces whose ancestors form a cycle are created as root namespaces
+func getOrderedNamespaces(namespaceGroups map[string][]string, parentNamespaces map[string]string) []string {
+
+	namespaceDepths := map[string]int{}
+	orderedNamespaces := []string{}
+
+	for namespaceName := range namespaceGroups {
+
+		depth := 0
+
+		for parentNamespace, found := parentNamespaces[namespaceName]; found; parentNamespace, found = parentNamespaces[parentNamespace] {
+			depth++
+
+			if depth > len(parentNamespaces) {
+				delete(parentNamespaces, namespaceName)
+				depth = 0
+				break
+			}
+		}
+
+		namespaceDepths[namespaceName] = depth
+		orderedNamespaces = append(orderedNamespaces, namespaceName)
+	}
+
+	sort.Slice(orderedNamespaces, func(i, j int) bool {
+		if namespaceDepths[orderedNamespaces[i]] != namespaceDepths[orderedNamespaces[j]] {
+			return namespaceDepths[orderedNamespaces[i]] < namespaceDepths[orderedNamespaces[j]]
+		}
+
+		return orderedNamespaces[i] < orderedNamespaces[j]
+	})
+
+	return orderedNamespaces
+}
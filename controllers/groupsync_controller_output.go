@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// groupsConfigMapKey is the key of the groups in the ConfigMap the groups are written to
+const groupsConfigMapKey = "groups.json"
+
+// configMapGroup represents a synchronized group written to the groups ConfigMap
+type configMapGroup struct {
+	Name     string   `json:"name"`
+	Provider string   `json:"provider"`
+	Users    []string `json:"users"`
+}
+
+// isGroupOutput returns whether the synchronized groups are written as OpenShift Groups
+func isGroupOutput(instance *redhatcopv1alpha1.GroupSync) bool {
+	return instance.Spec.Output != redhatcopv1alpha1.ConfigMapGroupOutput
+}
+
+// getGroupsConfigMapName returns the name of the ConfigMap the groups of the GroupSync are written to
+func getGroupsConfigMapName(instance *redhatcopv1alpha1.GroupSync) string {
+	return fmt.Sprintf("%s-groups", instance.Name)
+}
+
+// writeGroupsConfigMap writes the synchronized groups to the groups ConfigMap in the namespace of the GroupSync using server-side apply.
+// Groups are sorted by name so that the ConfigMap only changes along with the groups
+func (r *GroupSyncReconciler) writeGroupsConfigMap(context context.Context, instance *redhatcopv1alpha1.GroupSync, groups []configMapGroup, logger logr.Logger) error {
+
+	name := getGroupsConfigMapName(instance)
+
+	existingConfigMap := &corev1.ConfigMap{}
+	err := r.GetClient().Get(context, types.NamespacedName{Name: name, Namespace: instance.Namespace}, existingConfigMap)
+
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil && (existingConfigMap.Labels[constants.GroupSyncName] != instance.Name || existingConfigMap.Labels[constants.GroupSyncNamespace] != instance.Namespace) {
+		return fmt.Errorf("ConfigMap '%s' is not managed by the GroupSync", name)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+
+	data, err := json.Marshal(groups)
+
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: instance.Namespace,
+			Labels:    getGroupSyncSelector(instance),
+		},
+		Data: map[string]string{
+			groupsConfigMapKey: string(data),
+		},
+	}
+
+	logger.Info("Writing Groups to ConfigMap", "ConfigMap", name, "Groups", len(groups))
+
+	return r.GetClient().Patch(context, configMap, client.Apply, client.FieldOwner(groupFieldManager), client.ForceOwnership)
+}
+
+// deleteGroupsConfigMap deletes the groups ConfigMap of the GroupSync when it was written by the GroupSync
+func (r *GroupSyncReconciler) deleteGroupsConfigMap(context context.Context, instance *redhatcopv1alpha1.GroupSync, logger logr.Logger) error {
+
+	configMap := &corev1.ConfigMap{}
+	err := r.GetClient().Get(context, types.NamespacedName{Name: getGroupsConfigMapName(instance), Namespace: instance.Namespace}, configMap)
+
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if configMap.Labels[constants.GroupSyncName] != instance.Name || configMap.Labels[constants.GroupSyncNamespace] != instance.Namespace {
+		return nil
+	}
+
+	logger.Info("deleteGroupsConfigMap", "Delete ConfigMap", configMap.Name)
+	if err := r.GetClient().Delete(context, configMap); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
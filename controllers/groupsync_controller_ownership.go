@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// setOwnershipLabels labels the group with the GroupSync and provider synchronizing it
+func setOwnershipLabels(group *userv1.Group, instance *redhatcopv1alpha1.GroupSync, providerName string) {
+	group.Labels[constants.GroupSyncName] = instance.Name
+	group.Labels[constants.GroupSyncNamespace] = instance.Namespace
+	group.Labels[constants.ProviderName] = providerName
+}
+
+// isOwnedByOtherGroupSync determines whether the group is labeled as owned by a GroupSync in another namespace. Groups synchronized
+// before the ownership labels were introduced are not considered owned by another GroupSync
+func isOwnedByOtherGroupSync(instance *redhatcopv1alpha1.GroupSync, group *userv1.Group) bool {
+	groupSyncNamespace, found := group.Labels[constants.GroupSyncNamespace]
+	return found && groupSyncNamespace != instance.Namespace
+}
+
+// getOwnershipSelector selects the groups owned by the provider of the GroupSync. Only groups labeled with the namespace of the
+// GroupSync are selected so that GroupSyncs with the same name in different namespaces never select each other's groups
+func getOwnershipSelector(instance *redhatcopv1alpha1.GroupSync, providerLabel string) client.MatchingLabels {
+	return client.MatchingLabels{
+		constants.SyncProvider:       providerLabel,
+		constants.GroupSyncNamespace: instance.Namespace,
+	}
+}
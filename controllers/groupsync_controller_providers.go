@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+const defaultMaxConcurrentProviderSyncs = 4
+
+// providerResult represents the outcome of binding to a provider and retrieving its groups
+type providerResult struct {
+	groups        []userv1.Group
+	syncStartTime string
+	bindError     error
+	syncError     error
+	duration      time.Duration
+}
+
+// retrieveProviderGroups validates, binds to and retrieves the groups of the providers concurrently using at most maxConcurrentSyncs
+// workers. Results are returned in the order of the providers
+func retrieveProviderGroups(context context.Context, groupSyncMgr *syncer.GroupSyncMgr, groupSyncers []syncer.GroupSyncer, timeouts map[string]time.Duration, maxConcurrentSyncs int, logger logr.Logger) []providerResult {
+
+	if maxConcurrentSyncs < 1 {
+		maxConcurrentSyncs = defaultMaxConcurrentProviderSyncs
+	}
+
+	results := make([]providerResult, len(groupSyncers))
+	workers := make(chan struct{}, maxConcurrentSyncs)
+	var waitGroup sync.WaitGroup
+
+	for i, groupSyncer := range groupSyncers {
+		waitGroup.Add(1)
+		workers <- struct{}{}
+
+		go func(i int, providerName string) {
+			defer waitGroup.Done()
+			defer func() { <-workers }()
+
+			results[i] = retrieveGroupsWithTimeout(context, groupSyncMgr, providerName, timeouts[providerName], logger)
+		}(i, groupSyncer.GetProviderName())
+	}
+
+	waitGroup.Wait()
+
+	return results
+}
+
+// retrieveGroupsWithTimeout validates, binds to and retrieves the groups of the provider within the timeout of the provider. The syncer of
+// the provider is given a context expiring once the timeout elapsed so that the requests made to the provider are cancelled. Clients of
+// providers that are not context aware cannot be cancelled, so that the provider is abandoned to release the worker retrieving it
+func retrieveGroupsWithTimeout(reconcileContext context.Context, groupSyncMgr *syncer.GroupSyncMgr, providerName string, timeout time.Duration, logger logr.Logger) providerResult {
+
+	if timeout <= 0 {
+		return retrieveGroups(reconcileContext, groupSyncMgr, providerName, logger)
+	}
+
+	timeoutContext, cancel := context.WithTimeout(reconcileContext, timeout)
+	defer cancel()
+
+	// Buffered so that an abandoned provider does not block once it completes
+	result := make(chan providerResult, 1)
+
+	go func() {
+		result <- retrieveGroups(timeoutContext, groupSyncMgr, providerName, logger)
+	}()
+
+	select {
+	case retrieved := <-result:
+		return retrieved
+	case <-timeoutContext.Done():
+		logger.Info("Warning: Abandoning Provider Exceeding Timeout", "Provider", providerName, "Timeout", timeout.String())
+		return providerResult{syncError: fmt.Errorf("Timed out after %s validating, binding to the provider and retrieving its groups", timeout.String()), duration: timeout}
+	}
+}
+
+// getProviderTimeouts returns the timeouts of the providers by provider name
+func getProviderTimeouts(instance *redhatcopv1alpha1.GroupSync) map[string]time.Duration {
+
+	timeouts := map[string]time.Duration{}
+
+	for _, provider := range instance.Spec.Providers {
+		if provider.Timeout != nil {
+			timeouts[provider.Name] = provider.Timeout.Duration
+		}
+	}
+
+	return timeouts
+}
+
+// retrieveGroups validates, binds to and retrieves the groups of the provider using a new syncer bound to the context given. Validation
+// errors are reported as errors binding to the provider
+func retrieveGroups(context context.Context, groupSyncMgr *syncer.GroupSyncMgr, providerName string, logger logr.Logger) providerResult {
+
+	logger.Info("Beginning Sync", "Provider", providerName)
+	startTime := time.Now()
+
+	groupSyncer, err := groupSyncMgr.GetProviderSyncer(context, providerName)
+	if err != nil {
+		return providerResult{bindError: err, duration: time.Since(startTime)}
+	}
+
+	if err := groupSyncer.Validate(); err != nil {
+		return providerResult{bindError: fmt.Errorf("Failed to validate the provider: %w", err), duration: time.Since(startTime)}
+	}
+
+	// Initialize Connection
+	if err := groupSyncer.Bind(); err != nil {
+		return providerResult{bindError: err, duration: time.Since(startTime)}
+	}
+
+	syncStartTime := ISO8601(time.Now())
+	// Perform Sync
+	groups, err := groupSyncer.Sync()
+
+	return providerResult{groups: groups, syncStartTime: syncStartTime, syncError: err, duration: time.Since(startTime)}
+}
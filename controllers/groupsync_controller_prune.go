@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// getExpiredPruneCandidates returns the stale groups whose prune grace period elapsed. Stale groups are first marked as prune candidates
+// with the time they were found stale and are kept until the grace period elapsed. Empty groups are pruned without grace period so that
+// their former members do not retain their membership. When performing a dry run, stale groups are not marked
+func (r *GroupSyncReconciler) getExpiredPruneCandidates(context context.Context, instance *redhatcopv1alpha1.GroupSync, staleGroups []userv1.Group, emptyGroups map[string]bool, logger logr.Logger) ([]userv1.Group, error) {
+
+	if instance.Spec.PruneGracePeriod == nil {
+		return staleGroups, nil
+	}
+
+	expiredGroups := []userv1.Group{}
+	now := clock.Now()
+
+	for i := range staleGroups {
+
+		group := &staleGroups[i]
+
+		if emptyGroups[group.Name] {
+			expiredGroups = append(expiredGroups, *group)
+			continue
+		}
+
+		if candidateTime, err := time.Parse(time.RFC3339, group.Annotations[constants.PruneCandidateTime]); err == nil {
+			if now.Sub(candidateTime) >= instance.Spec.PruneGracePeriod.Duration {
+				expiredGroups = append(expiredGroups, *group)
+			}
+			continue
+		}
+
+		if instance.Spec.DryRun {
+			continue
+		}
+
+		logger.Info("Marking Group as Prune Candidate", "Group Name", group.Name, "Grace Period", instance.Spec.PruneGracePeriod.Duration.String())
+
+		if err := r.setPruneCandidateTime(context, group, ISO8601(now.UTC())); err != nil {
+			return expiredGroups, err
+		}
+	}
+
+	return expiredGroups, nil
+}
+
+// setPruneCandidateTime sets the time the group was found stale. A merge patch is used rather than server-side apply as the
+// annotation is not part of the groups applied by the operator
+func (r *GroupSyncReconciler) setPruneCandidateTime(context context.Context, group *userv1.Group, candidateTime string) error {
+
+	patch := client.MergeFrom(group.DeepCopy())
+
+	if group.Annotations == nil {
+		group.Annotations = map[string]string{}
+	}
+	group.Annotations[constants.PruneCandidateTime] = candidateTime
+
+	return r.GetClient().Patch(context, group, patch)
+}
+
+// quarantineGroup removes the users of the pruned group and labels it as orphaned instead of deleting it, so that RoleBindings
+// referencing the group are preserved while access is revoked
+func (r *GroupSyncReconciler) quarantineGroup(context context.Context, group *userv1.Group) error {
+
+	patch := client.MergeFrom(group.DeepCopy())
+
+	if group.Labels == nil {
+		group.Labels = map[string]string{}
+	}
+	group.Labels[constants.Orphaned] = "true"
+	delete(group.Annotations, constants.PruneCandidateTime)
+	group.Users = userv1.OptionalNames{}
+
+	return r.GetClient().Patch(context, group, patch)
+}
+
+// hasPruneMarkers determines whether the group was marked as a prune candidate or quarantined
+func hasPruneMarkers(group *userv1.Group) bool {
+
+	_, candidate := group.Annotations[constants.PruneCandidateTime]
+	_, orphaned := group.Labels[constants.Orphaned]
+
+	return candidate || orphaned
+}
+
+// clearPruneMarkers removes the prune candidate time and orphaned label from a group returned again by the provider
+func (r *GroupSyncReconciler) clearPruneMarkers(context context.Context, group *userv1.Group) error {
+
+	patch := client.MergeFrom(group.DeepCopy())
+
+	delete(group.Annotations, constants.PruneCandidateTime)
+	delete(group.Labels, constants.Orphaned)
+
+	return r.GetClient().Patch(context, group, patch)
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// referenceChanges records the GroupSyncs whose referenced secrets or config maps changed since they were last synchronized
+type referenceChanges struct {
+	changed map[types.NamespacedName]bool
+	mutex   sync.Mutex
+}
+
+func newReferenceChanges() *referenceChanges {
+	return &referenceChanges{changed: map[types.NamespacedName]bool{}}
+}
+
+func (c *referenceChanges) add(key types.NamespacedName) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.changed[key] = true
+}
+
+func (c *referenceChanges) isChanged(key types.NamespacedName) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.changed[key]
+}
+
+func (c *referenceChanges) clear(key types.NamespacedName) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.changed, key)
+}
+
+// referencedObjectChangedPredicate fires an update event when the content of a secret or config map changes. Objects listed when the
+// operator starts are ignored so that restarting the operator does not synchronize every GroupSync
+var referencedObjectChangedPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return false
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld != nil && e.ObjectNew != nil && e.ObjectOld.GetResourceVersion() != e.ObjectNew.GetResourceVersion()
+	},
+}
+
+// findGroupSyncsReferencingObject returns a reconcile request for each GroupSync referencing the changed secret or config map. The
+// GroupSyncs are recorded so that they are synchronized regardless of their schedules
+func (r *GroupSyncReconciler) findGroupSyncsReferencingObject(obj client.Object) []reconcile.Request {
+
+	kind := redhatcopv1alpha1.SecretMapObjectRefKind
+	if _, isConfigMap := obj.(*corev1.ConfigMap); isConfigMap {
+		kind = redhatcopv1alpha1.ConfigMapObjectRefKind
+	}
+
+	groupSyncs := &redhatcopv1alpha1.GroupSyncList{}
+
+	if err := r.GetClient().List(context.TODO(), groupSyncs); err != nil {
+		r.Log.Error(err, "Failed to List GroupSyncs Referencing Object", "Kind", kind, "Name", obj.GetName(), "Namespace", obj.GetNamespace())
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+
+	for _, groupSync := range groupSyncs.Items {
+
+		objectRefs := getObjectRefs(&groupSync)
+
+		// The CA bundle of the operator is trusted by all providers
+		if r.CaBundle != nil {
+			objectRefs = append(objectRefs, r.CaBundle.ObjectRef())
+		}
+
+		for _, objectRef := range objectRefs {
+			if objectRef.Name == obj.GetName() && objectRef.Namespace == obj.GetNamespace() && getObjectRefKind(objectRef) == kind {
+				key := types.NamespacedName{Name: groupSync.Name, Namespace: groupSync.Namespace}
+				r.Log.Info("Referenced Object Changed", "groupsync", key, "Kind", kind, "Name", obj.GetName(), "Namespace", obj.GetNamespace())
+				r.referenceChanges.add(key)
+				requests = append(requests, reconcile.Request{NamespacedName: key})
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// getObjectRefs returns the secrets and config maps referenced by the providers and remote clusters of the GroupSync
+func getObjectRefs(instance *redhatcopv1alpha1.GroupSync) []*redhatcopv1alpha1.ObjectRef {
+
+	objectRefs := []*redhatcopv1alpha1.ObjectRef{}
+
+	for _, provider := range instance.Spec.Providers {
+
+		objectRefs = append(objectRefs, provider.GroupNameMappingsRef)
+
+		if provider.MappingWebhook != nil {
+			objectRefs = append(objectRefs, provider.MappingWebhook.Ca, provider.MappingWebhook.CredentialsSecret)
+		}
+
+		if provider.CaBundleConfigMapRef != nil {
+			objectRefs = append(objectRefs, provider.CaBundleConfigMapRef.ObjectRef())
+		}
+
+		if provider.ClientCertificateSecretRef != nil {
+			objectRefs = append(objectRefs, provider.ClientCertificateSecretRef.ObjectRef())
+		}
+
+		if provider.CredentialsSource != nil && provider.CredentialsSource.Vault != nil {
+			objectRefs = append(objectRefs, provider.CredentialsSource.Vault.Ca)
+		}
+
+		switch {
+		case provider.Azure != nil:
+			objectRefs = append(objectRefs, provider.Azure.CredentialsSecret)
+			if provider.Azure.Proxy != nil {
+				objectRefs = append(objectRefs, provider.Azure.Proxy.CredentialsSecret)
+			}
+		case provider.GitHub != nil:
+			objectRefs = append(objectRefs, provider.GitHub.Ca, provider.GitHub.CaSecret, provider.GitHub.CredentialsSecret)
+		case provider.GitLab != nil:
+			objectRefs = append(objectRefs, provider.GitLab.Ca, provider.GitLab.CaSecret, provider.GitLab.CredentialsSecret)
+		case provider.Keycloak != nil:
+			objectRefs = append(objectRefs, provider.Keycloak.Ca, provider.Keycloak.CaSecret, provider.Keycloak.CredentialsSecret)
+		case provider.Ldap != nil:
+			objectRefs = append(objectRefs, provider.Ldap.Ca, provider.Ldap.CaSecret, provider.Ldap.CredentialsSecret)
+		case provider.Okta != nil:
+			objectRefs = append(objectRefs, provider.Okta.CredentialsSecret)
+		case provider.Plugin != nil:
+			objectRefs = append(objectRefs, provider.Plugin.Ca, provider.Plugin.CredentialsSecret)
+		case provider.Exec != nil:
+			objectRefs = append(objectRefs, provider.Exec.CredentialsSecret)
+		}
+	}
+
+	for _, cluster := range instance.Spec.Clusters {
+		objectRefs = append(objectRefs, cluster.KubeConfigSecret)
+	}
+
+	nonNilObjectRefs := []*redhatcopv1alpha1.ObjectRef{}
+	for _, objectRef := range objectRefs {
+		if objectRef != nil {
+			nonNilObjectRefs = append(nonNilObjectRefs, objectRef)
+		}
+	}
+
+	return nonNilObjectRefs
+}
+
+// getObjectRefKind returns the kind of the referenced resource, which is a secret unless specified otherwise
+func getObjectRefKind(objectRef *redhatcopv1alpha1.ObjectRef) redhatcopv1alpha1.ObjectRefKind {
+
+	if objectRef.Kind == "" {
+		return redhatcopv1alpha1.SecretMapObjectRefKind
+	}
+
+	return objectRef.Kind
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+const (
+	reportMaxSkippedGroups = 100
+)
+
+// newSyncReport returns an empty report for a synchronization of the GroupSync identified by the ID. The report shares the name of the
+// GroupSync
+func newSyncReport(instance *redhatcopv1alpha1.GroupSync, syncRunID string) *redhatcopv1alpha1.GroupSyncReport {
+
+	report := &redhatcopv1alpha1.GroupSyncReport{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "GroupSyncReport",
+			APIVersion: redhatcopv1alpha1.GroupVersion.String(),
+		},
+		Status: redhatcopv1alpha1.GroupSyncReportStatus{
+			GroupSync:          instance.Name,
+			ObservedGeneration: instance.GetGeneration(),
+			DryRun:             instance.Spec.DryRun,
+			SyncRunID:          syncRunID,
+		},
+	}
+
+	report.Name = instance.Name
+	report.Namespace = instance.Namespace
+
+	return report
+}
+
+// getProviderReport returns the report of a provider, adding it to the report when not found
+func getProviderReport(report *redhatcopv1alpha1.GroupSyncReport, providerName string) *redhatcopv1alpha1.ProviderReport {
+
+	for i := range report.Status.Providers {
+		if report.Status.Providers[i].Name == providerName {
+			return &report.Status.Providers[i]
+		}
+	}
+
+	report.Status.Providers = append(report.Status.Providers, redhatcopv1alpha1.ProviderReport{Name: providerName})
+
+	return &report.Status.Providers[len(report.Status.Providers)-1]
+}
+
+// recordReportChange records whether a group was created, updated or left unchanged
+func recordReportChange(providerReport *redhatcopv1alpha1.ProviderReport, change string) {
+
+	switch change {
+	case dryRunGroupCreated:
+		providerReport.Created++
+	case dryRunGroupUpdated:
+		providerReport.Updated++
+	default:
+		providerReport.Unchanged++
+	}
+}
+
+// recordReportMembershipChange adds the users added to and removed from a group to the totals of the provider
+func recordReportMembershipChange(providerReport *redhatcopv1alpha1.ProviderReport, membershipChange *redhatcopv1alpha1.GroupMembershipChange) {
+
+	if membershipChange == nil {
+		return
+	}
+
+	providerReport.UsersAdded += membershipChange.Added
+	providerReport.UsersRemoved += membershipChange.Removed
+}
+
+// recordReportSkippedGroup records a group that was not synchronized unless the maximum number of skipped groups has been reached
+func recordReportSkippedGroup(providerReport *redhatcopv1alpha1.ProviderReport, groupName string, reason string) {
+
+	if len(providerReport.SkippedGroups) >= reportMaxSkippedGroups {
+		return
+	}
+
+	providerReport.SkippedGroups = append(providerReport.SkippedGroups, redhatcopv1alpha1.SkippedGroup{Name: groupName, Reason: reason})
+}
+
+// writeSyncReport creates or updates the report of the GroupSync. Failing to write the report does not fail the synchronization
+func (r *GroupSyncReconciler) writeSyncReport(context context.Context, instance *redhatcopv1alpha1.GroupSync, report *redhatcopv1alpha1.GroupSyncReport, syncErrors []error) {
+
+	for _, syncErr := range syncErrors {
+		report.Status.Errors = append(report.Status.Errors, syncErr.Error())
+	}
+
+	report.Status.SyncTime = &metav1.Time{Time: clock.Now()}
+
+	if err := r.CreateOrUpdateResource(context, instance, "", report); err != nil {
+		r.Log.Error(err, "Failed to Write Group Sync Report", "groupsync", types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name})
+	}
+}
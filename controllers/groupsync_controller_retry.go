@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// defaultMaxRetryInterval is the maximum time waited before retrying a failed synchronization when no max retry interval is set
+const defaultMaxRetryInterval = time.Hour
+
+// manageSyncError records a failed synchronization. When a retry interval is set, the synchronization is requeued after a backoff
+// doubling with each consecutive failure rather than by the rate limiter of the controller, and the time of the retry is recorded
+func (r *GroupSyncReconciler) manageSyncError(context context.Context, instance *redhatcopv1alpha1.GroupSync, issue error) (ctrl.Result, error) {
+
+	if instance.Spec.RetryInterval == nil {
+		return r.ManageError(context, instance, issue)
+	}
+
+	instance.Status.RetryCount++
+	retryAfter := getRetryBackoff(instance, instance.Status.RetryCount)
+	instance.Status.NextRetryTime = &metav1.Time{Time: clock.Now().Add(retryAfter)}
+
+	result, err := r.ManageErrorWithRequeue(context, instance, issue, retryAfter)
+
+	// The issue is not returned as the request would otherwise be requeued by the rate limiter instead
+	if err == issue {
+		err = nil
+	}
+
+	return result, err
+}
+
+// getRetryBackoff returns the time waited before retrying after the number of consecutive failed synchronizations
+func getRetryBackoff(instance *redhatcopv1alpha1.GroupSync, retryCount int32) time.Duration {
+
+	maxRetryInterval := defaultMaxRetryInterval
+
+	if instance.Spec.MaxRetryInterval != nil {
+		maxRetryInterval = instance.Spec.MaxRetryInterval.Duration
+	}
+
+	backoff := instance.Spec.RetryInterval.Duration
+
+	for i := int32(1); i < retryCount && backoff < maxRetryInterval; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxRetryInterval {
+		return maxRetryInterval
+	}
+
+	return backoff
+}
+
+// isRetryDue determines whether a failed synchronization is due to be retried
+func isRetryDue(instance *redhatcopv1alpha1.GroupSync) bool {
+	return instance.Status.NextRetryTime != nil && !instance.Status.NextRetryTime.After(clock.Now())
+}
+
+// resetRetries clears the retries of failed synchronizations after a successful synchronization
+func resetRetries(instance *redhatcopv1alpha1.GroupSync) {
+	instance.Status.RetryCount = 0
+	instance.Status.NextRetryTime = nil
+}
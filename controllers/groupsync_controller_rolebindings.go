@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// getGroupSyncSelector selects the objects owned by the GroupSync regardless of the provider
+func getGroupSyncSelector(instance *redhatcopv1alpha1.GroupSync) client.MatchingLabels {
+	return client.MatchingLabels{
+		constants.GroupSyncName:      instance.Name,
+		constants.GroupSyncNamespace: instance.Namespace,
+	}
+}
+
+// getSyncedGroupProviders returns the names of the groups synchronized by the GroupSync along with the name of the provider
+// synchronizing each of them and the parent of the groups exposing a hierarchy
+func (r *GroupSyncReconciler) getSyncedGroupProviders(context context.Context, instance *redhatcopv1alpha1.GroupSync) (map[string]string, map[string]string, error) {
+
+	ocpGroups := &userv1.GroupList{}
+
+	if err := r.GetClient().List(context, ocpGroups, client.InNamespace(""), getGroupSyncSelector(instance)); err != nil {
+		return nil, nil, err
+	}
+
+	groupProviders := map[string]string{}
+	groupParents := map[string]string{}
+
+	for _, group := range ocpGroups.Items {
+		groupProviders[group.Name] = group.Labels[constants.ProviderName]
+
+		if parentGroupName := group.Annotations[constants.HierarchyParent]; parentGroupName != "" {
+			groupParents[group.Name] = parentGroupName
+		}
+	}
+
+	return groupProviders, groupParents, nil
+}
+
+// syncRoleBindings binds the roles of the role bindings of the GroupSync to the groups in each of their namespaces. RoleBindings
+// previously maintained for the GroupSync and no longer specified are deleted, other than the provided RoleBindings maintained for
+// other purposes, such as the namespaces of groups
+func (r *GroupSyncReconciler) syncRoleBindings(context context.Context, instance *redhatcopv1alpha1.GroupSync, groupProviders map[string]string, roleBindings map[types.NamespacedName]bool, logger logr.Logger) error {
+
+	for i := range instance.Spec.RoleBindings {
+
+		groupRoleBinding := &instance.Spec.RoleBindings[i]
+		subjects := getRoleBindingSubjects(groupRoleBinding, groupProviders)
+
+		for _, namespace := range groupRoleBinding.Namespaces {
+
+			roleBindings[types.NamespacedName{Name: groupRoleBinding.Name, Namespace: namespace}] = true
+
+			if err := r.applyRoleBinding(context, instance, groupRoleBinding, namespace, subjects, logger); err != nil {
+				return err
+			}
+		}
+	}
+
+	return r.pruneRoleBindings(context, instance, roleBindings, logger)
+}
+
+// applyRoleBinding applies the RoleBinding in the namespace using server-side apply. As the role referenced by a RoleBinding cannot
+// be changed, the RoleBinding is recreated when its role changed
+func (r *GroupSyncReconciler) applyRoleBinding(context context.Context, instance *redhatcopv1alpha1.GroupSync, groupRoleBinding *redhatcopv1alpha1.GroupRoleBinding, namespace string, subjects []rbacv1.Subject, logger logr.Logger) error {
+
+	roleRef := getRoleRef(groupRoleBinding)
+
+	existingRoleBinding := &rbacv1.RoleBinding{}
+	err := r.GetClient().Get(context, types.NamespacedName{Name: groupRoleBinding.Name, Namespace: namespace}, existingRoleBinding)
+
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil {
+		if existingRoleBinding.Labels[constants.GroupSyncName] != instance.Name || existingRoleBinding.Labels[constants.GroupSyncNamespace] != instance.Namespace {
+			return fmt.Errorf("RoleBinding '%s' in namespace '%s' is not managed by the GroupSync", groupRoleBinding.Name, namespace)
+		}
+
+		if existingRoleBinding.RoleRef != roleRef {
+			logger.Info("Recreating RoleBinding Referencing Another Role", "RoleBinding", groupRoleBinding.Name, "Namespace", namespace)
+
+			if err := r.GetClient().Delete(context, existingRoleBinding); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	roleBinding := &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleBinding",
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      groupRoleBinding.Name,
+			Namespace: namespace,
+			Labels:    getGroupSyncSelector(instance),
+		},
+		RoleRef:  roleRef,
+		Subjects: subjects,
+	}
+
+	return r.GetClient().Patch(context, roleBinding, client.Apply, client.FieldOwner(groupFieldManager), client.ForceOwnership)
+}
+
+// pruneRoleBindings deletes the RoleBindings maintained for the GroupSync other than the provided RoleBindings
+func (r *GroupSyncReconciler) pruneRoleBindings(context context.Context, instance *redhatcopv1alpha1.GroupSync, roleBindings map[types.NamespacedName]bool, logger logr.Logger) error {
+
+	existingRoleBindings := &rbacv1.RoleBindingList{}
+
+	if err := r.GetClient().List(context, existingRoleBindings, getGroupSyncSelector(instance)); err != nil {
+		return err
+	}
+
+	for i := range existingRoleBindings.Items {
+
+		roleBinding := &existingRoleBindings.Items[i]
+
+		// RoleBindings propagated by HNC to subnamespaces are maintained by HNC
+		if _, found := roleBinding.Labels[hncInheritedFrom]; found || roleBindings[types.NamespacedName{Name: roleBinding.Name, Namespace: roleBinding.Namespace}] {
+			continue
+		}
+
+		logger.Info("pruneRoleBindings", "Delete RoleBinding", roleBinding.Name, "Namespace", roleBinding.Namespace)
+		if err := r.GetClient().Delete(context, roleBinding); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getRoleBindingSubjects returns the groups bound to the role of the role binding sorted by name
+func getRoleBindingSubjects(groupRoleBinding *redhatcopv1alpha1.GroupRoleBinding, groupProviders map[string]string) []rbacv1.Subject {
+
+	groupNames := []string{}
+	groups := syncer.NewGroupPatterns(groupRoleBinding.Groups)
+
+	for groupName, providerName := range groupProviders {
+		if syncer.IsGroupBound(groupRoleBinding, groups, groupName, providerName) {
+			groupNames = append(groupNames, groupName)
+		}
+	}
+
+	return getGroupSubjects(groupNames)
+}
+
+// getGroupSubjects returns the groups as subjects of a RoleBinding or ClusterRoleBinding sorted by name
+func getGroupSubjects(groupNames []string) []rbacv1.Subject {
+
+	sort.Strings(groupNames)
+
+	subjects := []rbacv1.Subject{}
+
+	for _, groupName := range groupNames {
+		subjects = append(subjects, rbacv1.Subject{
+			Kind:     rbacv1.GroupKind,
+			APIGroup: rbacv1.GroupName,
+			Name:     groupName,
+		})
+	}
+
+	return subjects
+}
+
+func getRoleRef(groupRoleBinding *redhatcopv1alpha1.GroupRoleBinding) rbacv1.RoleRef {
+
+	kind := groupRoleBinding.RoleRef.Kind
+
+	if kind == "" {
+		kind = redhatcopv1alpha1.ClusterRoleRoleRefKind
+	}
+
+	return rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     string(kind),
+		Name:     groupRoleBinding.RoleRef.Name,
+	}
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// syncClusterRoleBindings binds each ClusterRole of the role mappings of the GroupSync to the groups mapped to it. ClusterRoleBindings
+// are only maintained for ClusterRoles mapped to at least one group, while ClusterRoleBindings previously maintained for the GroupSync
+// and no longer required are deleted
+func (r *GroupSyncReconciler) syncClusterRoleBindings(context context.Context, instance *redhatcopv1alpha1.GroupSync, groupProviders map[string]string, logger logr.Logger) error {
+
+	clusterRoleGroups := map[string][]string{}
+
+	for i := range instance.Spec.RoleMappings {
+
+		roleMapping := &instance.Spec.RoleMappings[i]
+		groups := syncer.NewGroupPatterns(roleMapping.Groups)
+
+		for groupName := range groupProviders {
+			if groups.Matches(groupName) && !containsString(clusterRoleGroups[roleMapping.ClusterRole], groupName) {
+				clusterRoleGroups[roleMapping.ClusterRole] = append(clusterRoleGroups[roleMapping.ClusterRole], groupName)
+			}
+		}
+	}
+
+	clusterRoleBindings := map[string]bool{}
+
+	for clusterRole, groupNames := range clusterRoleGroups {
+
+		clusterRoleBindingName := getClusterRoleBindingName(instance, clusterRole)
+		clusterRoleBindings[clusterRoleBindingName] = true
+
+		if err := r.applyClusterRoleBinding(context, instance, clusterRoleBindingName, clusterRole, getGroupSubjects(groupNames)); err != nil {
+			return err
+		}
+	}
+
+	return r.pruneClusterRoleBindings(context, instance, clusterRoleBindings, logger)
+}
+
+// applyClusterRoleBinding applies the ClusterRoleBinding using server-side apply
+func (r *GroupSyncReconciler) applyClusterRoleBinding(context context.Context, instance *redhatcopv1alpha1.GroupSync, name string, clusterRole string, subjects []rbacv1.Subject) error {
+
+	existingClusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+	err := r.GetClient().Get(context, types.NamespacedName{Name: name}, existingClusterRoleBinding)
+
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil && (existingClusterRoleBinding.Labels[constants.GroupSyncName] != instance.Name || existingClusterRoleBinding.Labels[constants.GroupSyncNamespace] != instance.Namespace) {
+		return fmt.Errorf("ClusterRoleBinding '%s' is not managed by the GroupSync", name)
+	}
+
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRoleBinding",
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: getGroupSyncSelector(instance),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     string(redhatcopv1alpha1.ClusterRoleRoleRefKind),
+			Name:     clusterRole,
+		},
+		Subjects: subjects,
+	}
+
+	return r.GetClient().Patch(context, clusterRoleBinding, client.Apply, client.FieldOwner(groupFieldManager), client.ForceOwnership)
+}
+
+// pruneClusterRoleBindings deletes the ClusterRoleBindings maintained for the GroupSync other than the provided ClusterRoleBindings
+func (r *GroupSyncReconciler) pruneClusterRoleBindings(context context.Context, instance *redhatcopv1alpha1.GroupSync, clusterRoleBindings map[string]bool, logger logr.Logger) error {
+
+	existingClusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+
+	if err := r.GetClient().List(context, existingClusterRoleBindings, getGroupSyncSelector(instance)); err != nil {
+		return err
+	}
+
+	for i := range existingClusterRoleBindings.Items {
+
+		clusterRoleBinding := &existingClusterRoleBindings.Items[i]
+
+		if clusterRoleBindings[clusterRoleBinding.Name] {
+			continue
+		}
+
+		logger.Info("pruneClusterRoleBindings", "Delete ClusterRoleBinding", clusterRoleBinding.Name)
+		if err := r.GetClient().Delete(context, clusterRoleBinding); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getClusterRoleBindingName returns the name of the ClusterRoleBinding of the ClusterRole. The namespace and name of the GroupSync
+// are included so that GroupSyncs mapping groups to the same ClusterRole maintain distinct ClusterRoleBindings
+func getClusterRoleBindingName(instance *redhatcopv1alpha1.GroupSync, clusterRole string) string {
+	return fmt.Sprintf("%s-%s-%s", instance.Namespace, instance.Name, clusterRole)
+}
+
+func containsString(values []string, value string) bool {
+
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// isSyncRequired determines whether a synchronization should occur for a scheduled GroupSync. Synchronizations occur when the timer of
+// the GroupSync fires for its schedule, when the GroupSync has never been synchronized or has changed, when a failed synchronization is due
+// to be retried and when scheduled synchronizations were missed unless the missed run policy is to skip them
+func (r *GroupSyncReconciler) isSyncRequired(context context.Context, instance *redhatcopv1alpha1.GroupSync, schedule cron.Schedule, logger logr.Logger) (bool, error) {
+
+	key := types.NamespacedName{Name: instance.GetName(), Namespace: instance.GetNamespace()}
+
+	if instance.Status.LastSyncSuccessTime == nil || instance.GetGeneration() != instance.Status.ObservedGeneration || isRetryDue(instance) {
+		return true, nil
+	}
+
+	// The timer may also have fired for the schedule of a provider
+	if r.Scheduler.IsDue(key) && !schedule.Next(instance.Status.LastSyncSuccessTime.Time).After(clock.Now()) {
+		return true, nil
+	}
+
+	// Missed runs already recorded are not counted again
+	lastEvaluationTime := instance.Status.LastSyncSuccessTime.Time
+	if instance.Status.LastMissedRunTime != nil && instance.Status.LastMissedRunTime.After(lastEvaluationTime) {
+		lastEvaluationTime = instance.Status.LastMissedRunTime.Time
+	}
+
+	missedRuns := countMissedRuns(schedule, lastEvaluationTime, clock.Now())
+
+	if missedRuns == 0 {
+		return false, nil
+	}
+
+	logger.Info("Scheduled Synchronizations Were Missed", "Missed Runs", missedRuns, "Missed Run Policy", getMissedRunPolicy(instance))
+
+	missedScheduledSynchronizations.With(prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName()}).Add(float64(missedRuns))
+	instance.Status.MissedRuns += int64(missedRuns)
+	instance.Status.LastMissedRunTime = &metav1.Time{Time: clock.Now()}
+
+	if getMissedRunPolicy(instance) == redhatcopv1alpha1.SkipMissedRunPolicy {
+		// Record the missed runs since no synchronization will occur to update the status
+		return false, r.GetClient().Status().Update(context, instance)
+	}
+
+	return true, nil
+}
+
+// scheduleNextSync arms the timer of the GroupSync for the next synchronization scheduled by the GroupSync or by one of its providers
+func (r *GroupSyncReconciler) scheduleNextSync(instance *redhatcopv1alpha1.GroupSync, schedule cron.Schedule, providerSchedules map[string]cron.Schedule) {
+
+	schedules := []cron.Schedule{}
+
+	if schedule != nil {
+		schedules = append(schedules, schedule)
+	}
+
+	for _, providerSchedule := range providerSchedules {
+		schedules = append(schedules, providerSchedule)
+	}
+
+	if len(schedules) == 0 {
+		return
+	}
+
+	now := clock.Now()
+	nextScheduledTime := schedules[0].Next(now)
+
+	for _, nextSchedule := range schedules[1:] {
+		if next := nextSchedule.Next(now); next.Before(nextScheduledTime) {
+			nextScheduledTime = next
+		}
+	}
+
+	nextScheduledSynchronization.With(prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName()}).Set(float64(nextScheduledTime.UTC().Unix()))
+	r.Scheduler.Schedule(instance.DeepCopy(), nextScheduledTime)
+}
+
+// getProviderSchedules returns the schedules of the providers that are synchronized independently of the GroupSync by provider name
+func getProviderSchedules(instance *redhatcopv1alpha1.GroupSync) map[string]cron.Schedule {
+
+	providerSchedules := map[string]cron.Schedule{}
+
+	for _, provider := range instance.Spec.Providers {
+		if provider.Schedule == "" {
+			continue
+		}
+
+		if providerSchedule, err := cron.ParseStandard(provider.Schedule); err == nil {
+			providerSchedules[provider.Name] = providerSchedule
+		}
+	}
+
+	return providerSchedules
+}
+
+// isProviderSyncRequired determines whether a synchronization should occur for a provider with its own schedule. Synchronizations occur
+// when the provider has never been synchronized, when the GroupSync has changed and when a scheduled time has elapsed since the last
+// synchronization of the provider
+func isProviderSyncRequired(instance *redhatcopv1alpha1.GroupSync, providerName string, providerSchedule cron.Schedule) bool {
+
+	if instance.GetGeneration() != instance.Status.ObservedGeneration {
+		return true
+	}
+
+	for _, providerStatus := range instance.Status.Providers {
+		if providerStatus.Name == providerName && providerStatus.LastSyncSuccessTime != nil {
+			return !providerSchedule.Next(providerStatus.LastSyncSuccessTime.Time).After(clock.Now())
+		}
+	}
+
+	return true
+}
+
+// maxMissedRuns caps the number of missed runs counted at once, so that frequent schedules missed during long outages do not need to be
+// walked one scheduled time at a time
+const maxMissedRuns = 1000
+
+// countMissedRuns returns the number of scheduled times that elapsed between the last synchronization and now, up to maxMissedRuns
+func countMissedRuns(schedule cron.Schedule, lastSync time.Time, now time.Time) int {
+
+	missedRuns := 0
+
+	for next := schedule.Next(lastSync); !next.IsZero() && !next.After(now) && missedRuns < maxMissedRuns; next = schedule.Next(next) {
+		missedRuns++
+	}
+
+	return missedRuns
+}
+
+func getMissedRunPolicy(instance *redhatcopv1alpha1.GroupSync) redhatcopv1alpha1.MissedRunPolicy {
+
+	if instance.Spec.MissedRunPolicy == "" {
+		return redhatcopv1alpha1.RunOnceMissedRunPolicy
+	}
+
+	return instance.Spec.MissedRunPolicy
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// Shard selects the GroupSyncs reconciled by a replica of the operator so that multiple replicas can each own a subset of the
+// GroupSyncs. GroupSyncs are assigned either by hashing their namespace and name across the count of shards or by a label selector
+type Shard struct {
+	// Index is the index of the shard when GroupSyncs are assigned by hash
+	Index int
+	// Count is the number of shards when GroupSyncs are assigned by hash
+	Count int
+	// Selector selects the GroupSyncs of the shard by label
+	Selector labels.Selector
+	// selector is the label selector of the shard as specified
+	selector string
+}
+
+// NewShard returns the shard of the replica, or nil when GroupSyncs are not sharded. Hash and label based sharding can be combined
+func NewShard(index int, count int, selector string) (*Shard, error) {
+
+	if count <= 1 && selector == "" {
+		return nil, nil
+	}
+
+	if count > 1 && (index < 0 || index >= count) {
+		return nil, fmt.Errorf("Shard index %d must be between 0 and %d", index, count-1)
+	}
+
+	shard := &Shard{Index: index, Count: count, selector: selector}
+
+	if selector != "" {
+		parsedSelector, err := labels.Parse(selector)
+
+		if err != nil {
+			return nil, fmt.Errorf("Invalid shard selector '%s': %w", selector, err)
+		}
+
+		shard.Selector = parsedSelector
+	}
+
+	return shard, nil
+}
+
+// Owns determines whether the GroupSync is reconciled by the shard. All GroupSyncs are owned when GroupSyncs are not sharded
+func (s *Shard) Owns(object client.Object) bool {
+
+	if s == nil {
+		return true
+	}
+
+	if s.Selector != nil && !s.Selector.Matches(labels.Set(object.GetLabels())) {
+		return false
+	}
+
+	if s.Count > 1 {
+		hash := fnv.New32a()
+		hash.Write([]byte(object.GetNamespace() + "/" + object.GetName()))
+
+		return int(hash.Sum32()%uint32(s.Count)) == s.Index
+	}
+
+	return true
+}
+
+// shardOwnershipChangedPredicate fires an update event when a change of the labels of a GroupSync moves it into or out of the shard.
+// Relabeling a GroupSync does not change its generation, so the GroupSync would otherwise not be reconciled by its new shard
+type shardOwnershipChangedPredicate struct {
+	shard *Shard
+}
+
+func (shardOwnershipChangedPredicate) Create(e event.CreateEvent) bool {
+	return false
+}
+
+func (shardOwnershipChangedPredicate) Delete(e event.DeleteEvent) bool {
+	return false
+}
+
+func (shardOwnershipChangedPredicate) Generic(e event.GenericEvent) bool {
+	return false
+}
+
+func (p shardOwnershipChangedPredicate) Update(e event.UpdateEvent) bool {
+
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	return p.shard.Owns(e.ObjectOld) != p.shard.Owns(e.ObjectNew)
+}
+
+// LeaderElectionID returns the leader election ID of the shard so that the replicas of each shard elect their own leader
+func (s *Shard) LeaderElectionID(leaderElectionID string) string {
+
+	if s == nil {
+		return leaderElectionID
+	}
+
+	if s.Count > 1 {
+		leaderElectionID = fmt.Sprintf("%s-shard-%d", leaderElectionID, s.Index)
+	}
+
+	if s.selector != "" {
+		hash := fnv.New32a()
+		hash.Write([]byte(s.selector))
+		leaderElectionID = fmt.Sprintf("%s-%08x", leaderElectionID, hash.Sum32())
+	}
+
+	return leaderElectionID
+}
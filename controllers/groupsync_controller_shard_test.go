@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+func TestShardOwnershipChangedPredicate(t *testing.T) {
+
+	shard, err := NewShard(0, 0, "shard=ldap")
+
+	if err != nil {
+		t.Fatalf("unexpected error creating shard: %v", err)
+	}
+
+	newGroupSync := func(labels map[string]string) *redhatcopv1alpha1.GroupSync {
+		return &redhatcopv1alpha1.GroupSync{ObjectMeta: metav1.ObjectMeta{Name: "groupsync", Namespace: "default", Labels: labels}}
+	}
+
+	tests := []struct {
+		name      string
+		shard     *Shard
+		oldLabels map[string]string
+		newLabels map[string]string
+		expected  bool
+	}{
+		{name: "relabeled into the shard", shard: shard, newLabels: map[string]string{"shard": "ldap"}, expected: true},
+		{name: "relabeled out of the shard", shard: shard, oldLabels: map[string]string{"shard": "ldap"}, newLabels: map[string]string{"shard": "azure"}, expected: true},
+		{name: "relabeled within the shard", shard: shard, oldLabels: map[string]string{"shard": "ldap"}, newLabels: map[string]string{"shard": "ldap", "team": "a"}, expected: false},
+		{name: "labels unchanged", shard: shard, oldLabels: map[string]string{"shard": "ldap"}, newLabels: map[string]string{"shard": "ldap"}, expected: false},
+		{name: "not sharded", oldLabels: map[string]string{"shard": "ldap"}, newLabels: map[string]string{"shard": "azure"}, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			updateEvent := event.UpdateEvent{ObjectOld: newGroupSync(test.oldLabels), ObjectNew: newGroupSync(test.newLabels)}
+
+			if fired := (shardOwnershipChangedPredicate{shard: test.shard}).Update(updateEvent); fired != test.expected {
+				t.Errorf("expected predicate to return %t, found %t", test.expected, fired)
+			}
+		})
+	}
+}
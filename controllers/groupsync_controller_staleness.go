@@ -0,0 +1,259 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// defaultStaleSyncCheckInterval is the time between checks of the staleness of the GroupSyncs when no interval is set
+const defaultStaleSyncCheckInterval = time.Minute
+
+// StalenessChecker periodically reports the GroupSyncs whose last successful synchronization is older than a number of intervals of
+// their schedules, so that synchronizations that silently stopped occurring, such as when a worker is stuck, are noticed. Staleness is
+// recorded in the SyncStale condition of each GroupSync and exposed as metrics along with the age of the last successful synchronization
+type StalenessChecker struct {
+	// Reconciler provides the client and shard of the GroupSyncs checked
+	Reconciler *GroupSyncReconciler
+	// Intervals is the number of scheduled intervals after which a synchronization is stale
+	Intervals int
+	// Interval is the time between checks
+	Interval time.Duration
+	Log      logr.Logger
+}
+
+// Start checks the GroupSyncs every interval until the context is done
+func (c *StalenessChecker) Start(context context.Context) error {
+
+	interval := c.Interval
+	if interval <= 0 {
+		interval = defaultStaleSyncCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.check(context)
+
+		select {
+		case <-context.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection ensures that only the leader updates the status of the GroupSyncs
+func (c *StalenessChecker) NeedLeaderElection() bool {
+	return true
+}
+
+// check evaluates the staleness of each scheduled GroupSync of the shard
+func (c *StalenessChecker) check(context context.Context) {
+
+	groupSyncs := &redhatcopv1alpha1.GroupSyncList{}
+
+	if err := c.Reconciler.GetClient().List(context, groupSyncs); err != nil {
+		c.Log.Error(err, "Failed to List GroupSyncs")
+		return
+	}
+
+	// Metrics of deleted GroupSyncs are removed
+	syncAge.Reset()
+	syncStale.Reset()
+
+	now := clock.Now()
+
+	for i := range groupSyncs.Items {
+		instance := &groupSyncs.Items[i]
+
+		if !c.Reconciler.Shard.Owns(instance) || instance.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		prometheusLabels := prometheus.Labels{METRICS_CR_NAMESPACE_LABEL: instance.GetNamespace(), METRICS_CR_NAME_LABEL: instance.GetName()}
+
+		if instance.Status.LastSyncSuccessTime != nil {
+			syncAge.With(prometheusLabels).Set(now.Sub(instance.Status.LastSyncSuccessTime.Time).Seconds())
+		}
+
+		scheduled, staleness := getSyncStaleness(instance, c.Intervals, now)
+
+		// GroupSyncs that are not scheduled only synchronize when changed and cannot be overdue
+		if !scheduled {
+			continue
+		}
+
+		if staleness != nil {
+			syncStale.With(prometheusLabels).Set(1)
+		} else {
+			syncStale.With(prometheusLabels).Set(0)
+		}
+
+		if isSyncStaleConditionCurrent(instance, staleness) {
+			continue
+		}
+
+		if staleness != nil {
+			c.Log.Info("Warning: Synchronization Is Stale", "groupsync", client.ObjectKeyFromObject(instance), "Reason", staleness.Error())
+		}
+
+		if err := c.updateStatus(context, client.ObjectKeyFromObject(instance), staleness); err != nil {
+			c.Log.Error(err, "Failed to Record Synchronization Staleness", "groupsync", client.ObjectKeyFromObject(instance))
+		}
+	}
+}
+
+// updateStatus records the staleness in the SyncStale condition of the GroupSync, retrying when the GroupSync was updated concurrently by
+// the reconciler
+func (c *StalenessChecker) updateStatus(context context.Context, key client.ObjectKey, staleness error) error {
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+
+		instance := &redhatcopv1alpha1.GroupSync{}
+
+		if err := c.Reconciler.GetClient().Get(context, key, instance); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		conditions := instance.GetConditions()
+		meta.SetStatusCondition(&conditions, newSyncStaleCondition(instance, staleness))
+		instance.SetConditions(conditions)
+
+		return c.Reconciler.GetClient().Status().Update(context, instance)
+	})
+}
+
+// newSyncStaleCondition returns the SyncStale condition reporting the staleness of the GroupSync
+func newSyncStaleCondition(instance *redhatcopv1alpha1.GroupSync, staleness error) metav1.Condition {
+
+	condition := metav1.Condition{
+		Type:               redhatcopv1alpha1.SyncStaleCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             redhatcopv1alpha1.SyncCurrentReason,
+		ObservedGeneration: instance.GetGeneration(),
+	}
+
+	if staleness != nil {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = redhatcopv1alpha1.SyncOverdueReason
+		condition.Message = staleness.Error()
+
+		if _, invalid := staleness.(*invalidScheduleError); invalid {
+			condition.Reason = redhatcopv1alpha1.InvalidScheduleReason
+		}
+	}
+
+	return condition
+}
+
+// isSyncStaleConditionCurrent determines whether the SyncStale condition of the GroupSync already reports the staleness so that the
+// status is only updated when the staleness changes
+func isSyncStaleConditionCurrent(instance *redhatcopv1alpha1.GroupSync, staleness error) bool {
+
+	condition := meta.FindStatusCondition(instance.GetConditions(), redhatcopv1alpha1.SyncStaleCondition)
+	expected := newSyncStaleCondition(instance, staleness)
+
+	return condition != nil && condition.Status == expected.Status && condition.Reason == expected.Reason && condition.Message == expected.Message
+}
+
+// invalidScheduleError indicates that the staleness of a GroupSync cannot be evaluated as its schedule or the schedule of one of its
+// providers cannot be parsed, so that the GroupSync is reported as stale rather than never being considered overdue
+type invalidScheduleError struct {
+	providerName string
+	schedule     string
+	err          error
+}
+
+func (e *invalidScheduleError) Error() string {
+
+	if e.providerName != "" {
+		return fmt.Sprintf("Invalid schedule '%s' of provider '%s': %s", e.schedule, e.providerName, e.err.Error())
+	}
+
+	return fmt.Sprintf("Invalid schedule '%s': %s", e.schedule, e.err.Error())
+}
+
+// getSyncStaleness determines whether the GroupSync or any of its providers is scheduled and returns an error describing the staleness
+// of the first schedule that cannot be parsed or whose number of intervals elapsed since its last successful synchronization, or since
+// the creation of the GroupSync when it never synchronized successfully
+func getSyncStaleness(instance *redhatcopv1alpha1.GroupSync, intervals int, now time.Time) (bool, error) {
+
+	scheduled := false
+
+	if instance.Spec.Schedule != "" {
+		scheduled = true
+
+		schedule, err := cron.ParseStandard(instance.Spec.Schedule)
+		if err != nil {
+			return scheduled, &invalidScheduleError{schedule: instance.Spec.Schedule, err: err}
+		}
+
+		if isSyncOverdue(schedule, getLastSyncTime(instance, instance.Status.LastSyncSuccessTime), intervals, now) {
+			return scheduled, fmt.Errorf("No successful synchronization occurred during the last %d intervals of schedule '%s'", intervals, instance.Spec.Schedule)
+		}
+	}
+
+	for _, provider := range instance.Spec.Providers {
+
+		if provider.Schedule == "" {
+			continue
+		}
+
+		scheduled = true
+
+		providerSchedule, err := cron.ParseStandard(provider.Schedule)
+		if err != nil {
+			return scheduled, &invalidScheduleError{providerName: provider.Name, schedule: provider.Schedule, err: err}
+		}
+
+		var lastSyncSuccessTime *metav1.Time
+		for _, providerStatus := range instance.Status.Providers {
+			if providerStatus.Name == provider.Name {
+				lastSyncSuccessTime = providerStatus.LastSyncSuccessTime
+			}
+		}
+
+		if isSyncOverdue(providerSchedule, getLastSyncTime(instance, lastSyncSuccessTime), intervals, now) {
+			return scheduled, fmt.Errorf("No successful synchronization of provider '%s' occurred during the last %d intervals of schedule '%s'", provider.Name, intervals, provider.Schedule)
+		}
+	}
+
+	return scheduled, nil
+}
+
+// getLastSyncTime returns the time of the last successful synchronization, or the creation time of the GroupSync when it never
+// synchronized successfully
+func getLastSyncTime(instance *redhatcopv1alpha1.GroupSync, lastSyncSuccessTime *metav1.Time) time.Time {
+
+	if lastSyncSuccessTime == nil {
+		return instance.GetCreationTimestamp().Time
+	}
+
+	return lastSyncSuccessTime.Time
+}
+
+// isSyncOverdue determines whether the number of scheduled intervals elapsed since the last successful synchronization
+func isSyncOverdue(schedule cron.Schedule, lastSync time.Time, intervals int, now time.Time) bool {
+
+	staleTime := lastSync
+
+	for i := 0; i < intervals; i++ {
+		if staleTime = schedule.Next(staleTime); staleTime.IsZero() {
+			return false
+		}
+	}
+
+	return now.After(staleTime)
+}
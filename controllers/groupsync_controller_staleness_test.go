@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+func TestGetSyncStaleness(t *testing.T) {
+
+	created := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		schedule          string
+		providerSchedule  string
+		lastSyncSuccess   *metav1.Time
+		now               time.Time
+		expectedScheduled bool
+		expectedReason    string
+	}{
+		{
+			name:              "not scheduled",
+			now:               created.Add(24 * time.Hour),
+			expectedScheduled: false,
+		},
+		{
+			name:              "current",
+			schedule:          "0 * * * *",
+			lastSyncSuccess:   &metav1.Time{Time: created.Add(2 * time.Hour)},
+			now:               created.Add(3 * time.Hour),
+			expectedScheduled: true,
+			expectedReason:    redhatcopv1alpha1.SyncCurrentReason,
+		},
+		{
+			name:              "overdue",
+			schedule:          "0 * * * *",
+			lastSyncSuccess:   &metav1.Time{Time: created.Add(time.Hour)},
+			now:               created.Add(5 * time.Hour),
+			expectedScheduled: true,
+			expectedReason:    redhatcopv1alpha1.SyncOverdueReason,
+		},
+		{
+			name:              "invalid schedule",
+			schedule:          "every hour",
+			now:               created.Add(time.Minute),
+			expectedScheduled: true,
+			expectedReason:    redhatcopv1alpha1.InvalidScheduleReason,
+		},
+		{
+			name:              "invalid provider schedule",
+			providerSchedule:  "61 * * * *",
+			now:               created.Add(time.Minute),
+			expectedScheduled: true,
+			expectedReason:    redhatcopv1alpha1.InvalidScheduleReason,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			instance := &redhatcopv1alpha1.GroupSync{
+				ObjectMeta: metav1.ObjectMeta{Name: "staleness", CreationTimestamp: metav1.Time{Time: created}},
+				Spec: redhatcopv1alpha1.GroupSyncSpec{
+					Schedule:  test.schedule,
+					Providers: []redhatcopv1alpha1.Provider{{Name: "provider", Schedule: test.providerSchedule}},
+				},
+				Status: redhatcopv1alpha1.GroupSyncStatus{LastSyncSuccessTime: test.lastSyncSuccess},
+			}
+
+			scheduled, staleness := getSyncStaleness(instance, 3, test.now)
+
+			if scheduled != test.expectedScheduled {
+				t.Fatalf("expected scheduled to be %t, found %t", test.expectedScheduled, scheduled)
+			}
+
+			if !scheduled {
+				return
+			}
+
+			if condition := newSyncStaleCondition(instance, staleness); condition.Reason != test.expectedReason {
+				t.Errorf("expected reason '%s', found '%s' (%v)", test.expectedReason, condition.Reason, staleness)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+const (
+	testNamespace = "default"
+	testTimeout   = 30 * time.Second
+	testInterval  = 250 * time.Millisecond
+)
+
+// The following specs synchronize fake providers, whose groups are specified inline, using the controller running in the test
+// environment. Each spec uses its own GroupSync and groups as groups are cluster scoped
+var _ = Describe("GroupSync controller", func() {
+
+	var instance *redhatcopv1alpha1.GroupSync
+
+	AfterEach(func() {
+		if instance != nil {
+			Expect(k8sClient.Delete(context.Background(), instance)).To(Succeed())
+			instance = nil
+		}
+	})
+
+	It("synchronizes the groups of a provider", func() {
+		instance = newGroupSync("e2e-sync", redhatcopv1alpha1.Provider{
+			Name: "fake",
+			ProviderType: &redhatcopv1alpha1.ProviderType{
+				Fake: &redhatcopv1alpha1.FakeProvider{
+					Groups: []redhatcopv1alpha1.FakeGroup{
+						{Name: "e2e-sync-developers", ID: "42", Users: []string{"john", "jane", "john"}},
+					},
+				},
+			},
+		})
+		Expect(k8sClient.Create(context.Background(), instance)).To(Succeed())
+
+		Eventually(getGroupUsers("e2e-sync-developers"), testTimeout, testInterval).Should(Equal([]string{"jane", "john"}))
+
+		group := &userv1.Group{}
+		Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: "e2e-sync-developers"}, group)).To(Succeed())
+		Expect(group.Labels).To(HaveKeyWithValue(constants.SyncProvider, "e2e-sync_fake"))
+		Expect(group.Labels).To(HaveKeyWithValue(constants.GroupSyncNamespace, testNamespace))
+		Expect(group.Annotations).To(HaveKeyWithValue(constants.SyncSourceHost, "fake"))
+		Expect(group.Annotations).To(HaveKeyWithValue(constants.SyncSourceUID, "42"))
+
+		Eventually(getProviderCondition(instance, "fake", redhatcopv1alpha1.SyncedProviderCondition), testTimeout, testInterval).Should(
+			WithTransform(func(condition *metav1.Condition) metav1.ConditionStatus { return condition.Status }, Equal(metav1.ConditionTrue)))
+
+		synced := getGroupSync(instance)
+		Expect(synced.Status.LastSyncSuccessTime).ToNot(BeNil())
+		Expect(synced.Status.ObservedGeneration).To(Equal(synced.Generation))
+	})
+
+	It("applies the transformations of the provider", func() {
+		instance = newGroupSync("e2e-transform", redhatcopv1alpha1.Provider{
+			Name:                "fake",
+			GroupNamePrefix:     "e2e-",
+			LowercaseGroupNames: true,
+			UsernameCase:        redhatcopv1alpha1.LowerUsernameCase,
+			ProviderType: &redhatcopv1alpha1.ProviderType{
+				Fake: &redhatcopv1alpha1.FakeProvider{
+					Groups: []redhatcopv1alpha1.FakeGroup{
+						{Name: "Transform-Admins", Users: []string{"Jane"}},
+					},
+				},
+			},
+		})
+		Expect(k8sClient.Create(context.Background(), instance)).To(Succeed())
+
+		Eventually(getGroupUsers("e2e-transform-admins"), testTimeout, testInterval).Should(Equal([]string{"jane"}))
+	})
+
+	It("merges groups returned by multiple providers", func() {
+		instance = newGroupSync("e2e-merge",
+			redhatcopv1alpha1.Provider{
+				Name: "first",
+				ProviderType: &redhatcopv1alpha1.ProviderType{
+					Fake: &redhatcopv1alpha1.FakeProvider{
+						Groups: []redhatcopv1alpha1.FakeGroup{{Name: "e2e-merge-shared", Users: []string{"alice"}}},
+					},
+				},
+			},
+			redhatcopv1alpha1.Provider{
+				Name: "second",
+				ProviderType: &redhatcopv1alpha1.ProviderType{
+					Fake: &redhatcopv1alpha1.FakeProvider{
+						Groups: []redhatcopv1alpha1.FakeGroup{{Name: "e2e-merge-shared", Users: []string{"bob"}}},
+					},
+				},
+			})
+		instance.Spec.GroupMergeStrategy = redhatcopv1alpha1.UnionGroupMergeStrategy
+		Expect(k8sClient.Create(context.Background(), instance)).To(Succeed())
+
+		Eventually(getGroupUsers("e2e-merge-shared"), testTimeout, testInterval).Should(Equal([]string{"alice", "bob"}))
+	})
+
+	It("prunes groups that are no longer returned by the provider", func() {
+		instance = newGroupSync("e2e-prune", redhatcopv1alpha1.Provider{
+			Name: "fake",
+			ProviderType: &redhatcopv1alpha1.ProviderType{
+				Fake: &redhatcopv1alpha1.FakeProvider{
+					Prune: true,
+					Groups: []redhatcopv1alpha1.FakeGroup{
+						{Name: "e2e-prune-kept", Users: []string{"jane"}},
+						{Name: "e2e-prune-removed", Users: []string{"john"}},
+					},
+				},
+			},
+		})
+		Expect(k8sClient.Create(context.Background(), instance)).To(Succeed())
+
+		Eventually(getGroupUsers("e2e-prune-removed"), testTimeout, testInterval).Should(Equal([]string{"john"}))
+		Eventually(func() *metav1.Time { return getGroupSync(instance).Status.LastSyncSuccessTime }, testTimeout, testInterval).ShouldNot(BeNil())
+
+		// Groups are stale when their synchronization timestamp, recorded with a precision of a second, precedes the synchronization
+		time.Sleep(time.Second)
+
+		Eventually(func() error {
+			updated := getGroupSync(instance)
+			updated.Spec.Providers[0].Fake.Groups = updated.Spec.Providers[0].Fake.Groups[:1]
+			return k8sClient.Update(context.Background(), updated)
+		}, testTimeout, testInterval).Should(Succeed())
+
+		Eventually(func() bool {
+			err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "e2e-prune-removed"}, &userv1.Group{})
+			return apierrors.IsNotFound(err)
+		}, testTimeout, testInterval).Should(BeTrue())
+
+		Expect(getGroupUsers("e2e-prune-kept")()).To(Equal([]string{"jane"}))
+	})
+
+	It("reports providers that fail validation in the status", func() {
+		instance = newGroupSync("e2e-invalid", redhatcopv1alpha1.Provider{
+			Name: "fake",
+			ProviderType: &redhatcopv1alpha1.ProviderType{
+				Fake: &redhatcopv1alpha1.FakeProvider{
+					Groups: []redhatcopv1alpha1.FakeGroup{
+						{Name: "e2e-invalid-duplicate", Users: []string{"jane"}},
+						{Name: "e2e-invalid-duplicate", Users: []string{"john"}},
+					},
+				},
+			},
+		})
+		Expect(k8sClient.Create(context.Background(), instance)).To(Succeed())
+
+		Eventually(getProviderCondition(instance, "fake", redhatcopv1alpha1.ValidatedProviderCondition), testTimeout, testInterval).Should(
+			WithTransform(func(condition *metav1.Condition) string { return condition.Reason }, Equal(redhatcopv1alpha1.ValidationFailedReason)))
+
+		Consistently(func() bool {
+			err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "e2e-invalid-duplicate"}, &userv1.Group{})
+			return apierrors.IsNotFound(err)
+		}, 2*time.Second, testInterval).Should(BeTrue())
+	})
+
+	It("identifies the synchronization in its report", func() {
+		instance = newGroupSync("e2e-report", redhatcopv1alpha1.Provider{
+			Name:     "fake",
+			LogLevel: redhatcopv1alpha1.DebugLogLevel,
+			ProviderType: &redhatcopv1alpha1.ProviderType{
+				Fake: &redhatcopv1alpha1.FakeProvider{
+					Groups: []redhatcopv1alpha1.FakeGroup{
+						{Name: "e2e-report-developers", Users: []string{"jane"}},
+					},
+				},
+			},
+		})
+		Expect(k8sClient.Create(context.Background(), instance)).To(Succeed())
+
+		Eventually(func() string {
+			report := &redhatcopv1alpha1.GroupSyncReport{}
+			if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, report); err != nil {
+				return ""
+			}
+			return report.Status.SyncRunID
+		}, testTimeout, testInterval).ShouldNot(BeEmpty())
+	})
+})
+
+// newGroupSync returns a GroupSync of the test namespace synchronizing the providers
+func newGroupSync(name string, providers ...redhatcopv1alpha1.Provider) *redhatcopv1alpha1.GroupSync {
+	return &redhatcopv1alpha1.GroupSync{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Spec: redhatcopv1alpha1.GroupSyncSpec{
+			Providers: providers,
+		},
+	}
+}
+
+// getGroupSync returns the latest version of the GroupSync
+func getGroupSync(instance *redhatcopv1alpha1.GroupSync) *redhatcopv1alpha1.GroupSync {
+	groupSync := &redhatcopv1alpha1.GroupSync{}
+	Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, groupSync)).To(Succeed())
+	return groupSync
+}
+
+// getGroupUsers returns a function returning the users of the group, or nil when the group does not exist
+func getGroupUsers(name string) func() []string {
+	return func() []string {
+		group := &userv1.Group{}
+		if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: name}, group); err != nil {
+			return nil
+		}
+		return group.Users
+	}
+}
+
+// getProviderCondition returns a function returning the condition of the provider of the GroupSync, or an empty condition when the
+// condition has not been reported yet
+func getProviderCondition(instance *redhatcopv1alpha1.GroupSync, providerName string, conditionType string) func() *metav1.Condition {
+	return func() *metav1.Condition {
+		for _, provider := range getGroupSync(instance).Status.Providers {
+			if provider.Name != providerName {
+				continue
+			}
+			if condition := meta.FindStatusCondition(provider.Conditions, conditionType); condition != nil {
+				return condition
+			}
+		}
+		return &metav1.Condition{}
+	}
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// syncRequestedPredicate fires an update event when the annotation requesting an immediate synchronization is added or changed
+type syncRequestedPredicate struct{}
+
+func (syncRequestedPredicate) Create(e event.CreateEvent) bool {
+	return false
+}
+
+func (syncRequestedPredicate) Delete(e event.DeleteEvent) bool {
+	return false
+}
+
+func (syncRequestedPredicate) Generic(e event.GenericEvent) bool {
+	return false
+}
+
+func (syncRequestedPredicate) Update(e event.UpdateEvent) bool {
+
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	syncNow, requested := getSyncRequest(e.ObjectNew)
+
+	return requested && syncNow != e.ObjectOld.GetAnnotations()[constants.SyncNow]
+}
+
+// getSyncRequest returns the value of the annotation requesting an immediate synchronization and whether a synchronization is requested.
+// Any value other than an empty string or false requests a synchronization so that a timestamp can be used to request another one
+func getSyncRequest(obj client.Object) (string, bool) {
+
+	syncNow, found := obj.GetAnnotations()[constants.SyncNow]
+
+	return syncNow, found && syncNow != "" && syncNow != "false"
+}
+
+// clearSyncRequest removes the annotation requesting an immediate synchronization once the synchronization has run. The annotation is
+// kept when its value was changed in the meantime to request another synchronization
+func (r *GroupSyncReconciler) clearSyncRequest(context context.Context, key types.NamespacedName, syncNow string, logger logr.Logger) {
+
+	instance := &redhatcopv1alpha1.GroupSync{}
+
+	if err := r.GetClient().Get(context, key, instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to Clear Synchronization Request")
+		}
+		return
+	}
+
+	if instance.GetAnnotations()[constants.SyncNow] != syncNow {
+		return
+	}
+
+	patch := client.MergeFrom(instance.DeepCopy())
+	delete(instance.Annotations, constants.SyncNow)
+
+	if err := r.GetClient().Patch(context, instance, patch); err != nil {
+		logger.Error(err, "Failed to Clear Synchronization Request")
+	}
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/plan"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/validation/path"
+	"k8s.io/apimachinery/pkg/types"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// provisionUsers creates the OpenShift Users of the members of the groups synchronized by the provider that do not exist yet, so that
+// tooling relying on Users works before their first login. When an identity provider is configured, an Identity of the identity provider
+// is also created and linked to each User. Existing Users are only updated to link their Identity, and Users are never deleted
+func (r *GroupSyncReconciler) provisionUsers(context context.Context, instance *redhatcopv1alpha1.GroupSync, providerName string, usernames []string, logger logr.Logger) error {
+
+	userProvisioning := getUserProvisioning(instance, providerName)
+
+	if userProvisioning == nil {
+		return nil
+	}
+
+	for _, username := range plan.SortedUniqueStrings(usernames) {
+
+		if errs := path.IsValidPathSegmentName(username); len(errs) > 0 {
+			logger.Info("Warning: Skipping User with Invalid Name", "Provider", providerName, "User Name", username, "Reason", strings.Join(errs, ", "))
+			continue
+		}
+
+		user, err := r.ensureUser(context, instance, providerName, username, logger)
+
+		if err != nil {
+			return err
+		}
+
+		if userProvisioning.IdentityProvider != "" {
+			if err := r.ensureIdentity(context, instance, providerName, userProvisioning.IdentityProvider, user, logger); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ensureUser returns the User, creating it when it does not exist
+func (r *GroupSyncReconciler) ensureUser(context context.Context, instance *redhatcopv1alpha1.GroupSync, providerName string, username string, logger logr.Logger) (*userv1.User, error) {
+
+	user := &userv1.User{}
+	err := r.GetClient().Get(context, types.NamespacedName{Name: username}, user)
+
+	if !apierrors.IsNotFound(err) {
+		return user, err
+	}
+
+	logger.Info("Creating User", "Provider", providerName, "User Name", username)
+
+	user = &userv1.User{}
+	user.Name = username
+	user.Labels = getUserProvisioningLabels(instance, providerName)
+
+	return user, r.GetClient().Create(context, user)
+}
+
+// ensureIdentity creates the Identity of the User for the identity provider when it does not exist and links it to the User. Identities
+// already mapped to another User are left untouched
+func (r *GroupSyncReconciler) ensureIdentity(context context.Context, instance *redhatcopv1alpha1.GroupSync, providerName string, identityProvider string, user *userv1.User, logger logr.Logger) error {
+
+	identityName := fmt.Sprintf("%s:%s", identityProvider, user.Name)
+
+	identity := &userv1.Identity{}
+	err := r.GetClient().Get(context, types.NamespacedName{Name: identityName}, identity)
+
+	if apierrors.IsNotFound(err) {
+		logger.Info("Creating Identity", "Provider", providerName, "Identity", identityName)
+
+		identity = &userv1.Identity{
+			ProviderName:     identityProvider,
+			ProviderUserName: user.Name,
+			User: corev1.ObjectReference{
+				Name: user.Name,
+				UID:  user.UID,
+			},
+		}
+		identity.Name = identityName
+		identity.Labels = getUserProvisioningLabels(instance, providerName)
+
+		if err := r.GetClient().Create(context, identity); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else if identity.User.Name != user.Name {
+		logger.Info("Warning: Skipping Identity Mapped to Another User", "Provider", providerName, "Identity", identityName, "User Name", identity.User.Name)
+		return nil
+	}
+
+	if containsString(user.Identities, identityName) {
+		return nil
+	}
+
+	user.Identities = append(user.Identities, identityName)
+
+	return r.GetClient().Update(context, user)
+}
+
+// getUserProvisioning returns the configuration of the Users created for the members of the groups of the provider
+func getUserProvisioning(instance *redhatcopv1alpha1.GroupSync, providerName string) *redhatcopv1alpha1.UserProvisioning {
+
+	for _, provider := range instance.Spec.Providers {
+		if provider.Name == providerName {
+			return provider.UserProvisioning
+		}
+	}
+
+	return nil
+}
+
+// getUserProvisioningLabels returns the labels of the Users and Identities created for the provider
+func getUserProvisioningLabels(instance *redhatcopv1alpha1.GroupSync, providerName string) map[string]string {
+	return map[string]string{
+		constants.GroupSyncName:      instance.Name,
+		constants.GroupSyncNamespace: instance.Namespace,
+		constants.ProviderName:       providerName,
+	}
+}
@@ -17,9 +17,14 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"net/http"
+	"net/http/pprof"
 	"os"
+	"strings"
+	"time"
 
 	userv1 "github.com/openshift/api/user/v1"
 	"github.com/redhat-cop/operator-utils/pkg/util"
@@ -32,7 +37,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	redhatcopv1beta1 "github.com/redhat-cop/group-sync-operator/api/v1beta1"
 	"github.com/redhat-cop/group-sync-operator/controllers"
+	"github.com/redhat-cop/group-sync-operator/pkg/audit"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/syncer"
+	"github.com/redhat-cop/group-sync-operator/pkg/tracing"
+	"github.com/redhat-cop/group-sync-operator/pkg/trigger"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -46,6 +57,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(redhatcopv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(redhatcopv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 
 	utilruntime.Must(userv1.AddToScheme(scheme))
@@ -56,11 +68,62 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var protectedGroupPrefixes string
+	var maxConcurrentProviderSyncs int
+	var enableSyncEndpoint bool
+	var caBundleConfigMap string
+	var shardIndex int
+	var shardCount int
+	var shardSelector string
+	var providerCheckInterval time.Duration
+	var enablePprof bool
+	var auditLogPath string
+	var execProviderDir string
+	var restrictCrossNamespaceReferences bool
+	var trustedNamespaces string
+	var staleSyncIntervals int
+	var otlpEndpoint string
+	var otlpInsecure bool
+	var traceSamplingRatio float64
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&protectedGroupPrefixes, "protected-group-prefixes", "system:",
+		"Comma separated list of Group name prefixes that will never be created, updated or pruned.")
+	flag.IntVar(&maxConcurrentProviderSyncs, "max-concurrent-provider-syncs", 4,
+		"The maximum number of providers of a GroupSync that are synchronized concurrently.")
+	flag.BoolVar(&enableSyncEndpoint, "enable-sync-endpoint", false,
+		"Enable the endpoint of the webhook server triggering synchronizations using POST requests to "+trigger.Path+"{namespace}/{name}.")
+	flag.StringVar(&caBundleConfigMap, "ca-bundle-configmap", "",
+		"ConfigMap containing CA certificates trusted by all providers in the form namespace/name or namespace/name/key.")
+	flag.IntVar(&shardIndex, "shard-index", 0,
+		"The index of the shard of GroupSyncs reconciled by the operator when GroupSyncs are sharded by hash.")
+	flag.IntVar(&shardCount, "shard-count", 1,
+		"The number of shards GroupSyncs are distributed across by hashing their namespace and name.")
+	flag.StringVar(&shardSelector, "shard-selector", "",
+		"Label selector of the GroupSyncs reconciled by the operator.")
+	flag.DurationVar(&providerCheckInterval, "provider-check-interval", 0,
+		"The interval between checks binding to the providers of the GroupSyncs, reported by the "+controllers.ProviderCheckPath+" endpoint of the metrics server. Disabled when 0.")
+	flag.BoolVar(&enablePprof, "enable-pprof", false,
+		"Enable the pprof profiling endpoints under /debug/pprof/ of the metrics server.")
+	flag.StringVar(&auditLogPath, "audit-log-path", "",
+		"File the users added to and removed from groups are appended to in JSON, or "+audit.StdoutPath+" for the standard output.")
+	flag.StringVar(&execProviderDir, "exec-provider-dir", "",
+		"Directory containing the commands exec providers may run. Exec providers are disabled when not specified.")
+	flag.BoolVar(&restrictCrossNamespaceReferences, "restrict-cross-namespace-references", false,
+		"Reject secrets and config maps referenced by GroupSyncs outside of their namespace unless shared with their namespace using the "+constants.SharedNamespaces+" annotation.")
+	flag.StringVar(&trustedNamespaces, "trusted-namespaces", "",
+		"Comma separated list of namespaces whose GroupSyncs may reference secrets and config maps of any namespace when cross namespace references are restricted.")
+	flag.IntVar(&staleSyncIntervals, "stale-sync-intervals", 3,
+		"The number of scheduled intervals without a successful synchronization after which the SyncStale condition of a GroupSync is set. Disabled when 0.")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "",
+		"The host:port of the OTLP collector the spans of reconciliations and synchronizations are exported to over gRPC. Tracing is disabled when not specified.")
+	flag.BoolVar(&otlpInsecure, "otlp-insecure", false,
+		"Export spans to the OTLP collector without TLS.")
+	flag.Float64Var(&traceSamplingRatio, "trace-sampling-ratio", 1,
+		"The ratio of reconciliations traced when tracing is enabled, between 0 and 1.")
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -71,13 +134,51 @@ func main() {
 			"the manager will watch and manage resources in all Namespaces")
 	}
 
+	caBundle, err := syncer.ParseCaBundleConfigMapRef(caBundleConfigMap)
+	if err != nil {
+		setupLog.Error(err, "invalid CA bundle ConfigMap")
+		os.Exit(1)
+	}
+
+	shard, err := controllers.NewShard(shardIndex, shardCount, shardSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid shard")
+		os.Exit(1)
+	}
+
+	syncer.ExecProviderDirectory = execProviderDir
+
+	if !restrictCrossNamespaceReferences {
+		setupLog.Info("WARNING: cross namespace references are not restricted, GroupSyncs may read the secrets and config maps of any namespace using the permissions of the operator. " +
+			"Set --restrict-cross-namespace-references on multi-tenant clusters")
+	}
+
+	auditLog, err := audit.NewLog(auditLogPath)
+	if err != nil {
+		setupLog.Error(err, "unable to open audit log")
+		os.Exit(1)
+	}
+
+	if otlpEndpoint != "" {
+		shutdownTracing, err := tracing.Setup(context.Background(), otlpEndpoint, otlpInsecure, traceSamplingRatio)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				setupLog.Error(err, "unable to flush spans")
+			}
+		}()
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                     scheme,
 		MetricsBindAddress:         metricsAddr,
 		Port:                       9443,
 		HealthProbeBindAddress:     probeAddr,
 		LeaderElection:             enableLeaderElection,
-		LeaderElectionID:           "085c249a.redhat.io",
+		LeaderElectionID:           shard.LeaderElectionID("085c249a.redhat.io"),
 		LeaderElectionResourceLock: "configmaps",
 		Namespace:                  watchNamespace,
 	})
@@ -86,15 +187,72 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&controllers.GroupSyncReconciler{
-		ReconcilerBase: util.NewReconcilerBase(mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor(controllerName), mgr.GetAPIReader()),
-		Log:            ctrl.Log.WithName("controllers").WithName(controllerName),
-	}).SetupWithManager(mgr); err != nil {
+	groupSyncReconciler := &controllers.GroupSyncReconciler{
+		ReconcilerBase:             util.NewReconcilerBase(mgr.GetClient(), mgr.GetScheme(), mgr.GetConfig(), mgr.GetEventRecorderFor(controllerName), mgr.GetAPIReader()),
+		Log:                        ctrl.Log.WithName("controllers").WithName(controllerName),
+		ProtectedGroupPrefixes:     getProtectedGroupPrefixes(protectedGroupPrefixes),
+		MaxConcurrentProviderSyncs: maxConcurrentProviderSyncs,
+		CaBundle:                   caBundle,
+		Shard:                      shard,
+		AuditLog:                   auditLog,
+		ReferencePolicy:            controllers.NewReferencePolicy(restrictCrossNamespaceReferences, trustedNamespaces),
+	}
+	if err = groupSyncReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", controllerName)
 		os.Exit(1)
 	}
+
+	// Conversion between API versions is disabled when running the operator locally without serving certificates
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&redhatcopv1alpha1.GroupSync{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", controllerName)
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	if enableSyncEndpoint {
+		mgr.GetWebhookServer().Register(trigger.Path, &trigger.Handler{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("trigger"),
+		})
+	}
+
+	if providerCheckInterval > 0 {
+		providerChecker := &controllers.ProviderChecker{
+			Reconciler: groupSyncReconciler,
+			Interval:   providerCheckInterval,
+			Log:        ctrl.Log.WithName("providerchecker"),
+		}
+		if err := mgr.Add(providerChecker); err != nil {
+			setupLog.Error(err, "unable to set up provider checker")
+			os.Exit(1)
+		}
+		if err := mgr.AddMetricsExtraHandler(controllers.ProviderCheckPath, providerChecker); err != nil {
+			setupLog.Error(err, "unable to set up provider check endpoint")
+			os.Exit(1)
+		}
+	}
+
+	if staleSyncIntervals > 0 {
+		stalenessChecker := &controllers.StalenessChecker{
+			Reconciler: groupSyncReconciler,
+			Intervals:  staleSyncIntervals,
+			Log:        ctrl.Log.WithName("stalenesschecker"),
+		}
+		if err := mgr.Add(stalenessChecker); err != nil {
+			setupLog.Error(err, "unable to set up staleness checker")
+			os.Exit(1)
+		}
+	}
+
+	if enablePprof {
+		if err := addPprofHandlers(mgr); err != nil {
+			setupLog.Error(err, "unable to set up pprof endpoints")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -124,3 +282,37 @@ func getWatchNamespace() (string, error) {
 	}
 	return ns, nil
 }
+
+// getProtectedGroupPrefixes parses the comma separated list of protected Group name prefixes
+func getProtectedGroupPrefixes(protectedGroupPrefixes string) []string {
+
+	prefixes := []string{}
+
+	for _, prefix := range strings.Split(protectedGroupPrefixes, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	return prefixes
+}
+
+// addPprofHandlers serves the pprof profiling endpoints from the metrics server
+func addPprofHandlers(mgr ctrl.Manager) error {
+
+	handlers := map[string]http.Handler{
+		"/debug/pprof/":        http.HandlerFunc(pprof.Index),
+		"/debug/pprof/cmdline": http.HandlerFunc(pprof.Cmdline),
+		"/debug/pprof/profile": http.HandlerFunc(pprof.Profile),
+		"/debug/pprof/symbol":  http.HandlerFunc(pprof.Symbol),
+		"/debug/pprof/trace":   http.HandlerFunc(pprof.Trace),
+	}
+
+	for path, handler := range handlers {
+		if err := mgr.AddMetricsExtraHandler(path, handler); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
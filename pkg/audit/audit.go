@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// StdoutPath writes the audit log to the standard output of the operator
+const StdoutPath = "-"
+
+// Action is the change made to the members of a group
+type Action string
+
+const (
+	AddUserAction    Action = "AddUser"
+	RemoveUserAction Action = "RemoveUser"
+)
+
+// Reason is the cause of a change made to the members of a group
+type Reason string
+
+const (
+	// SynchronizedReason indicates that the members of the group were changed by a synchronization
+	SynchronizedReason Reason = "Synchronized"
+	// PrunedReason indicates that the group was deleted as it is no longer returned by the provider
+	PrunedReason Reason = "Pruned"
+	// QuarantinedReason indicates that the members of the group were removed as it is no longer returned by the provider
+	QuarantinedReason Reason = "Quarantined"
+	// DeletedReason indicates that the group was deleted along with the GroupSync
+	DeletedReason Reason = "Deleted"
+)
+
+// Entry records a user added to or removed from a group
+type Entry struct {
+	Time               string `json:"time"`
+	Action             Action `json:"action"`
+	User               string `json:"user"`
+	Group              string `json:"group"`
+	Provider           string `json:"provider,omitempty"`
+	GroupSyncNamespace string `json:"groupSyncNamespace"`
+	GroupSyncName      string `json:"groupSyncName"`
+	Reason             Reason `json:"reason"`
+}
+
+// Log appends an entry in JSON for each user added to or removed from a group so that changes made to the members of groups can be
+// reviewed
+type Log struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+// NewLog opens the audit log at the path for appending, or returns nil when no path is specified
+func NewLog(path string) (*Log, error) {
+
+	if path == "" {
+		return nil, nil
+	}
+
+	if path == StdoutPath {
+		return &Log{writer: os.Stdout}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open audit log '%s': %w", path, err)
+	}
+
+	return &Log{writer: file}, nil
+}
+
+// RecordMembershipChange appends an entry for each user added to or removed from the group. Nothing is recorded when the log is nil
+func (l *Log) RecordMembershipChange(groupSyncNamespace string, groupSyncName string, providerName string, groupName string, addedUsers []string, removedUsers []string, reason Reason) error {
+
+	if l == nil || (len(addedUsers) == 0 && len(removedUsers) == 0) {
+		return nil
+	}
+
+	entry := Entry{
+		Time:               time.Now().UTC().Format(time.RFC3339),
+		Group:              groupName,
+		Provider:           providerName,
+		GroupSyncNamespace: groupSyncNamespace,
+		GroupSyncName:      groupSyncName,
+		Reason:             reason,
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	encoder := json.NewEncoder(l.writer)
+
+	for _, user := range addedUsers {
+		entry.Action = AddUserAction
+		entry.User = user
+
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("Failed to write audit log: %w", err)
+		}
+	}
+
+	for _, user := range removedUsers {
+		entry.Action = RemoveUserAction
+		entry.User = user
+
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("Failed to write audit log: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -1,13 +1,26 @@
 package constants
 
 const (
-	AnnotationBase    = "group-sync-operator.redhat-cop.io"
-	SyncTimestamp     = AnnotationBase + "/sync-time"
-	SyncSourceURL     = AnnotationBase + "/sync.source.url"
-	SyncSourceHost    = AnnotationBase + "/sync.source.host"
-	SyncSourceUID     = AnnotationBase + "/sync.source.uid"
-	SyncProvider      = AnnotationBase + "/sync-provider"
-	HierarchyChildren = "hierarchy_children"
-	HierarchyParent   = "hierarchy_parent"
-	HierarchyParents  = "hierarchy_parents"
+	AnnotationBase     = "group-sync-operator.redhat-cop.io"
+	SyncTimestamp      = AnnotationBase + "/sync-time"
+	SyncSourceURL      = AnnotationBase + "/sync.source.url"
+	SyncSourceHost     = AnnotationBase + "/sync.source.host"
+	SyncSourceUID      = AnnotationBase + "/sync.source.uid"
+	SyncProvider       = AnnotationBase + "/sync-provider"
+	SyncUsers          = AnnotationBase + "/sync-users"
+	GroupSyncName      = AnnotationBase + "/groupsync-name"
+	GroupSyncNamespace = AnnotationBase + "/groupsync-namespace"
+	ProviderName       = AnnotationBase + "/provider-name"
+	GroupSyncFinalizer = AnnotationBase + "/groups"
+	SyncNow            = AnnotationBase + "/sync-now"
+	HierarchyChildren  = "hierarchy_children"
+	HierarchyParent    = "hierarchy_parent"
+	HierarchyParents   = "hierarchy_parents"
+	ParentGroups       = AnnotationBase + "/parent-groups"
+	ChildGroups        = AnnotationBase + "/child-groups"
+	HierarchyDepth     = AnnotationBase + "/hierarchy-depth"
+	PruneCandidateTime = AnnotationBase + "/prune-candidate-time"
+	Orphaned           = AnnotationBase + "/orphaned"
+	SharedNamespaces   = AnnotationBase + "/shared-namespaces"
+	SyncRunID          = AnnotationBase + "/sync-run-id"
 )
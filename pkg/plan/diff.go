@@ -0,0 +1,143 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// Action is the change made to a group of the cluster
+type Action string
+
+const (
+	CreateAction Action = "Create"
+	UpdateAction Action = "Update"
+	DeleteAction Action = "Delete"
+)
+
+// Change describes how a synchronization changes a group of the cluster
+type Change struct {
+	GroupName    string
+	Action       Action
+	Users        int
+	AddedUsers   []string
+	RemovedUsers []string
+}
+
+// GetChanges compares the merged groups of each provider with the existing groups of the cluster and returns the changes the operator
+// would make for each provider, applying the empty group policy, the preservation of unmanaged users and the pruning of the providers.
+// Groups of shadow providers of a migration are not written. Protected groups and groups owned by other GroupSyncs are not taken into
+// account
+func GetChanges(instance *redhatcopv1alpha1.GroupSync, providerNames []string, providerGroups [][]userv1.Group, pruneProviders map[string]bool, existingGroups []userv1.Group, logger logr.Logger) [][]Change {
+
+	ocpGroups := map[string]*userv1.Group{}
+	for i := range existingGroups {
+		ocpGroups[existingGroups[i].Name] = &existingGroups[i]
+	}
+
+	// Groups written by any provider are not pruned, as they are relabeled by the provider writing them
+	writtenGroups := map[string]bool{}
+	for i, groups := range providerGroups {
+		if IsShadowProvider(instance, providerNames[i]) {
+			continue
+		}
+
+		for _, group := range groups {
+			writtenGroups[group.Name] = true
+		}
+	}
+
+	changes := make([][]Change, len(providerGroups))
+
+	for i, groups := range providerGroups {
+
+		changes[i] = []Change{}
+
+		if IsShadowProvider(instance, providerNames[i]) {
+			continue
+		}
+
+		emptyGroupPolicy := GetEmptyGroupPolicy(instance, providerNames[i])
+		prunedGroups := map[string]bool{}
+
+		for _, group := range sortedGroups(groups) {
+
+			ocpGroup, found := ocpGroups[group.Name]
+			users := GetGroupUsers(instance, providerNames[i], ocpGroup, group.Users, logger)
+
+			if !found {
+				if len(users) == 0 && emptyGroupPolicy != redhatcopv1alpha1.CreateEmptyGroupPolicy {
+					continue
+				}
+				changes[i] = append(changes[i], Change{GroupName: group.Name, Action: CreateAction, Users: len(users)})
+				continue
+			}
+
+			if len(users) == 0 && emptyGroupPolicy == redhatcopv1alpha1.PruneEmptyGroupPolicy {
+				prunedGroups[group.Name] = true
+				changes[i] = append(changes[i], Change{GroupName: group.Name, Action: DeleteAction})
+				continue
+			}
+
+			if added, removed := StringsDifference(users, ocpGroup.Users), StringsDifference(ocpGroup.Users, users); len(added) > 0 || len(removed) > 0 {
+				changes[i] = append(changes[i], Change{GroupName: group.Name, Action: UpdateAction, Users: len(users), AddedUsers: added, RemovedUsers: removed})
+			}
+		}
+
+		if pruneProviders[providerNames[i]] {
+			providerLabel := fmt.Sprintf("%s_%s", instance.Name, providerNames[i])
+
+			for _, ocpGroup := range sortedGroups(existingGroups) {
+				if ocpGroup.Labels[constants.SyncProvider] == providerLabel && !writtenGroups[ocpGroup.Name] && !prunedGroups[ocpGroup.Name] {
+					changes[i] = append(changes[i], Change{GroupName: ocpGroup.Name, Action: DeleteAction})
+				}
+			}
+		}
+	}
+
+	return changes
+}
+
+// PrintChanges prints the changes of the provider, prefixing created groups and added users with +, deleted groups and removed users
+// with - and updated groups with ~
+func PrintChanges(w io.Writer, providerName string, changes []Change) {
+
+	fmt.Fprintf(w, "Provider: %s\n", providerName)
+
+	for _, change := range changes {
+		switch change.Action {
+		case CreateAction:
+			fmt.Fprintf(w, "+ %s (%d users)\n", change.GroupName, change.Users)
+		case DeleteAction:
+			fmt.Fprintf(w, "- %s\n", change.GroupName)
+		case UpdateAction:
+			fmt.Fprintf(w, "~ %s\n", change.GroupName)
+			for _, user := range change.AddedUsers {
+				fmt.Fprintf(w, "    + %s\n", user)
+			}
+			for _, user := range change.RemovedUsers {
+				fmt.Fprintf(w, "    - %s\n", user)
+			}
+		}
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes")
+	}
+
+	fmt.Fprintln(w)
+}
+
+func sortedGroups(groups []userv1.Group) []userv1.Group {
+
+	sorted := append([]userv1.Group{}, groups...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	return sorted
+}
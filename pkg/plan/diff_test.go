@@ -0,0 +1,102 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+func newGroup(name string, labels map[string]string, annotations map[string]string, users ...string) userv1.Group {
+	return userv1.Group{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations}, Users: users}
+}
+
+func TestGetChanges(t *testing.T) {
+
+	providerLabel := map[string]string{constants.SyncProvider: "diff_ldap"}
+
+	tests := []struct {
+		name            string
+		provider        redhatcopv1alpha1.Provider
+		migration       *redhatcopv1alpha1.Migration
+		prune           bool
+		groups          []userv1.Group
+		existingGroups  []userv1.Group
+		expectedChanges []Change
+	}{
+		{
+			name:            "new group is created",
+			groups:          []userv1.Group{newGroup("admins", nil, nil, "bob", "alice", "bob")},
+			expectedChanges: []Change{{GroupName: "admins", Action: CreateAction, Users: 2}},
+		},
+		{
+			name:            "unchanged group",
+			groups:          []userv1.Group{newGroup("admins", nil, nil, "bob", "alice")},
+			existingGroups:  []userv1.Group{newGroup("admins", providerLabel, nil, "alice", "bob")},
+			expectedChanges: []Change{},
+		},
+		{
+			name:            "members are added and removed",
+			groups:          []userv1.Group{newGroup("admins", nil, nil, "alice", "carol")},
+			existingGroups:  []userv1.Group{newGroup("admins", providerLabel, nil, "alice", "bob")},
+			expectedChanges: []Change{{GroupName: "admins", Action: UpdateAction, Users: 2, AddedUsers: []string{"carol"}, RemovedUsers: []string{"bob"}}},
+		},
+		{
+			name:            "unmanaged users are preserved",
+			provider:        redhatcopv1alpha1.Provider{PreserveUnmanagedUsers: true},
+			groups:          []userv1.Group{newGroup("admins", nil, nil, "carol")},
+			existingGroups:  []userv1.Group{newGroup("admins", providerLabel, map[string]string{constants.SyncUsers: `["alice"]`}, "alice", "manual")},
+			expectedChanges: []Change{{GroupName: "admins", Action: UpdateAction, Users: 2, AddedUsers: []string{"carol"}, RemovedUsers: []string{"alice"}}},
+		},
+		{
+			name:            "empty group is skipped",
+			provider:        redhatcopv1alpha1.Provider{EmptyGroupPolicy: redhatcopv1alpha1.SkipEmptyGroupPolicy},
+			groups:          []userv1.Group{newGroup("admins", nil, nil)},
+			expectedChanges: []Change{},
+		},
+		{
+			name:            "empty group is pruned",
+			provider:        redhatcopv1alpha1.Provider{EmptyGroupPolicy: redhatcopv1alpha1.PruneEmptyGroupPolicy},
+			groups:          []userv1.Group{newGroup("admins", nil, nil)},
+			existingGroups:  []userv1.Group{newGroup("admins", providerLabel, nil, "alice")},
+			expectedChanges: []Change{{GroupName: "admins", Action: DeleteAction}},
+		},
+		{
+			name:            "groups no longer returned are pruned",
+			prune:           true,
+			groups:          []userv1.Group{newGroup("admins", nil, nil, "alice")},
+			existingGroups:  []userv1.Group{newGroup("admins", providerLabel, nil, "alice"), newGroup("removed", providerLabel, nil, "bob"), newGroup("other", nil, nil, "carol")},
+			expectedChanges: []Change{{GroupName: "removed", Action: DeleteAction}},
+		},
+		{
+			name:            "shadow providers do not change groups",
+			migration:       &redhatcopv1alpha1.Migration{Providers: []string{"ldap", "azure"}, SourceOfTruth: "azure"},
+			groups:          []userv1.Group{newGroup("admins", nil, nil, "alice")},
+			expectedChanges: []Change{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			provider := test.provider
+			provider.Name = "ldap"
+
+			instance := &redhatcopv1alpha1.GroupSync{
+				ObjectMeta: metav1.ObjectMeta{Name: "diff"},
+				Spec:       redhatcopv1alpha1.GroupSyncSpec{Providers: []redhatcopv1alpha1.Provider{provider}, Migration: test.migration},
+			}
+
+			changes := GetChanges(instance, []string{"ldap"}, [][]userv1.Group{test.groups}, map[string]bool{"ldap": test.prune}, test.existingGroups, logf.Log)
+
+			if !reflect.DeepEqual(changes[0], test.expectedChanges) {
+				t.Errorf("expected changes %+v, found %+v", test.expectedChanges, changes[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	userv1 "github.com/openshift/api/user/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// MergeProviderGroups resolves the groups returned by more than one provider according to the group merge strategy and returns the
+// groups to write for each provider. Providers are given in the order they are declared. Failed providers and providers that do not
+// write groups are not considered. When using the error strategy, the conflicts of each provider are returned by provider name
+func MergeProviderGroups(instance *redhatcopv1alpha1.GroupSync, providerNames []string, providerGroups [][]userv1.Group, failedProviders map[string]bool) ([][]userv1.Group, map[string]error) {
+
+	mergedGroups := make([][]userv1.Group, len(providerGroups))
+	conflicts := map[string]error{}
+
+	// Index of the providers returning each group in the order the providers are declared
+	groupProviders := map[string][]int{}
+
+	for i, groups := range providerGroups {
+		mergedGroups[i] = groups
+
+		if failedProviders[providerNames[i]] || IsShadowProvider(instance, providerNames[i]) {
+			continue
+		}
+
+		for _, group := range groups {
+			if providers := groupProviders[group.Name]; len(providers) == 0 || providers[len(providers)-1] != i {
+				groupProviders[group.Name] = append(providers, i)
+			}
+		}
+	}
+
+	// Groups removed from each provider along with the users of the groups merged into the provider declared first
+	removedGroups := make([]map[string]bool, len(providerGroups))
+	mergedUsers := make([]map[string][]string, len(providerGroups))
+	for i := range providerGroups {
+		removedGroups[i] = map[string]bool{}
+		mergedUsers[i] = map[string][]string{}
+	}
+
+	providerConflicts := map[int][]error{}
+
+	for _, groupName := range sortedGroupNames(groupProviders) {
+
+		providers := groupProviders[groupName]
+
+		if len(providers) < 2 {
+			continue
+		}
+
+		switch GetGroupMergeStrategy(instance) {
+		case redhatcopv1alpha1.ErrorGroupMergeStrategy:
+			conflictingProviderNames := []string{}
+			for _, provider := range providers {
+				conflictingProviderNames = append(conflictingProviderNames, providerNames[provider])
+			}
+
+			for _, provider := range providers {
+				providerConflicts[provider] = append(providerConflicts[provider], fmt.Errorf("Group '%s' is returned by multiple providers: %s", groupName, strings.Join(conflictingProviderNames, ", ")))
+			}
+		case redhatcopv1alpha1.UnionGroupMergeStrategy:
+			users := []string{}
+			for _, provider := range providers {
+				users = append(users, getGroupUsers(providerGroups[provider], groupName)...)
+			}
+			mergedUsers[providers[0]][groupName] = UniqueStrings(users)
+
+			for _, provider := range providers[1:] {
+				removedGroups[provider][groupName] = true
+			}
+		default:
+			for _, provider := range providers[1:] {
+				removedGroups[provider][groupName] = true
+			}
+		}
+	}
+
+	for provider, errs := range providerConflicts {
+		conflicts[providerNames[provider]] = utilerrors.NewAggregate(errs)
+	}
+
+	for i := range mergedGroups {
+
+		if len(removedGroups[i]) == 0 && len(mergedUsers[i]) == 0 {
+			continue
+		}
+
+		groups := []userv1.Group{}
+
+		for _, group := range mergedGroups[i] {
+			if removedGroups[i][group.Name] {
+				continue
+			}
+
+			if users, found := mergedUsers[i][group.Name]; found {
+				group.Users = users
+			}
+
+			groups = append(groups, group)
+		}
+
+		mergedGroups[i] = groups
+	}
+
+	return mergedGroups, conflicts
+}
+
+func getGroupUsers(groups []userv1.Group, groupName string) []string {
+
+	users := []string{}
+
+	for _, group := range groups {
+		if group.Name == groupName {
+			users = append(users, group.Users...)
+		}
+	}
+
+	return users
+}
+
+func sortedGroupNames(groupProviders map[string][]int) []string {
+
+	groupNames := []string{}
+	for groupName := range groupProviders {
+		groupNames = append(groupNames, groupName)
+	}
+
+	sort.Strings(groupNames)
+
+	return groupNames
+}
@@ -0,0 +1,144 @@
+package plan
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/go-logr/logr"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// GetEmptyGroupPolicy returns how the groups of the provider without users are handled
+func GetEmptyGroupPolicy(instance *redhatcopv1alpha1.GroupSync, providerName string) redhatcopv1alpha1.EmptyGroupPolicy {
+
+	for _, provider := range instance.Spec.Providers {
+		if provider.Name == providerName && provider.EmptyGroupPolicy != "" {
+			return provider.EmptyGroupPolicy
+		}
+	}
+
+	return redhatcopv1alpha1.CreateEmptyGroupPolicy
+}
+
+// IsPreservingUnmanagedUsers determines whether users added manually to the groups of the provider are kept
+func IsPreservingUnmanagedUsers(instance *redhatcopv1alpha1.GroupSync, providerName string) bool {
+
+	for _, provider := range instance.Spec.Providers {
+		if provider.Name == providerName {
+			return provider.PreserveUnmanagedUsers
+		}
+	}
+
+	return false
+}
+
+// GetGroupMergeStrategy returns how groups returned by more than one provider are resolved
+func GetGroupMergeStrategy(instance *redhatcopv1alpha1.GroupSync) redhatcopv1alpha1.GroupMergeStrategy {
+
+	if instance.Spec.GroupMergeStrategy == "" {
+		return redhatcopv1alpha1.PriorityGroupMergeStrategy
+	}
+
+	return instance.Spec.GroupMergeStrategy
+}
+
+// IsMigrationProvider determines whether the provider participates in a migration
+func IsMigrationProvider(instance *redhatcopv1alpha1.GroupSync, providerName string) bool {
+
+	if instance.Spec.Migration == nil {
+		return false
+	}
+
+	for _, migrationProvider := range instance.Spec.Migration.Providers {
+		if migrationProvider == providerName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsShadowProvider determines whether the provider participates in a migration without being the source of truth
+func IsShadowProvider(instance *redhatcopv1alpha1.GroupSync, providerName string) bool {
+	return IsMigrationProvider(instance, providerName) && instance.Spec.Migration.SourceOfTruth != providerName
+}
+
+// GetGroupUsers returns the users written to a group synchronized by the provider. Users are deduplicated and sorted as providers may
+// return the same user several times and in any order. Existing users that were not synchronized are kept when the provider preserves
+// unmanaged users
+func GetGroupUsers(instance *redhatcopv1alpha1.GroupSync, providerName string, existingGroup *userv1.Group, users []string, logger logr.Logger) []string {
+
+	users = SortedUniqueStrings(users)
+
+	if existingGroup != nil && IsPreservingUnmanagedUsers(instance, providerName) {
+		return SortedUniqueStrings(GetUsersPreservingUnmanagedUsers(existingGroup, users, logger))
+	}
+
+	return users
+}
+
+// GetUsersPreservingUnmanagedUsers returns the synchronized users along with the existing users of the group that were not previously
+// synchronized. The synchronized users are recorded in an annotation of the group. When the annotation is not found, all of the
+// existing users are considered unmanaged
+func GetUsersPreservingUnmanagedUsers(existingGroup *userv1.Group, users []string, logger logr.Logger) []string {
+
+	previouslySyncedUsers := []string{}
+
+	if syncedUsers, found := existingGroup.GetAnnotations()[constants.SyncUsers]; found {
+		if err := json.Unmarshal([]byte(syncedUsers), &previouslySyncedUsers); err != nil {
+			logger.Info("Warning: Unable to Parse Synchronized Users Annotation", "Group Name", existingGroup.Name, "Error", err.Error())
+		}
+	}
+
+	return UniqueStrings(append(append([]string{}, users...), StringsDifference(existingGroup.Users, previouslySyncedUsers)...))
+}
+
+// StringsDifference returns the sorted values contained in lhs that are not contained in rhs
+func StringsDifference(lhs, rhs []string) []string {
+
+	rhsValues := map[string]bool{}
+	for _, value := range rhs {
+		rhsValues[value] = true
+	}
+
+	difference := []string{}
+	for _, value := range lhs {
+		if !rhsValues[value] {
+			difference = append(difference, value)
+			rhsValues[value] = true
+		}
+	}
+
+	sort.Strings(difference)
+
+	return difference
+}
+
+// UniqueStrings returns the values without duplicates, preserving their order
+func UniqueStrings(values []string) []string {
+
+	found := map[string]bool{}
+	unique := []string{}
+
+	for _, value := range values {
+		if !found[value] {
+			found[value] = true
+			unique = append(unique, value)
+		}
+	}
+
+	return unique
+}
+
+// SortedUniqueStrings returns the distinct values in ascending order so that lists written to the cluster do not change between
+// synchronizations unless their content changes
+func SortedUniqueStrings(values []string) []string {
+
+	unique := UniqueStrings(values)
+	sort.Strings(unique)
+
+	return unique
+}
@@ -0,0 +1,73 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestGetUsersPreservingUnmanagedUsers(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		existingUsers []string
+		users         []string
+		expectedUsers []string
+	}{
+		{
+			name:          "existing users are unmanaged without the annotation",
+			existingUsers: []string{"manual", "alice"},
+			users:         []string{"bob"},
+			expectedUsers: []string{"bob", "alice", "manual"},
+		},
+		{
+			name:          "users previously synchronized are not preserved",
+			annotations:   map[string]string{constants.SyncUsers: `["alice"]`},
+			existingUsers: []string{"alice", "manual"},
+			users:         []string{"bob"},
+			expectedUsers: []string{"bob", "manual"},
+		},
+		{
+			name:          "synchronized users are not duplicated",
+			annotations:   map[string]string{constants.SyncUsers: `[]`},
+			existingUsers: []string{"manual"},
+			users:         []string{"manual", "bob"},
+			expectedUsers: []string{"manual", "bob"},
+		},
+		{
+			name:          "existing users are unmanaged when the annotation is corrupt",
+			annotations:   map[string]string{constants.SyncUsers: `alice`},
+			existingUsers: []string{"alice", "manual"},
+			users:         []string{"bob"},
+			expectedUsers: []string{"bob", "alice", "manual"},
+		},
+		{
+			name:          "no users are preserved when all were synchronized",
+			annotations:   map[string]string{constants.SyncUsers: `["alice","bob"]`},
+			existingUsers: []string{"alice", "bob"},
+			users:         []string{},
+			expectedUsers: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			existingGroup := &userv1.Group{
+				ObjectMeta: metav1.ObjectMeta{Name: "group", Annotations: test.annotations},
+				Users:      test.existingUsers,
+			}
+
+			users := GetUsersPreservingUnmanagedUsers(existingGroup, test.users, logf.Log)
+
+			if !reflect.DeepEqual(users, test.expectedUsers) {
+				t.Errorf("expected users %v, found %v", test.expectedUsers, users)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// The provider plugin protocol is a gRPC service whose messages are encoded in JSON, so that plugins can be implemented in any
+// language supporting gRPC without generating code from protocol buffers. Plugins written in Go register their implementation of
+// GroupProviderServer using RegisterGroupProviderServer
+const (
+	// ServiceName is the name of the gRPC service served by plugins
+	ServiceName = "groupsync.plugin.v1.GroupProvider"
+	// BindMethod verifies that the group source can be reached using the credentials of the request
+	BindMethod = "/" + ServiceName + "/Bind"
+	// SyncMethod returns the groups of the group source along with their users
+	SyncMethod = "/" + ServiceName + "/Sync"
+	// CodecName is the content subtype of the messages exchanged with plugins (application/grpc+json)
+	CodecName = "json"
+)
+
+// Request is sent by the operator to bind to the group source of the plugin and to retrieve its groups
+type Request struct {
+	// Provider is the name of the provider of the GroupSync
+	Provider string `json:"provider"`
+	// Parameters are the parameters of the provider configuring the groups returned by the plugin
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// Credentials are the entries of the credentials secret of the provider
+	Credentials map[string]string `json:"credentials,omitempty"`
+	// Checkpoint is the checkpoint returned by the previous synchronization of the plugin, such as a cursor or a delta token, allowing
+	// plugins to synchronize incrementally from their group source. Only sent to Sync and empty for the first synchronization
+	Checkpoint string `json:"checkpoint,omitempty"`
+}
+
+// BindResponse is returned by plugins that could bind to their group source
+type BindResponse struct {
+}
+
+// SyncResponse contains the groups returned by a plugin
+type SyncResponse struct {
+	Groups []Group `json:"groups"`
+	// Checkpoint is stored by the operator once the groups were written and sent to the next synchronization of the plugin
+	Checkpoint string `json:"checkpoint,omitempty"`
+}
+
+// Group is a group of the group source of a plugin
+type Group struct {
+	// Name is the name of the group
+	Name string `json:"name"`
+	// ID is the identifier of the group in the group source, recorded in the sync.source.uid annotation of the group
+	ID string `json:"id,omitempty"`
+	// Users are the names of the members of the group
+	Users []string `json:"users"`
+	// Attributes are the metadata of the group available to the metadata mappings of the provider
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// GroupProviderServer is implemented by plugins
+type GroupProviderServer interface {
+	Bind(context.Context, *Request) (*BindResponse, error)
+	Sync(context.Context, *Request) (*SyncResponse, error)
+}
+
+// RegisterGroupProviderServer serves the implementation of the plugin from the gRPC server
+func RegisterGroupProviderServer(server *grpc.Server, groupProviderServer GroupProviderServer) {
+	server.RegisterService(&groupProviderServiceDesc, groupProviderServer)
+}
+
+// Client invokes a plugin over a gRPC connection
+type Client struct {
+	conn *grpc.ClientConn
+}
+
+// NewClient returns a client of the plugin reachable over the connection
+func NewClient(conn *grpc.ClientConn) *Client {
+	return &Client{conn: conn}
+}
+
+func (c *Client) Bind(context context.Context, request *Request) (*BindResponse, error) {
+
+	response := &BindResponse{}
+
+	if err := c.conn.Invoke(context, BindMethod, request, response, grpc.CallContentSubtype(CodecName)); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *Client) Sync(context context.Context, request *Request) (*SyncResponse, error) {
+
+	response := &SyncResponse{}
+
+	if err := c.conn.Invoke(context, SyncMethod, request, response, grpc.CallContentSubtype(CodecName)); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+var groupProviderServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*GroupProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Bind",
+			Handler:    bindHandler,
+		},
+		{
+			MethodName: "Sync",
+			Handler:    syncHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func bindHandler(server interface{}, context context.Context, decode func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+
+	request := &Request{}
+
+	if err := decode(request); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return server.(GroupProviderServer).Bind(context, request)
+	}
+
+	handler := func(context context.Context, request interface{}) (interface{}, error) {
+		return server.(GroupProviderServer).Bind(context, request.(*Request))
+	}
+
+	return interceptor(context, request, &grpc.UnaryServerInfo{Server: server, FullMethod: BindMethod}, handler)
+}
+
+func syncHandler(server interface{}, context context.Context, decode func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+
+	request := &Request{}
+
+	if err := decode(request); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return server.(GroupProviderServer).Sync(context, request)
+	}
+
+	handler := func(context context.Context, request interface{}) (interface{}, error) {
+		return server.(GroupProviderServer).Sync(context, request.(*Request))
+	}
+
+	return interceptor(context, request, &grpc.UnaryServerInfo{Server: server, FullMethod: SyncMethod}, handler)
+}
+
+// jsonCodec encodes the messages exchanged with plugins in JSON
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (jsonCodec) Unmarshal(data []byte, value interface{}) error {
+	return json.Unmarshal(data, value)
+}
+
+func (jsonCodec) Name() string {
+	return CodecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
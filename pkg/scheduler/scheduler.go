@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var (
+	schedulerLogger = logf.Log.WithName("scheduler")
+)
+
+type scheduledTimer struct {
+	timer *time.Timer
+	id    uint64
+}
+
+// Scheduler maintains an independent timer for each scheduled object and triggers a reconciliation once the timer fires
+type Scheduler struct {
+	events chan event.GenericEvent
+	timers map[types.NamespacedName]*scheduledTimer
+	due    map[types.NamespacedName]bool
+	nextID uint64
+	mutex  sync.Mutex
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		events: make(chan event.GenericEvent),
+		timers: map[types.NamespacedName]*scheduledTimer{},
+		due:    map[types.NamespacedName]bool{},
+	}
+}
+
+// Source returns the source of the events produced when timers fire
+func (s *Scheduler) Source() source.Source {
+	return &source.Channel{Source: s.events}
+}
+
+// Schedule arms the timer of the object so that it fires at the provided time, replacing any existing timer
+func (s *Scheduler) Schedule(obj client.Object, next time.Time) {
+
+	key := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if scheduled, found := s.timers[key]; found {
+		scheduled.timer.Stop()
+	}
+
+	delete(s.due, key)
+
+	schedulerLogger.Info("Scheduling Synchronization", "Name", key.Name, "Namespace", key.Namespace, "Next Scheduled Time", next)
+
+	s.nextID++
+	id := s.nextID
+
+	s.timers[key] = &scheduledTimer{
+		id: id,
+		timer: time.AfterFunc(time.Until(next), func() {
+			s.fire(key, id, obj)
+		}),
+	}
+}
+
+// Unschedule stops the timer of the object
+func (s *Scheduler) Unschedule(key types.NamespacedName) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if scheduled, found := s.timers[key]; found {
+		scheduled.timer.Stop()
+		delete(s.timers, key)
+	}
+
+	delete(s.due, key)
+}
+
+// IsDue determines whether the timer of the object has fired since it was last scheduled
+func (s *Scheduler) IsDue(key types.NamespacedName) bool {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.due[key]
+}
+
+func (s *Scheduler) fire(key types.NamespacedName, id uint64, obj client.Object) {
+
+	s.mutex.Lock()
+	// Ignore timers that were replaced or stopped after firing
+	if scheduled, found := s.timers[key]; !found || scheduled.id != id {
+		s.mutex.Unlock()
+		return
+	}
+	s.due[key] = true
+	s.mutex.Unlock()
+
+	s.events <- event.GenericEvent{Object: obj}
+}
@@ -3,7 +3,11 @@ package syncer
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
+	"reflect"
+	"strings"
+	"time"
 
 	userv1 "github.com/openshift/api/user/v1"
 	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
@@ -11,15 +15,16 @@ import (
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	azidentity "github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	az "github.com/microsoft/kiota/authentication/go/azure"
 	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	msadministrativeunitmembers "github.com/microsoftgraph/msgraph-sdk-go/directory/administrativeunits/item/members"
 	msgroups "github.com/microsoftgraph/msgraph-sdk-go/groups"
 	msmembers "github.com/microsoftgraph/msgraph-sdk-go/groups/item/members"
+	mstransitivemembers "github.com/microsoftgraph/msgraph-sdk-go/groups/item/transitivemembers"
 	graph "github.com/microsoftgraph/msgraph-sdk-go/models/microsoft/graph"
 )
 
@@ -28,15 +33,48 @@ var (
 )
 
 const (
-	TenantID               = "AZURE_TENANT_ID"
-	ClientID               = "AZURE_CLIENT_ID"
-	ClientSecret           = "AZURE_CLIENT_SECRET"
-	GraphGroupType         = "#microsoft.graph.group"
-	GraphUserType          = "#microsoft.graph.user"
-	GraphOdataType         = "@odata.type"
-	GraphID                = "id"
-	GraphDisplayName       = "displayName"
-	GraphUserNameAttribute = "userPrincipalName"
+	TenantID                  = "AZURE_TENANT_ID"
+	ClientID                  = "AZURE_CLIENT_ID"
+	ClientSecret              = "AZURE_CLIENT_SECRET"
+	GraphGroupType            = "#microsoft.graph.group"
+	GraphUserType             = "#microsoft.graph.user"
+	GraphServicePrincipalType = "#microsoft.graph.servicePrincipal"
+	GraphDeviceType           = "#microsoft.graph.device"
+	GraphAppID                = "appId"
+	GraphOdataType            = "@odata.type"
+	GraphID                   = "id"
+	GraphDisplayName          = "displayName"
+	GraphMail                 = "mail"
+	GraphMailNickname         = "mailNickname"
+	GraphDescription          = "description"
+	GraphGroupTypes           = "groupTypes"
+	GraphMailEnabled          = "mailEnabled"
+	GraphSecurityEnabled      = "securityEnabled"
+	GraphUnifiedGroupType     = "Unified"
+	GraphUserNameAttribute    = "userPrincipalName"
+	GraphUserTypeAttribute    = "userType"
+	GraphAccountEnabled       = "accountEnabled"
+	GraphGuestUserType        = "Guest"
+	GraphExternalUserTag      = "#EXT#"
+	GraphAPIVersion           = "v1.0"
+	GraphDefaultScope         = ".default"
+
+	// Directory extension attributes are named extension_<appId>_<name>
+	GraphDirectoryExtensionPrefix = "extension_"
+	// Schema extension properties are referenced as <schema extension>.<property>
+	GraphExtensionPropertySeparator = "."
+)
+
+var (
+	// graphDefaultUserAttributes are the attributes returned for users when no attributes are selected
+	graphDefaultUserAttributes = []string{"businessPhones", GraphDisplayName, "givenName", GraphID, "jobTitle", GraphMail, "mobilePhone", "officeLocation", "preferredLanguage", "surname", GraphUserNameAttribute}
+
+	// graphEndpoints maps the Azure Active Directory endpoints of national clouds to their Microsoft Graph endpoint
+	graphEndpoints = map[azidentity.AuthorityHost]string{
+		azidentity.AzurePublicCloud: "https://graph.microsoft.com",
+		azidentity.AzureGovernment:  "https://graph.microsoft.us",
+		azidentity.AzureChina:       "https://microsoftgraph.chinacloudapi.cn",
+	}
 )
 
 type AzureSyncer struct {
@@ -46,26 +84,48 @@ type AzureSyncer struct {
 	Client            *msgraphsdk.GraphServiceClient
 	ReconcilerBase    util.ReconcilerBase
 	CredentialsSecret *corev1.Secret
+	CredentialsSource *redhatcopv1alpha1.CredentialsSource
+	CredentialsKeys   map[string]string
+	MetadataMappings  []redhatcopv1alpha1.MetadataMapping
+	RateLimit         *redhatcopv1alpha1.RateLimit
+	Timeout           *v1.Duration
 	CachedGroups      map[string]*graph.Group
 	CachedGroupUsers  map[string][]*graph.User
 	Context           context.Context
+	ProxyURL          *url.URL
+	syncContext       context.Context
+	groups            *GroupPatterns
+	excludedGroups    *GroupPatterns
 }
 
 func (a *AzureSyncer) Init() bool {
 
 	a.CachedGroups = make(map[string]*graph.Group)
 	a.CachedGroupUsers = make(map[string][]*graph.User)
-	a.Context = context.Background()
+	if a.Context == nil {
+		a.Context = context.Background()
+	}
 
-	return false
+	changed := false
+
+	if a.Provider.MemberScope == "" {
+		a.Provider.MemberScope = redhatcopv1alpha1.TransitiveAzureMemberScope
+		changed = true
+	}
+
+	if a.Provider.IncludeServicePrincipals && a.Provider.ServicePrincipalNameAttribute == "" {
+		a.Provider.ServicePrincipalNameAttribute = redhatcopv1alpha1.AppIDAzureServicePrincipalNameAttribute
+		changed = true
+	}
+
+	return changed
 }
 
 func (a *AzureSyncer) Validate() error {
 
 	validationErrors := []error{}
 
-	credentialsSecret := &corev1.Secret{}
-	err := a.ReconcilerBase.GetClient().Get(a.Context, types.NamespacedName{Name: a.Provider.CredentialsSecret.Name, Namespace: a.Provider.CredentialsSecret.Namespace}, credentialsSecret)
+	credentialsSecret, credentialsDescription, err := getCredentials(a.Context, a.ReconcilerBase.GetClient(), a.CredentialsSource, a.CredentialsKeys, a.Provider.CredentialsSecret)
 
 	if err != nil {
 		validationErrors = append(validationErrors, err)
@@ -77,13 +137,51 @@ func (a *AzureSyncer) Validate() error {
 		_, clientSecretSecretFound := credentialsSecret.Data[ClientSecret]
 
 		if !tenantIDSecretFound || !clientIDSecretFound || !clientSecretSecretFound {
-			validationErrors = append(validationErrors, fmt.Errorf("Could not find `AZURE_TENANT_ID` or `AZURE_CLIENT_ID` or `AZURE_CLIENT_SECRET` key in secret '%s' in namespace '%s", a.Provider.CredentialsSecret.Name, a.Provider.CredentialsSecret.Namespace))
+			validationErrors = append(validationErrors, fmt.Errorf("Could not find `AZURE_TENANT_ID` or `AZURE_CLIENT_ID` or `AZURE_CLIENT_SECRET` key in %s", credentialsDescription))
 		}
 
 		a.CredentialsSecret = credentialsSecret
 
 	}
 
+	if a.Provider.Proxy != nil {
+
+		proxyURL, err := a.getProxyURL()
+
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+
+		a.ProxyURL = proxyURL
+	}
+
+	if a.Provider.GraphEndpoint != nil {
+		if _, err := url.ParseRequestURI(*a.Provider.GraphEndpoint); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid Graph Endpoint: '%s'", *a.Provider.GraphEndpoint))
+		}
+	}
+
+	if len(a.Provider.AdministrativeUnits) > 0 && len(a.Provider.BaseGroups) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("administrativeUnits cannot be specified along with baseGroups"))
+	}
+
+	if a.Provider.MaxNestingDepth != nil {
+		if *a.Provider.MaxNestingDepth < 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("maxNestingDepth must be equal to or greater than zero"))
+		}
+
+		if a.Provider.MemberScope == redhatcopv1alpha1.DirectAzureMemberScope {
+			validationErrors = append(validationErrors, fmt.Errorf("maxNestingDepth cannot be specified with the '%s' memberScope", redhatcopv1alpha1.DirectAzureMemberScope))
+		}
+	}
+
+	var groupPatternErrors []error
+
+	a.groups, groupPatternErrors = compileGroupPatterns("groups", a.Provider.Groups)
+	validationErrors = append(validationErrors, groupPatternErrors...)
+	a.excludedGroups, groupPatternErrors = compileGroupPatterns("excludedGroups", a.Provider.ExcludedGroups)
+	validationErrors = append(validationErrors, groupPatternErrors...)
+
 	return utilerrors.NewAggregate(validationErrors)
 
 }
@@ -92,6 +190,16 @@ func (a *AzureSyncer) Bind() error {
 
 	opts := &azidentity.ClientSecretCredentialOptions{}
 	opts.AuthorityHost = azidentity.AuthorityHost(getAuthorityHost(a.Provider.AuthorityHost))
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if a.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(a.ProxyURL)
+	}
+
+	// Graph requests are not context aware so the context of the synchronization is attached to each request
+	httpClient := &http.Client{Transport: &contextTransport{transport: newTracedTransport(newRateLimitedTransport(transport, a.RateLimit)), context: a.getRequestContext}}
+	opts.Transport = httpClient
 	cred, err := azidentity.NewClientSecretCredential(
 		string(a.CredentialsSecret.Data[TenantID]), string(a.CredentialsSecret.Data[ClientID]), string(a.CredentialsSecret.Data[ClientSecret]),
 		opts)
@@ -100,13 +208,15 @@ func (a *AzureSyncer) Bind() error {
 		return err
 	}
 
-	auth, err := az.NewAzureIdentityAuthenticationProvider(cred)
+	graphEndpoint := getGraphEndpoint(a.Provider.GraphEndpoint, a.Provider.AuthorityHost)
+
+	auth, err := az.NewAzureIdentityAuthenticationProviderWithScopes(cred, []string{fmt.Sprintf("%s/%s", graphEndpoint, GraphDefaultScope)})
 
 	if err != nil {
 		return err
 	}
 
-	adapter, err := msgraphsdk.NewGraphRequestAdapter(auth)
+	adapter, err := msgraphsdk.NewGraphRequestAdapterWithParseNodeFactoryAndSerializationWriterFactoryAndHttpClient(auth, nil, nil, httpClient)
 	if err != nil {
 		return err
 
@@ -114,12 +224,21 @@ func (a *AzureSyncer) Bind() error {
 
 	a.Client = msgraphsdk.NewGraphServiceClient(adapter)
 
+	// The Graph Service Client defaults to the global Microsoft Graph endpoint
+	adapter.SetBaseUrl(fmt.Sprintf("%s/%s", graphEndpoint, GraphAPIVersion))
+
 	return nil
 
 }
 
 func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 
+	syncContext, cancel := a.newSyncContext()
+	defer cancel()
+
+	a.syncContext = syncContext
+	defer func() { a.syncContext = nil }()
+
 	ocpGroups := []userv1.Group{}
 	aadGroups := []graph.Group{}
 
@@ -130,6 +249,7 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 			filter := fmt.Sprintf("displayName eq '%s'", baseGroup)
 			groupRequestParameters := &msgroups.GroupsRequestBuilderGetQueryParameters{
 				Filter: &filter,
+				Select: a.getGroupSelect(),
 			}
 			groupOptions := &msgroups.GroupsRequestBuilderGetOptions{
 				Q: groupRequestParameters,
@@ -138,7 +258,7 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 			baseGroupRequest, err := a.Client.Groups().Get(groupOptions)
 
 			if err != nil {
-				azureLogger.Error(err, "Failed to get base group", "Provider", a.Name, "Base Group", baseGroup)
+				syncLogger(a.Context, azureLogger).Error(err, "Failed to get base group", "Provider", a.Name, "Base Group", baseGroup)
 				return nil, err
 			}
 
@@ -146,7 +266,7 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 
 			// Check that only 1 group was found
 			if len(baseGroupResult) != 1 {
-				azureLogger.Info("Failed to find a single base group to search from", "Provider", a.Name, "Base Group", baseGroup)
+				syncLogger(a.Context, azureLogger).Info("Failed to find a single base group to search from", "Provider", a.Name, "Base Group", baseGroup)
 				continue
 			}
 
@@ -155,9 +275,12 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 
 			var baseGroupMemberOptions *msmembers.MembersRequestBuilderGetOptions
 
-			if a.Provider.Filter != "" {
+			if a.Provider.Filter != "" || a.getGroupSelect() != nil {
 				requestParameters := &msmembers.MembersRequestBuilderGetQueryParameters{
-					Filter: &a.Provider.Filter,
+					Select: a.getGroupSelect(),
+				}
+				if a.Provider.Filter != "" {
+					requestParameters.Filter = &a.Provider.Filter
 				}
 				baseGroupMemberOptions = &msmembers.MembersRequestBuilderGetOptions{
 					Q: requestParameters,
@@ -168,7 +291,7 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 			baseGroupMembersRequest, err := a.Client.GroupsById(*baseGroupResult[0].GetId()).Members().Get(baseGroupMemberOptions)
 
 			if err != nil {
-				azureLogger.Error(err, "Failed to get base group members", "Provider", a.Name, "Base Group", baseGroup)
+				syncLogger(a.Context, azureLogger).Error(err, "Failed to get base group members", "Provider", a.Name, "Base Group", baseGroup)
 				return nil, err
 			}
 
@@ -193,29 +316,56 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 
 		}
 
-	} else {
+	} else if len(a.Provider.AdministrativeUnits) > 0 {
 
-		var groupOptions *msgroups.GroupsRequestBuilderGetOptions
+		administrativeUnitGroups, err := a.getAdministrativeUnitGroups()
 
-		if a.Provider.Filter != "" {
-			groupRequestParameters := &msgroups.GroupsRequestBuilderGetQueryParameters{
-				Filter: &a.Provider.Filter,
+		if err != nil {
+			return nil, err
+		}
+
+		aadGroups = append(aadGroups, administrativeUnitGroups...)
+
+	} else if len(a.Provider.GroupNamePrefixes) > 0 {
+
+		foundGroups := map[string]bool{}
+
+		for _, groupNamePrefix := range a.Provider.GroupNamePrefixes {
+
+			filter := fmt.Sprintf("startswith(displayName, '%s')", escapeODataString(groupNamePrefix))
+			if a.Provider.Filter != "" {
+				filter = fmt.Sprintf("(%s) and (%s)", filter, a.Provider.Filter)
 			}
-			groupOptions = &msgroups.GroupsRequestBuilderGetOptions{
-				Q: groupRequestParameters,
+
+			groupResult, err := a.listGroups(&filter)
+
+			if err != nil {
+				syncLogger(a.Context, azureLogger).Error(err, "Failed to get groups with prefix", "Provider", a.Name, "Prefix", groupNamePrefix)
+				return nil, err
 			}
 
+			for _, group := range groupResult {
+				if group.GetId() != nil && !foundGroups[*group.GetId()] {
+					foundGroups[*group.GetId()] = true
+					aadGroups = append(aadGroups, group)
+				}
+			}
 		}
 
-		groupRequest, err := a.Client.Groups().Get(groupOptions)
+	} else {
+
+		var filter *string
+		if a.Provider.Filter != "" {
+			filter = &a.Provider.Filter
+		}
+
+		groupResult, err := a.listGroups(filter)
 
 		if err != nil {
-			azureLogger.Error(err, "Failed to get groups", "Provider", a.Name)
+			syncLogger(a.Context, azureLogger).Error(err, "Failed to get groups", "Provider", a.Name)
 			return nil, err
 		}
 
-		groupResult := getGroupsFromResults(groupRequest)
-
 		aadGroups = append(aadGroups, groupResult...)
 
 	}
@@ -223,20 +373,29 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 	authorityHost := string(getAuthorityHost(a.Provider.AuthorityHost))
 	azureURL, err := url.Parse(authorityHost)
 	if err != nil {
-		azureLogger.Error(err, "Failed to parse Azure URL", "URL", authorityHost)
+		syncLogger(a.Context, azureLogger).Error(err, "Failed to parse Azure URL", "URL", authorityHost)
 		return nil, err
 	}
 
 	for _, group := range aadGroups {
 
-		groupName := group.GetDisplayName()
+		groupName, found := a.getGroupName(group)
+
+		if !found {
+			syncLogger(a.Context, azureLogger).Info(fmt.Sprintf("Warning: Skipping Group record with empty name attributes"), "Group ID", group.DirectoryObject.GetId())
+			continue
+		}
+
+		if !a.isGroupTypeAllowed(group) || !a.isGroupNamePrefixAllowed(group) {
+			continue
+		}
 
-		if groupName == nil {
-			azureLogger.Info(fmt.Sprintf("Warning: Skipping Group record with empty displayName"))
+		// Allow groups to be filtered by either their display name or their name in OpenShift
+		if !a.groups.Allows(groupName) && (group.GetDisplayName() == nil || !a.groups.Allows(*group.GetDisplayName())) {
 			continue
 		}
 
-		if !isGroupAllowed(*groupName, a.Provider.Groups) {
+		if a.excludedGroups.Matches(groupName) || (group.GetDisplayName() != nil && a.excludedGroups.Matches(*group.GetDisplayName())) {
 			continue
 		}
 
@@ -246,7 +405,7 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 				APIVersion: userv1.GroupVersion.String(),
 			},
 			ObjectMeta: v1.ObjectMeta{
-				Name:        *groupName,
+				Name:        groupName,
 				Annotations: map[string]string{},
 				Labels:      map[string]string{},
 			},
@@ -257,10 +416,12 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 		ocpGroup.GetAnnotations()[constants.SyncSourceHost] = azureURL.Host
 		ocpGroup.GetAnnotations()[constants.SyncSourceUID] = *group.DirectoryObject.GetId()
 
+		applyMetadataMappings(a.Context, &ocpGroup, a.MetadataMappings, a.getGroupMetadata(group))
+
 		groupMembers, err := a.listGroupMembers(group.DirectoryObject.GetId())
 
 		if err != nil {
-			azureLogger.Error(err, "Failed to get Group members for Group", "Group", group.GetDisplayName(), "Provider", a.Name)
+			syncLogger(a.Context, azureLogger).Error(err, "Failed to get Group members for Group", "Group", group.GetDisplayName(), "Provider", a.Name)
 			return nil, err
 		}
 
@@ -276,29 +437,230 @@ func (a *AzureSyncer) Sync() ([]userv1.Group, error) {
 
 }
 
+// newSyncContext returns a context derived from the context of the reconciliation that expires after the sync timeout
+func (a *AzureSyncer) newSyncContext() (context.Context, context.CancelFunc) {
+
+	if syncTimeout := a.getSyncTimeout(); syncTimeout > 0 {
+		return context.WithTimeout(a.Context, syncTimeout)
+	}
+
+	return context.WithCancel(a.Context)
+}
+
+// getSyncTimeout returns the timeout of the provider, falling back to the deprecated sync timeout of Azure when not set
+func (a *AzureSyncer) getSyncTimeout() time.Duration {
+
+	if a.Timeout != nil {
+		return a.Timeout.Duration
+	}
+
+	if a.Provider.SyncTimeout != nil {
+		return a.Provider.SyncTimeout.Duration
+	}
+
+	return 0
+}
+
+func (a *AzureSyncer) getRequestContext() context.Context {
+
+	if a.syncContext != nil {
+		return a.syncContext
+	}
+
+	return a.Context
+}
+
+// getProxyURL returns the URL of the proxy including the credentials from the referenced secret
+func (a *AzureSyncer) getProxyURL() (*url.URL, error) {
+
+	proxyURL, err := url.ParseRequestURI(a.Provider.Proxy.URL)
+
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("Invalid Proxy URL: '%s'", a.Provider.Proxy.URL)
+	}
+
+	if a.Provider.Proxy.CredentialsSecret == nil {
+		return proxyURL, nil
+	}
+
+	proxyCredentials, err := getObjectRefData(a.Context, a.ReconcilerBase.GetClient(), a.Provider.Proxy.CredentialsSecret)
+
+	if err != nil {
+		return nil, err
+	}
+
+	username, usernameFound := proxyCredentials[secretUsernameKey]
+	password, passwordFound := proxyCredentials[secretPasswordKey]
+
+	if !usernameFound || !passwordFound {
+		return nil, fmt.Errorf("Could not find 'username' or 'password' key in %s '%s' in namespace '%s", a.Provider.Proxy.CredentialsSecret.Kind, a.Provider.Proxy.CredentialsSecret.Name, a.Provider.Proxy.CredentialsSecret.Namespace)
+	}
+
+	proxyURL.User = url.UserPassword(string(username), string(password))
+
+	return proxyURL, nil
+}
+
+func (a *AzureSyncer) listGroups(filter *string) ([]graph.Group, error) {
+
+	var groupOptions *msgroups.GroupsRequestBuilderGetOptions
+
+	if filter != nil || a.getGroupSelect() != nil {
+		groupOptions = &msgroups.GroupsRequestBuilderGetOptions{
+			Q: &msgroups.GroupsRequestBuilderGetQueryParameters{
+				Filter: filter,
+				Select: a.getGroupSelect(),
+			},
+		}
+	}
+
+	groupRequest, err := a.Client.Groups().Get(groupOptions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return getGroupsFromResults(groupRequest), nil
+}
+
+// isGroupNamePrefixAllowed determines whether the display name of the group starts with one of the configured prefixes
+func (a *AzureSyncer) isGroupNamePrefixAllowed(group graph.Group) bool {
+
+	if len(a.Provider.GroupNamePrefixes) == 0 {
+		return true
+	}
+
+	if group.GetDisplayName() == nil {
+		return false
+	}
+
+	for _, groupNamePrefix := range a.Provider.GroupNamePrefixes {
+		// startswith is not case sensitive
+		if strings.HasPrefix(strings.ToLower(*group.GetDisplayName()), strings.ToLower(groupNamePrefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getAdministrativeUnitGroups returns the groups that are members of the configured Administrative Units
+func (a *AzureSyncer) getAdministrativeUnitGroups() ([]graph.Group, error) {
+
+	administrativeUnitGroups := []graph.Group{}
+	foundGroups := map[string]bool{}
+
+	for _, administrativeUnit := range a.Provider.AdministrativeUnits {
+
+		var administrativeUnitMemberOptions *msadministrativeunitmembers.MembersRequestBuilderGetOptions
+
+		if groupSelect := a.getGroupSelect(); groupSelect != nil {
+			administrativeUnitMemberOptions = &msadministrativeunitmembers.MembersRequestBuilderGetOptions{
+				Q: &msadministrativeunitmembers.MembersRequestBuilderGetQueryParameters{
+					Select: groupSelect,
+				},
+			}
+		}
+
+		administrativeUnitMembersRequest, err := a.Client.Directory().AdministrativeUnitsById(administrativeUnit).Members().Get(administrativeUnitMemberOptions)
+
+		if err != nil {
+			syncLogger(a.Context, azureLogger).Error(err, "Failed to get Administrative Unit members", "Provider", a.Name, "Administrative Unit", administrativeUnit)
+			return nil, err
+		}
+
+		for _, administrativeUnitMember := range getDirectoryObjectsFromResults(administrativeUnitMembersRequest) {
+
+			administrativeUnitMemberODataType, _ := administrativeUnitMember.GetAdditionalData()[GraphOdataType].(*string)
+
+			if administrativeUnitMemberODataType == nil || GraphGroupType != *administrativeUnitMemberODataType {
+				continue
+			}
+
+			if administrativeUnitMember.GetId() == nil || foundGroups[*administrativeUnitMember.GetId()] {
+				continue
+			}
+
+			foundGroups[*administrativeUnitMember.GetId()] = true
+
+			groupDisplayName, _ := administrativeUnitMember.GetAdditionalData()[GraphDisplayName].(*string)
+			group := graph.Group{
+				DirectoryObject: administrativeUnitMember,
+			}
+			group.SetDisplayName(groupDisplayName)
+			administrativeUnitGroups = append(administrativeUnitGroups, group)
+		}
+	}
+
+	return administrativeUnitGroups, nil
+}
+
 func (a *AzureSyncer) GetProviderName() string {
 	return a.Name
 }
 
 func (a *AzureSyncer) listGroupMembers(groupID *string) ([]string, error) {
 	groupMembers := []string{}
-	memberRequest, err := a.Client.GroupsById(*groupID).TransitiveMembers().Get(nil)
+
+	var members []graph.DirectoryObjectable
+	var err error
+
+	switch {
+	case a.Provider.MemberScope == redhatcopv1alpha1.DirectAzureMemberScope:
+		members, err = a.listDirectGroupMembers(groupID)
+	case a.Provider.MaxNestingDepth != nil:
+		members, err = a.listNestedGroupMembers(groupID, 0, map[string]bool{*groupID: true})
+	default:
+		members, err = a.listTransitiveGroupMembers(groupID)
+	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	members := memberRequest.GetValue()
-
 	for _, member := range members {
 
 		memberODataType, _ := member.GetAdditionalData()[GraphOdataType].(*string)
 
+		if memberODataType == nil {
+			continue
+		}
+
+		switch *memberODataType {
+		case GraphServicePrincipalType:
+			if a.Provider.IncludeServicePrincipals {
+				if servicePrincipalName, found := a.isUsernamePresent(member, a.getServicePrincipalNameAttribute()); found {
+					groupMembers = append(groupMembers, servicePrincipalName)
+				} else {
+					syncLogger(a.Context, azureLogger).Info(fmt.Sprintf("Warning: Name for service principal cannot be found in Group ID '%v'", *groupID))
+				}
+			}
+			continue
+		case GraphDeviceType:
+			if a.Provider.IncludeDevices {
+				if deviceName, found := a.isUsernamePresent(member, GraphDisplayName); found {
+					groupMembers = append(groupMembers, deviceName)
+				} else {
+					syncLogger(a.Context, azureLogger).Info(fmt.Sprintf("Warning: Name for device cannot be found in Group ID '%v'", *groupID))
+				}
+			}
+			continue
+		}
+
 		if *memberODataType == GraphUserType {
+
+			if a.Provider.ExcludeGuests && a.isGuestUser(member) {
+				continue
+			}
+
+			if a.Provider.ExcludeDisabledUsers && !a.isAccountEnabled(member) {
+				continue
+			}
+
 			if username, found := a.getUsernameForUser(member); found {
 				groupMembers = append(groupMembers, fmt.Sprintf("%v", username))
 			} else {
-				azureLogger.Info(fmt.Sprintf("Warning: Username for user cannot be found in Group ID '%v'", *groupID))
+				syncLogger(a.Context, azureLogger).Info(fmt.Sprintf("Warning: Username for user cannot be found in Group ID '%v'", *groupID))
 			}
 		}
 
@@ -308,29 +670,432 @@ func (a *AzureSyncer) listGroupMembers(groupID *string) ([]string, error) {
 
 }
 
-func (a *AzureSyncer) getUsernameForUser(user graph.DirectoryObjectable) (string, bool) {
+func (a *AzureSyncer) listTransitiveGroupMembers(groupID *string) ([]graph.DirectoryObjectable, error) {
+
+	var memberOptions *mstransitivemembers.TransitiveMembersRequestBuilderGetOptions
+
+	if memberSelect := a.getMemberSelect(); memberSelect != nil {
+		memberOptions = &mstransitivemembers.TransitiveMembersRequestBuilderGetOptions{
+			Q: &mstransitivemembers.TransitiveMembersRequestBuilderGetQueryParameters{
+				Select: memberSelect,
+			},
+		}
+	}
+
+	memberRequest, err := a.Client.GroupsById(*groupID).TransitiveMembers().Get(memberOptions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return memberRequest.GetValue(), nil
+}
+
+func (a *AzureSyncer) listDirectGroupMembers(groupID *string) ([]graph.DirectoryObjectable, error) {
+
+	var memberOptions *msmembers.MembersRequestBuilderGetOptions
+
+	if memberSelect := a.getMemberSelect(); memberSelect != nil {
+		memberOptions = &msmembers.MembersRequestBuilderGetOptions{
+			Q: &msmembers.MembersRequestBuilderGetQueryParameters{
+				Select: memberSelect,
+			},
+		}
+	}
+
+	memberRequest, err := a.Client.GroupsById(*groupID).Members().Get(memberOptions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return memberRequest.GetValue(), nil
+}
+
+// listNestedGroupMembers walks nested groups using direct memberships until the configured maximum depth is reached
+func (a *AzureSyncer) listNestedGroupMembers(groupID *string, depth int, visitedGroups map[string]bool) ([]graph.DirectoryObjectable, error) {
+
+	members, err := a.listDirectGroupMembers(groupID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nestedMembers := []graph.DirectoryObjectable{}
+
+	for _, member := range members {
+
+		memberODataType, _ := member.GetAdditionalData()[GraphOdataType].(*string)
+
+		if memberODataType == nil || *memberODataType != GraphGroupType {
+			nestedMembers = append(nestedMembers, member)
+			continue
+		}
+
+		if depth >= *a.Provider.MaxNestingDepth || member.GetId() == nil || visitedGroups[*member.GetId()] {
+			continue
+		}
+
+		visitedGroups[*member.GetId()] = true
+
+		childMembers, err := a.listNestedGroupMembers(member.GetId(), depth+1, visitedGroups)
+
+		if err != nil {
+			return nil, err
+		}
+
+		nestedMembers = append(nestedMembers, childMembers...)
+	}
+
+	return nestedMembers, nil
+}
+
+// getMemberSelect returns the attributes to request for members or nil when the default set of attributes is sufficient
+func (a *AzureSyncer) getMemberSelect() []string {
+
+	// The default set of properties returned for a member do not include the user type, account status or extension attributes
+	if !a.Provider.ExcludeGuests && !a.Provider.ExcludeDisabledUsers && !a.hasSelectedUsernameAttributes() {
+		return nil
+	}
+
+	return a.getMemberSelectAttributes()
+}
+
+func (a *AzureSyncer) getMemberSelectAttributes() []string {
+
+	selectAttributes := []string{GraphID, GraphDisplayName, GraphUserNameAttribute}
+
+	if a.Provider.ExcludeGuests {
+		selectAttributes = append(selectAttributes, GraphUserTypeAttribute)
+	}
+
+	if a.Provider.ExcludeDisabledUsers {
+		selectAttributes = append(selectAttributes, GraphAccountEnabled)
+	}
+
+	if a.Provider.IncludeServicePrincipals && !containsString(selectAttributes, a.getServicePrincipalNameAttribute()) {
+		selectAttributes = append(selectAttributes, a.getServicePrincipalNameAttribute())
+	}
+
+	if a.Provider.UserNameAttributes != nil {
+		for _, usernameAttribute := range *a.Provider.UserNameAttributes {
+			// Schema extension properties are selected using the name of the schema extension
+			selectAttribute := strings.SplitN(usernameAttribute, GraphExtensionPropertySeparator, 2)[0]
+			if !containsString(selectAttributes, selectAttribute) {
+				selectAttributes = append(selectAttributes, selectAttribute)
+			}
+		}
+	}
+
+	return selectAttributes
+}
+
+// hasSelectedUsernameAttributes determines whether a username attribute is only returned when explicitly selected, such as
+// on-premises attributes synchronized by Azure AD Connect and directory or schema extensions
+func (a *AzureSyncer) hasSelectedUsernameAttributes() bool {
 
 	if a.Provider.UserNameAttributes == nil {
-		return a.isUsernamePresent(user, GraphUserNameAttribute)
+		return false
 	}
 
 	for _, usernameAttribute := range *a.Provider.UserNameAttributes {
+		if isExtensionAttribute(usernameAttribute) || !containsString(graphDefaultUserAttributes, usernameAttribute) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getGroupSelect returns the attributes to request for groups or nil when the default set of attributes is sufficient. Attributes are
+// requested when groups are named using attributes or when attributes are mapped to metadata, as extension attributes and other
+// attributes outside of the default set are otherwise not returned
+func (a *AzureSyncer) getGroupSelect() []string {
+
+	metadataAttributes := []string{}
+
+	for _, source := range getMetadataSources(a.MetadataMappings) {
+		if attribute, found := getGraphMetadataAttribute(source); found {
+			metadataAttributes = append(metadataAttributes, attribute)
+		}
+	}
+
+	if a.Provider.GroupNameAttributes == nil && len(metadataAttributes) == 0 {
+		return nil
+	}
+
+	selectAttributes := []string{GraphID, GraphDisplayName}
+
+	if len(a.Provider.GroupTypes) > 0 {
+		selectAttributes = append(selectAttributes, GraphGroupTypes, GraphMailEnabled, GraphSecurityEnabled)
+	}
+
+	if a.Provider.GroupNameAttributes != nil {
+		for _, groupNameAttribute := range *a.Provider.GroupNameAttributes {
+			if !containsString(selectAttributes, groupNameAttribute) {
+				selectAttributes = append(selectAttributes, groupNameAttribute)
+			}
+		}
+	}
+
+	for _, attribute := range metadataAttributes {
+		if !containsString(selectAttributes, attribute) {
+			selectAttributes = append(selectAttributes, attribute)
+		}
+	}
+
+	return selectAttributes
+}
+
+// getGroupMetadata returns the metadata fields of the group referenced by the metadata mappings. Attributes of the group, including
+// extension attributes, are referenced by their name in Microsoft Graph
+func (a *AzureSyncer) getGroupMetadata(group graph.Group) map[string]string {
+
+	metadata := map[string]string{}
+
+	for _, source := range getMetadataSources(a.MetadataMappings) {
+
+		attribute, found := getGraphMetadataAttribute(source)
+
+		if !found {
+			continue
+		}
+
+		if value, found := a.getGroupAttribute(group, attribute); found {
+			metadata[source] = value
+		}
+	}
+
+	return metadata
+}
+
+// getGraphMetadataAttribute returns the attribute of groups in Microsoft Graph containing the metadata field. The name and identifier of
+// groups are not retrieved as attributes
+func getGraphMetadataAttribute(source string) (string, bool) {
+
+	switch source {
+	case MetadataName, MetadataID:
+		return "", false
+	case MetadataEmail:
+		return GraphMail, true
+	}
+
+	return source, true
+}
+
+// isGroupTypeAllowed determines whether the type of the group is one of the configured group types
+func (a *AzureSyncer) isGroupTypeAllowed(group graph.Group) bool {
+
+	if len(a.Provider.GroupTypes) == 0 {
+		return true
+	}
 
-		username, found := a.isUsernamePresent(user, usernameAttribute)
+	groupType := getGroupType(group)
 
-		if found {
-			return username, true
+	for _, allowedGroupType := range a.Provider.GroupTypes {
+		if allowedGroupType == groupType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getGroupType classifies a group based on whether it is a Microsoft 365 group and whether it is mail and security enabled
+func getGroupType(group graph.Group) redhatcopv1alpha1.AzureGroupType {
+
+	groupTypes := group.GetGroupTypes()
+	if groupTypes == nil {
+		if additionalGroupTypes, ok := getAdditionalDataValue(group.GetAdditionalData()[GraphGroupTypes]).([]interface{}); ok {
+			for _, additionalGroupType := range additionalGroupTypes {
+				if value, ok := getAdditionalDataValue(additionalGroupType).(*string); ok && value != nil {
+					groupTypes = append(groupTypes, *value)
+				}
+			}
+		}
+	}
+
+	if containsString(groupTypes, GraphUnifiedGroupType) {
+		return redhatcopv1alpha1.Microsoft365AzureGroupType
+	}
+
+	mailEnabled := group.GetMailEnabled()
+	if mailEnabled == nil {
+		mailEnabled, _ = getAdditionalDataValue(group.GetAdditionalData()[GraphMailEnabled]).(*bool)
+	}
+
+	securityEnabled := group.GetSecurityEnabled()
+	if securityEnabled == nil {
+		securityEnabled, _ = getAdditionalDataValue(group.GetAdditionalData()[GraphSecurityEnabled]).(*bool)
+	}
+
+	isMailEnabled := mailEnabled != nil && *mailEnabled
+	isSecurityEnabled := securityEnabled != nil && *securityEnabled
+
+	switch {
+	case isSecurityEnabled && isMailEnabled:
+		return redhatcopv1alpha1.MailEnabledSecurityAzureGroupType
+	case isSecurityEnabled:
+		return redhatcopv1alpha1.SecurityAzureGroupType
+	default:
+		return redhatcopv1alpha1.DistributionAzureGroupType
+	}
+}
+
+// getGroupName returns the value of the first group name attribute present on the group
+func (a *AzureSyncer) getGroupName(group graph.Group) (string, bool) {
+
+	if a.Provider.GroupNameAttributes == nil {
+		return a.getGroupAttribute(group, GraphDisplayName)
+	}
+
+	for _, groupNameAttribute := range *a.Provider.GroupNameAttributes {
+
+		if groupName, found := a.getGroupAttribute(group, groupNameAttribute); found {
+			return groupName, true
 		}
 	}
 
 	return "", false
+}
+
+func (a *AzureSyncer) getGroupAttribute(group graph.Group, attribute string) (string, bool) {
+
+	var value *string
+
+	switch attribute {
+	case GraphDisplayName:
+		value = group.GetDisplayName()
+	case GraphMail:
+		value = group.GetMail()
+	case GraphMailNickname:
+		value = group.GetMailNickname()
+	case GraphDescription:
+		value = group.GetDescription()
+	}
+
+	// Groups retrieved as directory objects and extension attributes only contain additional data
+	if value == nil {
+		value, _ = group.GetAdditionalData()[attribute].(*string)
+	}
+
+	if value == nil || *value == "" {
+		return "", false
+	}
+
+	return *value, true
+}
+
+func (a *AzureSyncer) getServicePrincipalNameAttribute() string {
+
+	if a.Provider.ServicePrincipalNameAttribute == "" {
+		return GraphAppID
+	}
+
+	return string(a.Provider.ServicePrincipalNameAttribute)
+}
+
+func (a *AzureSyncer) isGuestUser(user graph.DirectoryObjectable) bool {
+
+	if userType, ok := user.GetAdditionalData()[GraphUserTypeAttribute].(*string); ok && userType != nil && strings.EqualFold(*userType, GraphGuestUserType) {
+		return true
+	}
+
+	if userPrincipalName, ok := user.GetAdditionalData()[GraphUserNameAttribute].(*string); ok && userPrincipalName != nil && strings.Contains(strings.ToUpper(*userPrincipalName), GraphExternalUserTag) {
+		return true
+	}
+
+	return false
+}
 
+func (a *AzureSyncer) isAccountEnabled(user graph.DirectoryObjectable) bool {
+
+	// Treat users as enabled when the attribute was not returned
+	if accountEnabled, ok := user.GetAdditionalData()[GraphAccountEnabled].(*bool); ok && accountEnabled != nil {
+		return *accountEnabled
+	}
+
+	return true
+}
+
+func (a *AzureSyncer) getUsernameForUser(user graph.DirectoryObjectable) (string, bool) {
+	return resolveUsername(a.Provider.UserNameAttributes, GraphUserNameAttribute, func(attribute string) (string, bool) {
+		return a.isUsernamePresent(user, attribute)
+	})
 }
 
 func (a *AzureSyncer) isUsernamePresent(user graph.DirectoryObjectable, field string) (string, bool) {
 
-	value, ok := user.GetAdditionalData()[field].(*string)
-	return fmt.Sprintf("%v", *value), ok
+	// Schema extension properties are referenced as <schema extension>.<property>
+	fieldPath := strings.SplitN(field, GraphExtensionPropertySeparator, 2)
+
+	value := getAdditionalDataValue(user.GetAdditionalData()[fieldPath[0]])
+
+	if len(fieldPath) > 1 {
+		value = getAdditionalDataProperty(value, fieldPath[1])
+	}
+
+	switch typedValue := value.(type) {
+	case *string:
+		if typedValue != nil && *typedValue != "" {
+			return *typedValue, true
+		}
+	case string:
+		if typedValue != "" {
+			return typedValue, true
+		}
+	}
+
+	return "", false
+}
+
+// isExtensionAttribute determines whether an attribute refers to a directory extension (extension_<appId>_<name>) or a schema extension property
+func isExtensionAttribute(attribute string) bool {
+	return strings.HasPrefix(attribute, GraphDirectoryExtensionPrefix) || strings.Contains(attribute, GraphExtensionPropertySeparator)
+}
+
+// getAdditionalDataProperty returns a property of a complex value contained in the additional data of a directory object
+func getAdditionalDataProperty(value interface{}, property string) interface{} {
+
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		return getAdditionalDataValue(typedValue[property])
+	case *map[string]interface{}:
+		if typedValue != nil {
+			return getAdditionalDataValue((*typedValue)[property])
+		}
+	}
+
+	return nil
+}
+
+// getAdditionalDataValue unwraps values that were not deserialized into a primitive type
+func getAdditionalDataValue(value interface{}) interface{} {
+
+	rawValueNode, ok := value.(interface {
+		GetRawValue() (interface{}, error)
+	})
+
+	if !ok {
+		return value
+	}
+
+	rawValue, err := rawValueNode.GetRawValue()
+
+	if err != nil {
+		return nil
+	}
+
+	// Complex values are composed of nested nodes
+	if reflectValue := reflect.ValueOf(rawValue); reflectValue.Kind() == reflect.Map && reflectValue.Type().Key().Kind() == reflect.String {
+		properties := map[string]interface{}{}
+		for _, key := range reflectValue.MapKeys() {
+			properties[key.String()] = reflectValue.MapIndex(key).Interface()
+		}
+		return properties
+	}
+
+	return rawValue
 }
 
 func (a *AzureSyncer) GetPrune() bool {
@@ -348,6 +1113,26 @@ func getAuthorityHost(authorityHost *string) azidentity.AuthorityHost {
 
 }
 
+// getGraphEndpoint returns the Microsoft Graph endpoint to use, deriving it from the Azure Active Directory endpoint when not specified
+func getGraphEndpoint(graphEndpoint *string, authorityHost *string) string {
+
+	if graphEndpoint != nil {
+		return strings.TrimSuffix(*graphEndpoint, "/")
+	}
+
+	if endpoint, found := graphEndpoints[azidentity.AuthorityHost(strings.TrimSuffix(string(getAuthorityHost(authorityHost)), "/")+"/")]; found {
+		return endpoint
+	}
+
+	return graphEndpoints[azidentity.AzurePublicCloud]
+
+}
+
+// escapeODataString escapes single quotes contained in a string literal of an OData query
+func escapeODataString(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
 func getGroupsFromResults(result graph.GroupCollectionResponseable) []graph.Group {
 	groups := []graph.Group{}
 
@@ -0,0 +1,51 @@
+package syncer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAzureSyncTimeout(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		timeout     *v1.Duration
+		syncTimeout *v1.Duration
+		expected    time.Duration
+	}{
+		{name: "no timeout", expected: 0},
+		{name: "provider timeout", timeout: &v1.Duration{Duration: time.Minute}, expected: time.Minute},
+		{name: "deprecated sync timeout", syncTimeout: &v1.Duration{Duration: 2 * time.Minute}, expected: 2 * time.Minute},
+		{name: "provider timeout takes precedence", timeout: &v1.Duration{Duration: time.Minute}, syncTimeout: &v1.Duration{Duration: 2 * time.Minute}, expected: time.Minute},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			provider := &redhatcopv1alpha1.Provider{
+				Name:         "azure",
+				Timeout:      test.timeout,
+				ProviderType: &redhatcopv1alpha1.ProviderType{Azure: &redhatcopv1alpha1.AzureProvider{SyncTimeout: test.syncTimeout}},
+			}
+
+			groupSyncer, err := getGroupSyncerForProvider(context.Background(), &redhatcopv1alpha1.GroupSync{}, provider, util.ReconcilerBase{}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			azureSyncer, ok := groupSyncer.(*AzureSyncer)
+			if !ok {
+				t.Fatalf("expected an Azure syncer, found %T", groupSyncer)
+			}
+
+			if syncTimeout := azureSyncer.getSyncTimeout(); syncTimeout != test.expected {
+				t.Errorf("expected sync timeout %s, found %s", test.expected, syncTimeout)
+			}
+		})
+	}
+}
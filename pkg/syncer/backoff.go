@@ -0,0 +1,68 @@
+package syncer
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// transientErrorBackoff is the backoff between the attempts of a request to a group source failing with a transient error
+var transientErrorBackoff = wait.Backoff{
+	Steps:    4,
+	Duration: 500 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// responseError records the status code of the response of a failed request to a group source
+type responseError struct {
+	statusCode int
+	err        error
+}
+
+func (e *responseError) Error() string {
+	return e.err.Error()
+}
+
+func (e *responseError) Unwrap() error {
+	return e.err
+}
+
+// withResponseStatus records the status code of the response, if any, of a failed request so that the error can be classified
+func withResponseStatus(response *http.Response, err error) error {
+
+	if err == nil || response == nil {
+		return err
+	}
+
+	return &responseError{statusCode: response.StatusCode, err: err}
+}
+
+// isTransientError determines whether a request failed for a reason that is not expected to persist, such as a timeout, a reset
+// connection, throttling or an error of the group source
+func isTransientError(err error) bool {
+
+	var statusError *responseError
+	if errors.As(err, &statusError) {
+		return statusError.statusCode == http.StatusTooManyRequests || statusError.statusCode >= http.StatusInternalServerError
+	}
+
+	var netError net.Error
+	if errors.As(err, &netError) && netError.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryTransientErrors issues the request until it succeeds, fails with an error that is not transient or the attempts of the backoff
+// are exhausted
+func retryTransientErrors(request func() error) error {
+	return retry.OnError(transientErrorBackoff, isTransientError, request)
+}
@@ -0,0 +1,92 @@
+package syncer
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ParseCaBundleConfigMapRef parses a reference to a ConfigMap containing a bundle of CA certificates in the form namespace/name or
+// namespace/name/key
+func ParseCaBundleConfigMapRef(value string) (*redhatcopv1alpha1.ConfigMapRef, error) {
+
+	if value == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, "/")
+
+	if (len(parts) != 2 && len(parts) != 3) || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Invalid CA bundle ConfigMap '%s', expected namespace/name or namespace/name/key", value)
+	}
+
+	caBundle := &redhatcopv1alpha1.ConfigMapRef{Namespace: parts[0], Name: parts[1]}
+
+	if len(parts) == 3 {
+		caBundle.Key = parts[2]
+	}
+
+	return caBundle, nil
+}
+
+// getCaBundles returns the CA certificates contained in each of the ConfigMaps
+func getCaBundles(context context.Context, c client.Client, caBundles []*redhatcopv1alpha1.ConfigMapRef) ([]byte, error) {
+
+	certificates := [][]byte{}
+
+	for _, caBundle := range caBundles {
+
+		caBundleResource := caBundle.ObjectRef()
+
+		caResource, err := getObjectRefData(context, c, caBundleResource)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resourceCaKey := getResourceCaKey(caBundleResource, caResource)
+
+		certificate, found := caResource[resourceCaKey]
+
+		if !found {
+			return nil, fmt.Errorf("Could not find '%s' key in CA bundle ConfigMap '%s' in namespace '%s'", resourceCaKey, caBundle.Name, caBundle.Namespace)
+		}
+
+		certificates = append(certificates, bytes.TrimSpace(certificate))
+	}
+
+	return bytes.Join(certificates, []byte("\n")), nil
+}
+
+// appendCaBundles appends the CA certificates of the bundles to the certificate of the provider
+func appendCaBundles(caCertificate []byte, caBundles []byte) []byte {
+
+	if len(caBundles) == 0 {
+		return caCertificate
+	}
+
+	if len(caCertificate) == 0 {
+		return caBundles
+	}
+
+	return bytes.Join([][]byte{bytes.TrimSpace(caCertificate), caBundles}, []byte("\n"))
+}
+
+// getRootCAs returns the system certificates along with the provided certificates
+func getRootCAs(caCertificate []byte) *x509.CertPool {
+
+	rootCAs, err := x509.SystemCertPool()
+
+	if err != nil || rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	rootCAs.AppendCertsFromPEM(caCertificate)
+
+	return rootCAs
+}
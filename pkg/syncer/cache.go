@@ -0,0 +1,44 @@
+package syncer
+
+import (
+	"sync"
+)
+
+// responseCache memoizes the responses of a group source during a synchronization so that resources referenced several times, such as
+// groups granted multiple roles, are only retrieved once. A nil cache retrieves every response
+type responseCache struct {
+	entries map[string]interface{}
+	mutex   sync.Mutex
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: map[string]interface{}{}}
+}
+
+// get returns the cached response for the key, retrieving the response when not cached. Failed retrievals are not cached
+func (c *responseCache) get(key string, retrieve func() (interface{}, error)) (interface{}, error) {
+
+	if c == nil {
+		return retrieve()
+	}
+
+	c.mutex.Lock()
+	response, found := c.entries[key]
+	c.mutex.Unlock()
+
+	if found {
+		return response, nil
+	}
+
+	response, err := retrieve()
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.entries[key] = response
+	c.mutex.Unlock()
+
+	return response, nil
+}
@@ -0,0 +1,141 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// checkpointFieldManager is the field manager of the ConfigMaps checkpoints are stored in
+const checkpointFieldManager = "group-sync-operator"
+
+// CheckpointStore stores the checkpoints of incremental synchronizations, such as cursors, delta tokens or etags, between
+// synchronizations of a provider
+type CheckpointStore interface {
+	// GetCheckpoint returns the checkpoint stored for the key, or an empty string when none was stored
+	GetCheckpoint(key string) (string, error)
+	// SetCheckpoint stores the checkpoint for the key. Checkpoints are only persisted once the groups retrieved by the synchronization
+	// were written, so that a failed synchronization is retried from the previous checkpoints
+	SetCheckpoint(key string, value string)
+}
+
+// IncrementalSyncer is implemented by syncers synchronizing incrementally from the checkpoints of their previous synchronization
+type IncrementalSyncer interface {
+	SetCheckpointStore(checkpointStore CheckpointStore)
+}
+
+// ConfigMapCheckpointStore stores the checkpoints of a provider in a ConfigMap in the namespace of the GroupSync. The ConfigMap is
+// owned by the GroupSync so that it is deleted along with the GroupSync
+type ConfigMapCheckpointStore struct {
+	GroupSync      *redhatcopv1alpha1.GroupSync
+	ProviderName   string
+	ReconcilerBase util.ReconcilerBase
+	Context        context.Context
+	checkpoints    map[string]string
+	changed        bool
+}
+
+// GetCheckpointConfigMapName returns the name of the ConfigMap the checkpoints of the provider are stored in
+func GetCheckpointConfigMapName(groupSync *redhatcopv1alpha1.GroupSync, providerName string) string {
+	return fmt.Sprintf("%s-%s-checkpoints", groupSync.Name, providerName)
+}
+
+func (s *ConfigMapCheckpointStore) GetCheckpoint(key string) (string, error) {
+
+	if err := s.load(); err != nil {
+		return "", err
+	}
+
+	return s.checkpoints[key], nil
+}
+
+func (s *ConfigMapCheckpointStore) SetCheckpoint(key string, value string) {
+
+	if s.checkpoints == nil {
+		s.checkpoints = map[string]string{}
+	}
+
+	if s.checkpoints[key] != value {
+		s.checkpoints[key] = value
+		s.changed = true
+	}
+}
+
+// load reads the checkpoints from the ConfigMap the first time they are needed
+func (s *ConfigMapCheckpointStore) load() error {
+
+	if s.checkpoints != nil {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := s.ReconcilerBase.GetClient().Get(s.Context, types.NamespacedName{Name: GetCheckpointConfigMapName(s.GroupSync, s.ProviderName), Namespace: s.GroupSync.Namespace}, configMap)
+
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("Failed to read checkpoints of provider '%s': %w", s.ProviderName, err)
+	}
+
+	s.checkpoints = map[string]string{}
+
+	for key, value := range configMap.Data {
+		s.checkpoints[key] = value
+	}
+
+	return nil
+}
+
+// Save writes the checkpoints changed by the synchronization to the ConfigMap using server-side apply
+func (s *ConfigMapCheckpointStore) Save() error {
+
+	if !s.changed {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetCheckpointConfigMapName(s.GroupSync, s.ProviderName),
+			Namespace: s.GroupSync.Namespace,
+			Labels: map[string]string{
+				constants.GroupSyncName:      s.GroupSync.Name,
+				constants.GroupSyncNamespace: s.GroupSync.Namespace,
+				constants.ProviderName:       s.ProviderName,
+			},
+		},
+		Data: s.checkpoints,
+	}
+
+	if err := controllerutil.SetControllerReference(s.GroupSync, configMap, s.ReconcilerBase.GetScheme()); err != nil {
+		return err
+	}
+
+	if err := s.ReconcilerBase.GetClient().Patch(s.Context, configMap, client.Apply, client.FieldOwner(checkpointFieldManager), client.ForceOwnership); err != nil {
+		return fmt.Errorf("Failed to save checkpoints of provider '%s': %w", s.ProviderName, err)
+	}
+
+	s.changed = false
+
+	return nil
+}
+
+// SaveCheckpoints persists the checkpoints of the provider once the groups retrieved from the provider were written
+func (m *GroupSyncMgr) SaveCheckpoints(providerName string) error {
+
+	if checkpointStore, found := m.CheckpointStores[providerName]; found {
+		return checkpointStore.Save()
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package syncer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getClientCertificate loads the client certificate and key presented to providers requiring mutual TLS from a Secret of type
+// kubernetes.io/tls
+func getClientCertificate(context context.Context, c client.Client, clientCertificateSecret *redhatcopv1alpha1.SecretRef) (*tls.Certificate, error) {
+
+	if clientCertificateSecret == nil {
+		return nil, nil
+	}
+
+	clientCertificateData, err := getObjectRefData(context, c, clientCertificateSecret.ObjectRef())
+
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, certificateFound := clientCertificateData[corev1.TLSCertKey]
+	key, keyFound := clientCertificateData[corev1.TLSPrivateKeyKey]
+
+	if !certificateFound || !keyFound {
+		return nil, fmt.Errorf("Could not find '%s' and '%s' keys in client certificate secret '%s' in namespace '%s'", corev1.TLSCertKey, corev1.TLSPrivateKeyKey, clientCertificateSecret.Name, clientCertificateSecret.Namespace)
+	}
+
+	clientCertificate, err := tls.X509KeyPair(certificate, key)
+
+	if err != nil {
+		return nil, fmt.Errorf("Invalid client certificate in secret '%s' in namespace '%s': %w", clientCertificateSecret.Name, clientCertificateSecret.Namespace, err)
+	}
+
+	return &clientCertificate, nil
+}
@@ -0,0 +1,44 @@
+package syncer
+
+import (
+	"fmt"
+	"strings"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DefaultKubeConfigKey is the key of the kubeconfig of a remote cluster in its secret when no key is specified
+const DefaultKubeConfigKey = "kubeconfig"
+
+func (m *GroupSyncMgr) validateClusters() []error {
+	validationErrors := []error{}
+
+	// Groups are only distributed once written to the cluster
+	if m.GroupSync.Spec.RoleBindingsOnly {
+		validationErrors = append(validationErrors, fmt.Errorf("Clusters cannot be specified when roleBindingsOnly is enabled"))
+	}
+
+	clusterNames := map[string]bool{}
+
+	for _, cluster := range m.GroupSync.Spec.Clusters {
+
+		if errs := validation.IsDNS1123Subdomain(cluster.Name); len(errs) > 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid cluster name '%s': %s", cluster.Name, strings.Join(errs, ", ")))
+		}
+
+		if clusterNames[cluster.Name] {
+			validationErrors = append(validationErrors, fmt.Errorf("Cluster '%s' is specified more than once", cluster.Name))
+		}
+
+		clusterNames[cluster.Name] = true
+
+		if cluster.KubeConfigSecret == nil || cluster.KubeConfigSecret.Name == "" || cluster.KubeConfigSecret.Namespace == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("Cluster '%s' does not reference a kubeconfig secret", cluster.Name))
+		} else if cluster.KubeConfigSecret.Kind != "" && cluster.KubeConfigSecret.Kind != redhatcopv1alpha1.SecretMapObjectRefKind {
+			validationErrors = append(validationErrors, fmt.Errorf("Kubeconfig of cluster '%s' must be referenced from a Secret", cluster.Name))
+		}
+	}
+
+	return validationErrors
+}
@@ -0,0 +1,313 @@
+package syncer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultVaultAuthPath    = "kubernetes"
+	vaultRequestTimeout     = 30 * time.Second
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// getCredentials loads the credentials of a provider from its credentials source or, when no source is specified, from its credentials
+// secret. The credentials are returned as a Secret so that syncers access them in the same way regardless of their source, along with a
+// description of the source to be used in error messages. Credentials stored under other keys than the keys expected by the provider are
+// made available under the expected keys according to the credentials keys of the provider
+func getCredentials(context context.Context, c client.Client, credentialsSource *redhatcopv1alpha1.CredentialsSource, credentialsKeys map[string]string, credentialsSecret *redhatcopv1alpha1.ObjectRef) (*corev1.Secret, string, error) {
+
+	secret, description, err := loadCredentials(context, c, credentialsSource, credentialsSecret)
+
+	if err != nil || len(credentialsKeys) == 0 {
+		return secret, description, err
+	}
+
+	// Secrets are shared with the cache of the client and must not be changed
+	secret = secret.DeepCopy()
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	for expectedKey, key := range credentialsKeys {
+		if value, found := secret.Data[key]; found {
+			secret.Data[expectedKey] = value
+		}
+	}
+
+	return secret, description, nil
+}
+
+// loadCredentials loads the credentials of a provider from its credentials source or from its credentials secret
+func loadCredentials(context context.Context, c client.Client, credentialsSource *redhatcopv1alpha1.CredentialsSource, credentialsSecret *redhatcopv1alpha1.ObjectRef) (*corev1.Secret, string, error) {
+
+	if credentialsSource == nil {
+
+		if credentialsSecret == nil {
+			return nil, "", fmt.Errorf("Either credentialsSecret or credentialsSource must be specified")
+		}
+
+		description := fmt.Sprintf("secret '%s' in namespace '%s'", credentialsSecret.Name, credentialsSecret.Namespace)
+
+		secret := &corev1.Secret{}
+		err := c.Get(context, types.NamespacedName{Name: credentialsSecret.Name, Namespace: credentialsSecret.Namespace}, secret)
+
+		return secret, description, err
+	}
+
+	if err := validateCredentialsSource(credentialsSource); err != nil {
+		return nil, "", err
+	}
+
+	var description string
+	var data map[string][]byte
+	var err error
+
+	switch {
+	case credentialsSource.File != nil:
+		description = fmt.Sprintf("directory '%s'", credentialsSource.File.Path)
+		data, err = getFileCredentials(credentialsSource.File)
+	case credentialsSource.Env != nil:
+		description = "environment variables"
+		data, err = getEnvCredentials(credentialsSource.Env)
+	case credentialsSource.Vault != nil:
+		description = fmt.Sprintf("Vault path '%s'", credentialsSource.Vault.Path)
+		data, err = getVaultCredentials(context, c, credentialsSource.Vault)
+	}
+
+	if err != nil {
+		return nil, description, fmt.Errorf("Failed to load credentials from %s: %w", description, err)
+	}
+
+	return &corev1.Secret{Data: data}, description, nil
+}
+
+// validateCredentialsSource verifies that exactly one source of credentials is specified
+func validateCredentialsSource(credentialsSource *redhatcopv1alpha1.CredentialsSource) error {
+
+	sources := 0
+
+	if credentialsSource.File != nil {
+		sources++
+	}
+
+	if credentialsSource.Env != nil {
+		sources++
+	}
+
+	if credentialsSource.Vault != nil {
+		sources++
+	}
+
+	if sources != 1 {
+		return fmt.Errorf("Exactly one of file, env or vault must be specified in credentialsSource")
+	}
+
+	return nil
+}
+
+// getFileCredentials reads each file of the directory as a credential whose key is the name of the file. Hidden files, such as the
+// directories created when Kubernetes updates mounted volumes atomically, are ignored
+func getFileCredentials(fileSource *redhatcopv1alpha1.FileCredentialsSource) (map[string][]byte, error) {
+
+	files, err := ioutil.ReadDir(fileSource.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string][]byte{}
+
+	for _, file := range files {
+
+		if strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		// Mounted volumes contain symbolic links to the files
+		info, err := os.Stat(filepath.Join(fileSource.Path, file.Name()))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		value, err := ioutil.ReadFile(filepath.Join(fileSource.Path, file.Name()))
+
+		if err != nil {
+			return nil, err
+		}
+
+		data[file.Name()] = bytes.TrimRight(value, "\r\n")
+	}
+
+	return data, nil
+}
+
+// getEnvCredentials reads each credential from the environment variable of the operator it is mapped to
+func getEnvCredentials(envSource map[string]string) (map[string][]byte, error) {
+
+	data := map[string][]byte{}
+
+	for key, variable := range envSource {
+
+		value, found := os.LookupEnv(variable)
+
+		if !found {
+			return nil, fmt.Errorf("Environment variable '%s' for key '%s' is not set", variable, key)
+		}
+
+		data[key] = []byte(value)
+	}
+
+	return data, nil
+}
+
+// getVaultCredentials authenticates to Vault using the Kubernetes auth method and reads the credentials from the path. Both versions of
+// the KV secrets engine are supported
+func getVaultCredentials(context context.Context, c client.Client, vaultSource *redhatcopv1alpha1.VaultCredentialsSource) (map[string][]byte, error) {
+
+	httpClient, err := getVaultHTTPClient(context, c, vaultSource)
+
+	if err != nil {
+		return nil, err
+	}
+
+	jwt, err := ioutil.ReadFile(serviceAccountTokenFile)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read service account token: %w", err)
+	}
+
+	authPath := vaultSource.AuthPath
+	if authPath == "" {
+		authPath = defaultVaultAuthPath
+	}
+
+	loginRequest, err := json.Marshal(map[string]string{"role": vaultSource.Role, "jwt": strings.TrimSpace(string(jwt))})
+
+	if err != nil {
+		return nil, err
+	}
+
+	loginResponse := struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}{}
+
+	if err := doVaultRequest(context, httpClient, http.MethodPost, getVaultURL(vaultSource.Address, "auth", authPath, "login"), "", loginRequest, &loginResponse); err != nil {
+		return nil, fmt.Errorf("Failed to authenticate to Vault: %w", err)
+	}
+
+	secretResponse := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+
+	if err := doVaultRequest(context, httpClient, http.MethodGet, getVaultURL(vaultSource.Address, vaultSource.Path), loginResponse.Auth.ClientToken, nil, &secretResponse); err != nil {
+		return nil, err
+	}
+
+	values := secretResponse.Data
+
+	// Version 2 of the KV secrets engine nests the values of the secret along with its metadata
+	if nested, ok := values["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := values["metadata"]; hasMetadata {
+			values = nested
+		}
+	}
+
+	data := map[string][]byte{}
+
+	for key, value := range values {
+		if stringValue, ok := value.(string); ok {
+			data[key] = []byte(stringValue)
+		}
+	}
+
+	return data, nil
+}
+
+func getVaultHTTPClient(context context.Context, c client.Client, vaultSource *redhatcopv1alpha1.VaultCredentialsSource) (*http.Client, error) {
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if vaultSource.Ca != nil {
+		caResource, err := getObjectRefData(context, c, vaultSource.Ca)
+
+		if err != nil {
+			return nil, err
+		}
+
+		caCertificate, found := caResource[getResourceCaKey(vaultSource.Ca, caResource)]
+
+		if !found {
+			return nil, fmt.Errorf("Could not find CA certificate key in %s '%s' in namespace '%s'", vaultSource.Ca.Kind, vaultSource.Ca.Name, vaultSource.Ca.Namespace)
+		}
+
+		tlsConfig := &tls.Config{
+			RootCAs: x509.NewCertPool(),
+		}
+
+		tlsConfig.RootCAs.AppendCertsFromPEM(caCertificate)
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   vaultRequestTimeout,
+	}, nil
+}
+
+func getVaultURL(address string, paths ...string) string {
+	return fmt.Sprintf("%s/v1/%s", strings.TrimRight(address, "/"), strings.Trim(strings.Join(paths, "/"), "/"))
+}
+
+func doVaultRequest(context context.Context, httpClient *http.Client, method string, url string, token string, body []byte, response interface{}) error {
+
+	request, err := http.NewRequestWithContext(context, method, url, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	if token != "" {
+		request.Header.Set("X-Vault-Token", token)
+	}
+
+	httpResponse, err := httpClient.Do(request)
+
+	if err != nil {
+		return err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("Vault returned status %d for '%s'", httpResponse.StatusCode, url)
+	}
+
+	return json.NewDecoder(httpResponse.Body).Decode(response)
+}
@@ -0,0 +1,290 @@
+package syncer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/pkg/plugin"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	execLogger = logf.Log.WithName("syncer_exec")
+
+	// ExecProviderDirectory is the directory containing the commands that exec providers may run. Exec providers are disabled when
+	// no directory is specified
+	ExecProviderDirectory = ""
+)
+
+const (
+	defaultExecTimeout = 5 * time.Minute
+	// execMaxStderrLength is the maximum length of the standard error of a failed command included in the error returned
+	execMaxStderrLength = 1024
+	// execPath is the search path of the commands, which is not inherited from the operator
+	execPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+)
+
+var (
+	execEnvNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// execReservedEnvNames are the environment variables controlling the dynamic loader, the shell or the interpreters running the
+	// command, which would allow a GroupSync to run arbitrary code in the operator
+	execReservedEnvNames = map[string]bool{
+		"PATH":           true,
+		"BASH_ENV":       true,
+		"ENV":            true,
+		"SHELLOPTS":      true,
+		"BASHOPTS":       true,
+		"IFS":            true,
+		"CDPATH":         true,
+		"GLOBIGNORE":     true,
+		"PS4":            true,
+		"PROMPT_COMMAND": true,
+		"PYTHONPATH":     true,
+		"PYTHONSTARTUP":  true,
+		"PYTHONHOME":     true,
+		"PERL5LIB":       true,
+		"PERL5OPT":       true,
+		"PERLLIB":        true,
+		"RUBYLIB":        true,
+		"RUBYOPT":        true,
+		"NODE_OPTIONS":   true,
+		"NODE_PATH":      true,
+	}
+
+	// execReservedEnvPrefixes are the prefixes of the environment variables controlling the dynamic loader or exporting shell functions
+	execReservedEnvPrefixes = []string{"LD_", "DYLD_", "BASH_FUNC_", "GCONV_", "MALLOC_"}
+)
+
+// ExecSyncer retrieves the groups of an out-of-tree provider by running a command printing the groups in JSON
+type ExecSyncer struct {
+	Name              string
+	GroupSync         *redhatcopv1alpha1.GroupSync
+	Provider          *redhatcopv1alpha1.ExecProvider
+	Context           context.Context
+	ReconcilerBase    util.ReconcilerBase
+	CredentialsSource *redhatcopv1alpha1.CredentialsSource
+	CredentialsKeys   map[string]string
+	MetadataMappings  []redhatcopv1alpha1.MetadataMapping
+	Credentials       map[string]string
+}
+
+func (e *ExecSyncer) Init() bool {
+
+	if e.Context == nil {
+		e.Context = context.Background()
+	}
+
+	return false
+}
+
+func (e *ExecSyncer) Validate() error {
+
+	validationErrors := []error{}
+
+	if ExecProviderDirectory == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("Exec providers are not enabled by the operator"))
+	} else if err := validateExecCommand(e.Provider.Command); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	if e.Provider.Timeout != nil && e.Provider.Timeout.Duration <= 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("Invalid timeout of command '%s': '%s'", e.Provider.Command, e.Provider.Timeout.Duration.String()))
+	}
+
+	validationErrors = append(validationErrors, validateExecEnv(e.Provider.Env)...)
+
+	// Credentials are optional as commands may authenticate to their group source by other means
+	execCredentials, err := getPluginCredentials(e.Context, e.ReconcilerBase.GetClient(), e.CredentialsSource, e.CredentialsKeys, e.Provider.CredentialsSecret)
+
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	} else {
+		e.Credentials = execCredentials
+	}
+
+	return utilerrors.NewAggregate(validationErrors)
+}
+
+// Bind verifies that the command can be run, as commands are not expected to support a separate bind operation
+func (e *ExecSyncer) Bind() error {
+	return validateExecCommand(e.Provider.Command)
+}
+
+func (e *ExecSyncer) Sync() ([]userv1.Group, error) {
+
+	response, err := e.run()
+
+	if err != nil {
+		syncLogger(e.Context, execLogger).Error(err, "Failed to retrieve groups from command", "Provider", e.Name, "Command", e.Provider.Command)
+		return nil, err
+	}
+
+	return getPluginGroups(e.Context, e.Name, e.Provider.Command, response.Groups, e.MetadataMappings), nil
+}
+
+func (e *ExecSyncer) GetProviderName() string {
+	return e.Name
+}
+
+func (e *ExecSyncer) GetPrune() bool {
+	return e.Provider.Prune
+}
+
+// run writes the request of the provider to the standard input of the command and parses the groups printed on its standard output
+func (e *ExecSyncer) run() (*plugin.SyncResponse, error) {
+
+	timeout := defaultExecTimeout
+	if e.Provider.Timeout != nil {
+		timeout = e.Provider.Timeout.Duration
+	}
+
+	execContext, cancel := context.WithTimeout(e.Context, timeout)
+	defer cancel()
+
+	request, err := json.Marshal(&plugin.Request{
+		Provider:    e.Name,
+		Parameters:  e.Provider.Parameters,
+		Credentials: e.Credentials,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	command := exec.CommandContext(execContext, e.Provider.Command, e.Provider.Args...)
+	command.Stdin = bytes.NewReader(request)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	command.Env = getExecEnv(e.Provider.Env)
+
+	if err := command.Run(); err != nil {
+
+		if execContext.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("Command '%s' timed out after %s", e.Provider.Command, timeout.String())
+		}
+
+		return nil, fmt.Errorf("Command '%s' failed: %w: %s", e.Provider.Command, err, truncateStderr(stderr.String()))
+	}
+
+	response := &plugin.SyncResponse{}
+
+	if err := json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("Failed to parse groups printed by command '%s': %w", e.Provider.Command, err)
+	}
+
+	return response, nil
+}
+
+// validateExecCommand verifies that the command is an executable file of the exec provider directory
+func validateExecCommand(command string) error {
+
+	if !filepath.IsAbs(command) {
+		return fmt.Errorf("Command '%s' must be an absolute path", command)
+	}
+
+	directory, err := filepath.Abs(ExecProviderDirectory)
+
+	if err != nil {
+		return err
+	}
+
+	if relativePath, err := filepath.Rel(directory, filepath.Clean(command)); err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("Command '%s' must be located in the exec provider directory '%s'", command, directory)
+	}
+
+	info, err := os.Stat(command)
+
+	if err != nil {
+		return fmt.Errorf("Failed to find command '%s': %w", command, err)
+	}
+
+	if info.IsDir() || info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("Command '%s' is not executable", command)
+	}
+
+	return nil
+}
+
+// validateExecEnv verifies that the environment variables of the command are valid names that do not control the dynamic loader,
+// the shell or the interpreter running the command
+func validateExecEnv(env map[string]string) []error {
+
+	validationErrors := []error{}
+
+	for name := range env {
+		if !execEnvNameRegexp.MatchString(name) {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid environment variable name '%s'", name))
+		} else if isReservedExecEnvName(name) {
+			validationErrors = append(validationErrors, fmt.Errorf("Environment variable '%s' cannot be set for commands", name))
+		}
+	}
+
+	sort.Slice(validationErrors, func(i, j int) bool {
+		return validationErrors[i].Error() < validationErrors[j].Error()
+	})
+
+	return validationErrors
+}
+
+func isReservedExecEnvName(name string) bool {
+
+	name = strings.ToUpper(name)
+
+	if execReservedEnvNames[name] {
+		return true
+	}
+
+	for _, prefix := range execReservedEnvPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getExecEnv returns the environment of the command. Commands do not inherit the environment of the operator, which contains the
+// credentials of the operator, and only receive the search path and the environment variables of the provider
+func getExecEnv(env map[string]string) []string {
+
+	execEnv := []string{fmt.Sprintf("PATH=%s", execPath)}
+
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		execEnv = append(execEnv, fmt.Sprintf("%s=%s", name, env[name]))
+	}
+
+	return execEnv
+}
+
+func truncateStderr(stderr string) string {
+
+	stderr = strings.TrimSpace(stderr)
+
+	if len(stderr) > execMaxStderrLength {
+		return stderr[:execMaxStderrLength] + "..."
+	}
+
+	return stderr
+}
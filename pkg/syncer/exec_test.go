@@ -0,0 +1,53 @@
+package syncer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateExecEnv(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		env           map[string]string
+		expectedError bool
+	}{
+		{name: "no environment variables", expectedError: false},
+		{name: "valid environment variables", env: map[string]string{"HR_API_URL": "https://hr.example.com", "_DEBUG": "1"}, expectedError: false},
+		{name: "invalid name", env: map[string]string{"HR-API": "value"}, expectedError: true},
+		{name: "name containing an equal sign", env: map[string]string{"A=B": "value"}, expectedError: true},
+		{name: "search path", env: map[string]string{"PATH": "/tmp"}, expectedError: true},
+		{name: "dynamic loader", env: map[string]string{"LD_PRELOAD": "/tmp/library.so"}, expectedError: true},
+		{name: "dynamic loader in lower case", env: map[string]string{"ld_library_path": "/tmp"}, expectedError: true},
+		{name: "shell startup file", env: map[string]string{"BASH_ENV": "/tmp/script.sh"}, expectedError: true},
+		{name: "exported shell function", env: map[string]string{"BASH_FUNC_ls%%": "() { id; }"}, expectedError: true},
+		{name: "interpreter options", env: map[string]string{"NODE_OPTIONS": "--require /tmp/script.js"}, expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			validationErrors := validateExecEnv(test.env)
+
+			if test.expectedError && len(validationErrors) == 0 {
+				t.Errorf("expected environment %v to be rejected", test.env)
+			}
+
+			if !test.expectedError && len(validationErrors) > 0 {
+				t.Errorf("unexpected errors validating environment %v: %v", test.env, validationErrors)
+			}
+		})
+	}
+}
+
+func TestGetExecEnv(t *testing.T) {
+
+	t.Setenv("GROUP_SYNC_OPERATOR_SECRET", "secret")
+
+	env := getExecEnv(map[string]string{"B": "2", "A": "1"})
+	expected := []string{"PATH=" + execPath, "A=1", "B=2"}
+
+	if !reflect.DeepEqual(env, expected) {
+		t.Errorf("expected environment %v, found %v", expected, env)
+	}
+}
@@ -0,0 +1,84 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/pkg/plugin"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// fakeSourceHost is recorded in the sync.source.host annotation of the groups of fake providers
+const fakeSourceHost = "fake"
+
+// FakeSyncer returns the groups specified inline in the provider, so that the behavior of the operator can be tested without a group source
+type FakeSyncer struct {
+	Name             string
+	GroupSync        *redhatcopv1alpha1.GroupSync
+	Provider         *redhatcopv1alpha1.FakeProvider
+	Context          context.Context
+	ReconcilerBase   util.ReconcilerBase
+	MetadataMappings []redhatcopv1alpha1.MetadataMapping
+}
+
+func (f *FakeSyncer) Init() bool {
+
+	if f.Context == nil {
+		f.Context = context.Background()
+	}
+
+	return false
+}
+
+func (f *FakeSyncer) Validate() error {
+
+	validationErrors := []error{}
+	groupNames := map[string]bool{}
+
+	for _, group := range f.Provider.Groups {
+
+		if group.Name == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("Fake group name must be specified"))
+			continue
+		}
+
+		if groupNames[group.Name] {
+			validationErrors = append(validationErrors, fmt.Errorf("Fake group '%s' is specified more than once", group.Name))
+		}
+
+		groupNames[group.Name] = true
+	}
+
+	return utilerrors.NewAggregate(validationErrors)
+}
+
+func (f *FakeSyncer) Bind() error {
+	return nil
+}
+
+func (f *FakeSyncer) Sync() ([]userv1.Group, error) {
+
+	groups := []plugin.Group{}
+
+	for _, group := range f.Provider.Groups {
+		groups = append(groups, plugin.Group{
+			Name:       group.Name,
+			ID:         group.ID,
+			Users:      group.Users,
+			Attributes: group.Attributes,
+		})
+	}
+
+	return getPluginGroups(f.Context, f.Name, fakeSourceHost, groups, f.MetadataMappings), nil
+}
+
+func (f *FakeSyncer) GetProviderName() string {
+	return f.Name
+}
+
+func (f *FakeSyncer) GetPrune() bool {
+	return f.Provider.Prune
+}
@@ -0,0 +1,207 @@
+package syncer
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const (
+	groupFilterVariable = "group"
+	userFilterVariable  = "user"
+)
+
+// FilterSyncer decorates a GroupSyncer by filtering the synchronized groups and their users using CEL expressions and the size of the groups
+type FilterSyncer struct {
+	GroupSyncer
+	Provider    *redhatcopv1alpha1.Provider
+	groupFilter cel.Program
+	userFilter  cel.Program
+}
+
+// isFiltered determines whether the groups or users of the provider are filtered
+func isFiltered(provider *redhatcopv1alpha1.Provider) bool {
+	return provider.GroupFilterExpression != "" || provider.UserFilterExpression != "" || provider.MinGroupSize != nil || provider.MaxGroupSize != nil
+}
+
+func (f *FilterSyncer) Validate() error {
+
+	validationErrors := []error{}
+
+	if err := f.GroupSyncer.Validate(); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	if f.Provider.GroupFilterExpression != "" {
+		if _, err := compileFilterExpression(groupFilterVariable, f.Provider.GroupFilterExpression); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid group filter expression: %s", err))
+		}
+	}
+
+	if f.Provider.UserFilterExpression != "" {
+		if _, err := compileFilterExpression(userFilterVariable, f.Provider.UserFilterExpression); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid user filter expression: %s", err))
+		}
+	}
+
+	if f.Provider.MinGroupSize != nil && f.Provider.MaxGroupSize != nil && *f.Provider.MinGroupSize > *f.Provider.MaxGroupSize {
+		validationErrors = append(validationErrors, fmt.Errorf("Minimum group size %d is greater than maximum group size %d", *f.Provider.MinGroupSize, *f.Provider.MaxGroupSize))
+	}
+
+	return utilerrors.NewAggregate(validationErrors)
+}
+
+func (f *FilterSyncer) Sync() ([]userv1.Group, error) {
+
+	groups, err := f.GroupSyncer.Sync()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Provider.GroupFilterExpression != "" {
+		if f.groupFilter, err = compileFilterExpression(groupFilterVariable, f.Provider.GroupFilterExpression); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.Provider.UserFilterExpression != "" {
+		if f.userFilter, err = compileFilterExpression(userFilterVariable, f.Provider.UserFilterExpression); err != nil {
+			return nil, err
+		}
+	}
+
+	filteredGroups := []userv1.Group{}
+
+	for _, group := range groups {
+
+		if f.groupFilter != nil {
+			matches, err := evaluateFilterExpression(f.groupFilter, groupFilterVariable, getFilterGroup(group))
+
+			if err != nil {
+				return nil, fmt.Errorf("Failed to evaluate group filter expression for group '%s': %s", group.Name, err)
+			}
+
+			if !matches {
+				continue
+			}
+		}
+
+		if f.userFilter != nil {
+			users := []string{}
+
+			for _, user := range group.Users {
+				matches, err := evaluateFilterExpression(f.userFilter, userFilterVariable, getFilterUser(group, user))
+
+				if err != nil {
+					return nil, fmt.Errorf("Failed to evaluate user filter expression for user '%s' of group '%s': %s", user, group.Name, err)
+				}
+
+				if matches {
+					users = append(users, user)
+				}
+			}
+
+			group.Users = users
+		}
+
+		// The size of the group is compared once its users are filtered
+		if !isGroupSizeAllowed(f.Provider, group) {
+			continue
+		}
+
+		filteredGroups = append(filteredGroups, group)
+	}
+
+	return filteredGroups, nil
+}
+
+// isGroupSizeAllowed determines whether the number of users of the group is within the minimum and maximum group sizes of the provider
+func isGroupSizeAllowed(provider *redhatcopv1alpha1.Provider, group userv1.Group) bool {
+
+	if provider.MinGroupSize != nil && len(group.Users) < *provider.MinGroupSize {
+		return false
+	}
+
+	if provider.MaxGroupSize != nil && len(group.Users) > *provider.MaxGroupSize {
+		return false
+	}
+
+	return true
+}
+
+// getFilterGroup returns the normalized representation of a group that filter expressions are evaluated against. The attributes of the
+// group are its annotations and labels
+func getFilterGroup(group userv1.Group) map[string]interface{} {
+
+	attributes := map[string]string{}
+
+	for key, value := range group.GetLabels() {
+		attributes[key] = value
+	}
+
+	for key, value := range group.GetAnnotations() {
+		attributes[key] = value
+	}
+
+	return map[string]interface{}{
+		"name":        group.Name,
+		"id":          group.GetAnnotations()[constants.SyncSourceUID],
+		"attributes":  attributes,
+		"memberCount": len(group.Users),
+	}
+}
+
+// getFilterUser returns the normalized representation of a user that filter expressions are evaluated against. Providers only
+// resolve the names of users, which are combined with the name of the group containing the user
+func getFilterUser(group userv1.Group, user string) map[string]interface{} {
+
+	return map[string]interface{}{
+		"name":  user,
+		"group": group.Name,
+	}
+}
+
+// compileFilterExpression compiles a CEL expression referencing a single variable that must evaluate to a boolean
+func compileFilterExpression(variable string, expression string) (cel.Program, error) {
+
+	env, err := cel.NewEnv(cel.Declarations(decls.NewVar(variable, decls.NewMapType(decls.String, decls.Dyn))))
+
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := env.Compile(expression)
+
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+
+	if resultType := ast.ResultType(); resultType.GetPrimitive() != decls.Bool.GetPrimitive() && resultType.GetDyn() == nil {
+		return nil, fmt.Errorf("Expression '%s' does not evaluate to a boolean", expression)
+	}
+
+	return env.Program(ast)
+}
+
+func evaluateFilterExpression(program cel.Program, variable string, value map[string]interface{}) (bool, error) {
+
+	result, _, err := program.Eval(map[string]interface{}{variable: value})
+
+	if err != nil {
+		return false, err
+	}
+
+	matches, ok := result.Value().(bool)
+
+	if !ok {
+		return false, fmt.Errorf("Expression did not evaluate to a boolean")
+	}
+
+	return matches, nil
+}
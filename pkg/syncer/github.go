@@ -3,7 +3,6 @@ package syncer
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -20,7 +19,6 @@ import (
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -59,21 +57,32 @@ const (
 )
 
 type GitHubSyncer struct {
-	Name              string
-	GroupSync         *redhatcopv1alpha1.GroupSync
-	Provider          *redhatcopv1alpha1.GitHubProvider
-	Client            *github.Client
-	V4Client          *githubv4.Client
-	Context           context.Context
-	ReconcilerBase    util.ReconcilerBase
-	CredentialsSecret *corev1.Secret
-	URL               *url.URL
-	CaCertificate     []byte
+	Name                    string
+	GroupSync               *redhatcopv1alpha1.GroupSync
+	Provider                *redhatcopv1alpha1.GitHubProvider
+	Client                  *github.Client
+	V4Client                *githubv4.Client
+	Context                 context.Context
+	ReconcilerBase          util.ReconcilerBase
+	CredentialsSecret       *corev1.Secret
+	CredentialsSource       *redhatcopv1alpha1.CredentialsSource
+	CredentialsKeys         map[string]string
+	MetadataMappings        []redhatcopv1alpha1.MetadataMapping
+	RateLimit               *redhatcopv1alpha1.RateLimit
+	URL                     *url.URL
+	CaCertificate           []byte
+	CaBundles               []*redhatcopv1alpha1.ConfigMapRef
+	ClientCertificateSecret *redhatcopv1alpha1.SecretRef
+	ClientCertificate       *tls.Certificate
+	teams                   *GroupPatterns
+	excludedGroups          *GroupPatterns
 }
 
 func (g *GitHubSyncer) Init() bool {
 
-	g.Context = context.Background()
+	if g.Context == nil {
+		g.Context = context.Background()
+	}
 	g.URL, _ = url.Parse(defaultBaseURL)
 
 	return false
@@ -83,8 +92,7 @@ func (g *GitHubSyncer) Validate() error {
 
 	validationErrors := []error{}
 
-	credentialsSecret := &corev1.Secret{}
-	err := g.ReconcilerBase.GetClient().Get(g.Context, types.NamespacedName{Name: g.Provider.CredentialsSecret.Name, Namespace: g.Provider.CredentialsSecret.Namespace}, credentialsSecret)
+	credentialsSecret, credentialsDescription, err := getCredentials(g.Context, g.ReconcilerBase.GetClient(), g.CredentialsSource, g.CredentialsKeys, g.Provider.CredentialsSecret)
 
 	if err != nil {
 		validationErrors = append(validationErrors, err)
@@ -95,7 +103,7 @@ func (g *GitHubSyncer) Validate() error {
 		_, integrationIdFound := credentialsSecret.Data[appId]
 
 		if !tokenSecretFound && !(privateKeyFound && integrationIdFound) {
-			validationErrors = append(validationErrors, fmt.Errorf("Could not find `token` or `privateKey` and `appId` key in secret '%s' in namespace '%s", g.Provider.CredentialsSecret.Name, g.Provider.CredentialsSecret.Namespace))
+			validationErrors = append(validationErrors, fmt.Errorf("Could not find `token` or `privateKey` and `appId` key in %s", credentialsDescription))
 		}
 
 		g.CredentialsSecret = credentialsSecret
@@ -129,6 +137,22 @@ func (g *GitHubSyncer) Validate() error {
 		g.CaCertificate = caResource[resourceCaKey]
 	}
 
+	caBundles, err := getCaBundles(g.Context, g.ReconcilerBase.GetClient(), g.CaBundles)
+
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	} else {
+		g.CaCertificate = appendCaBundles(g.CaCertificate, caBundles)
+	}
+
+	clientCertificate, err := getClientCertificate(g.Context, g.ReconcilerBase.GetClient(), g.ClientCertificateSecret)
+
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	} else {
+		g.ClientCertificate = clientCertificate
+	}
+
 	if g.Provider.URL != nil {
 		if (*g.Provider.URL)[len(*g.Provider.URL)-1] != '/' {
 			validationErrors = append(validationErrors, fmt.Errorf("GitHub URL Must end with a slash ('/')"))
@@ -141,6 +165,13 @@ func (g *GitHubSyncer) Validate() error {
 		}
 	}
 
+	var groupPatternErrors []error
+
+	g.teams, groupPatternErrors = compileGroupPatterns("teams", g.Provider.Teams)
+	validationErrors = append(validationErrors, groupPatternErrors...)
+	g.excludedGroups, groupPatternErrors = compileGroupPatterns("excludedGroups", g.Provider.ExcludedGroups)
+	validationErrors = append(validationErrors, groupPatternErrors...)
+
 	return utilerrors.NewAggregate(validationErrors)
 }
 
@@ -160,19 +191,28 @@ func (g *GitHubSyncer) Bind() error {
 	} else {
 		if len(g.CaCertificate) > 0 {
 
-			tlsConfig := &tls.Config{}
-			if tlsConfig.RootCAs == nil {
-				tlsConfig.RootCAs = x509.NewCertPool()
+			// Trust the provided certificates in addition to the system certificates
+			tlsConfig := &tls.Config{
+				RootCAs: getRootCAs(g.CaCertificate),
 			}
 
-			tlsConfig.RootCAs.AppendCertsFromPEM(g.CaCertificate)
-
 			transport = &http.Transport{
 				TLSClientConfig: tlsConfig,
 			}
 		}
 	}
 
+	// Present the client certificate to providers requiring mutual TLS
+	if g.ClientCertificate != nil {
+		if transport == nil {
+			transport = &http.Transport{
+				TLSClientConfig: &tls.Config{},
+			}
+		}
+
+		transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, *g.ClientCertificate)
+	}
+
 	config := githubapp.Config{
 		V3APIURL: *g.Provider.URL,
 		V4APIURL: *g.Provider.V4URL,
@@ -183,7 +223,9 @@ func (g *GitHubSyncer) Bind() error {
 		githubapp.WithClientCaching(false, func() httpcache.Cache { return httpcache.NewMemoryCache() }),
 	}
 	if transport != nil {
-		opts = append(opts, githubapp.WithTransport(transport))
+		opts = append(opts, githubapp.WithTransport(newTracedTransport(newRateLimitedTransport(transport, g.RateLimit))))
+	} else {
+		opts = append(opts, githubapp.WithTransport(newTracedTransport(newRateLimitedTransport(nil, g.RateLimit))))
 	}
 
 	if privateKeyFound && appIdFound {
@@ -236,7 +278,7 @@ func (g *GitHubSyncer) Bind() error {
 			return err
 		}
 	} else {
-		return fmt.Errorf("Could not locate credentials in secret '%s' in namespace '%s'", g.Provider.CredentialsSecret.Name, g.Provider.CredentialsSecret.Namespace)
+		return fmt.Errorf("Could not locate credentials for provider '%s'", g.Name)
 	}
 
 	if g.URL != nil {
@@ -255,7 +297,7 @@ func (g *GitHubSyncer) Sync() ([]userv1.Group, error) {
 	organization, _, err := g.Client.Organizations.Get(g.Context, g.Provider.Organization)
 
 	if err != nil {
-		gitHubLogger.Error(err, "Failed to get Organization", "Organization", g.Provider.Organization, "Provider", g.Name)
+		syncLogger(g.Context, gitHubLogger).Error(err, "Failed to get Organization", "Organization", g.Provider.Organization, "Provider", g.Name)
 		return nil, err
 	}
 
@@ -263,7 +305,7 @@ func (g *GitHubSyncer) Sync() ([]userv1.Group, error) {
 	teams, err := g.getOrganizationTeams()
 
 	if err != nil {
-		gitHubLogger.Error(err, "Failed to get Teams", "Provider", g.Name)
+		syncLogger(g.Context, gitHubLogger).Error(err, "Failed to get Teams", "Provider", g.Name)
 		return nil, err
 	}
 
@@ -276,7 +318,7 @@ func (g *GitHubSyncer) Sync() ([]userv1.Group, error) {
 	}
 
 	for _, team := range teams {
-		if !isGroupAllowed(*team.Name, g.Provider.Teams) {
+		if !isGroupSelected(*team.Name, g.teams, g.excludedGroups) {
 			continue
 		}
 
@@ -297,10 +339,22 @@ func (g *GitHubSyncer) Sync() ([]userv1.Group, error) {
 		ocpGroup.GetAnnotations()[constants.SyncSourceHost] = g.URL.Host
 		ocpGroup.GetAnnotations()[constants.SyncSourceUID] = strconv.FormatInt(*team.ID, 10)
 
+		if parentTeamName := team.GetParent().GetName(); parentTeamName != "" {
+			ocpGroup.GetAnnotations()[constants.HierarchyParent] = parentTeamName
+		}
+
+		applyMetadataMappings(g.Context, &ocpGroup, g.MetadataMappings, map[string]string{
+			MetadataDescription: team.GetDescription(),
+			MetadataURL:         team.GetHTMLURL(),
+			"slug":              team.GetSlug(),
+			"privacy":           team.GetPrivacy(),
+			"parent":            team.GetParent().GetSlug(),
+		})
+
 		teamMembers, err := g.listTeamMembers(team.ID, organization.ID)
 
 		if err != nil {
-			gitHubLogger.Error(err, "Failed to get Team Member for Team", "Team", team.Name, "Provider", g.Name)
+			syncLogger(g.Context, gitHubLogger).Error(err, "Failed to get Team Member for Team", "Team", team.Name, "Provider", g.Name)
 			return nil, err
 		}
 
@@ -331,20 +385,31 @@ func (g *GitHubSyncer) getScimIdentity() (map[string]string, error) {
 	}
 
 	userMap := make(map[string]string)
-	for { // while
-		err := g.V4Client.Query(g.Context, &scimQuery, variables)
-		if err != nil {
-			return nil, err
+
+	err := forEachCursorPage(func(cursor string) (string, error) {
+
+		if cursor != "" {
+			variables[after] = githubv4.String(cursor)
+		}
+
+		if err := retryTransientErrors(func() error { return g.V4Client.Query(g.Context, &scimQuery, variables) }); err != nil {
+			return "", err
 		}
 
 		// map from loginId -> SCIM/SAML Id
 		for _, v := range scimQuery.Organization.SamlIdentityProvider.ExternalIdentities.Edges {
 			userMap[string(v.Node.User.Login)] = string(v.Node.SamlIdentity.Username)
 		}
+
 		if !scimQuery.Organization.SamlIdentityProvider.ExternalIdentities.PageInfo.HasNextPage {
-			break
+			return "", nil
 		}
-		variables[after] = scimQuery.Organization.SamlIdentityProvider.ExternalIdentities.PageInfo.EndCursor
+
+		return string(scimQuery.Organization.SamlIdentityProvider.ExternalIdentities.PageInfo.EndCursor), nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return userMap, nil
@@ -354,22 +419,30 @@ func (g *GitHubSyncer) getOrganizationTeams() ([]*github.Team, error) {
 	opts := &github.ListOptions{PerPage: pageSize}
 	var allTeams []*github.Team
 
-	for {
-		teams, r, err := g.Client.Teams.ListTeams(g.Context, g.Provider.Organization, opts)
+	err := forEachPage(0, func(page int) (int, error) {
+
+		opts.Page = page
+
+		var teams []*github.Team
+		var response *github.Response
+
+		err := retryTransientErrors(func() error {
+			var err error
+			teams, response, err = g.Client.Teams.ListTeams(g.Context, g.Provider.Organization, opts)
+			return withGitHubResponseStatus(response, err)
+		})
 
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
 
-		for _, t := range teams {
-			allTeams = append(allTeams, t)
-		}
+		allTeams = append(allTeams, teams...)
 
-		if r.NextPage == 0 {
-			break
-		}
+		return response.NextPage, nil
+	})
 
-		opts.Page = r.NextPage
+	if err != nil {
+		return nil, err
 	}
 
 	return allTeams, nil
@@ -383,22 +456,46 @@ func (g *GitHubSyncer) listTeamMembers(teamID *int64, organizationID *int64) ([]
 		ListOptions: github.ListOptions{PerPage: pageSize},
 	}
 
-	for {
-		users, resp, err := g.Client.Teams.ListTeamMembersByID(g.Context, *organizationID, *teamID, &opts)
+	err := forEachPage(0, func(page int) (int, error) {
+
+		opts.Page = page
+
+		var users []*github.User
+		var response *github.Response
+
+		err := retryTransientErrors(func() error {
+			var err error
+			users, response, err = g.Client.Teams.ListTeamMembersByID(g.Context, *organizationID, *teamID, &opts)
+			return withGitHubResponseStatus(response, err)
+		})
+
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
+
 		teamUsers = append(teamUsers, users...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
+
+		return response.NextPage, nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return teamUsers, nil
 
 }
 
+// withGitHubResponseStatus records the status code of the response of a failed request to GitHub
+func withGitHubResponseStatus(response *github.Response, err error) error {
+
+	if response == nil {
+		return err
+	}
+
+	return withResponseStatus(response.Response, err)
+}
+
 func (g *GitHubSyncer) GetProviderName() string {
 	return g.Name
 }
@@ -3,7 +3,6 @@ package syncer
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -19,7 +18,6 @@ import (
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
@@ -28,20 +26,31 @@ var (
 )
 
 type GitLabSyncer struct {
-	Name              string
-	GroupSync         *redhatcopv1alpha1.GroupSync
-	Provider          *redhatcopv1alpha1.GitLabProvider
-	Client            *gitlab.Client
-	Context           context.Context
-	ReconcilerBase    util.ReconcilerBase
-	CredentialsSecret *corev1.Secret
-	URL               *url.URL
-	CaCertificate     []byte
+	Name                    string
+	GroupSync               *redhatcopv1alpha1.GroupSync
+	Provider                *redhatcopv1alpha1.GitLabProvider
+	Client                  *gitlab.Client
+	Context                 context.Context
+	ReconcilerBase          util.ReconcilerBase
+	CredentialsSecret       *corev1.Secret
+	CredentialsSource       *redhatcopv1alpha1.CredentialsSource
+	CredentialsKeys         map[string]string
+	MetadataMappings        []redhatcopv1alpha1.MetadataMapping
+	RateLimit               *redhatcopv1alpha1.RateLimit
+	URL                     *url.URL
+	CaCertificate           []byte
+	CaBundles               []*redhatcopv1alpha1.ConfigMapRef
+	ClientCertificateSecret *redhatcopv1alpha1.SecretRef
+	ClientCertificate       *tls.Certificate
+	groups                  *GroupPatterns
+	excludedGroups          *GroupPatterns
 }
 
 func (g *GitLabSyncer) Init() bool {
 
-	g.Context = context.Background()
+	if g.Context == nil {
+		g.Context = context.Background()
+	}
 
 	return false
 }
@@ -50,8 +59,7 @@ func (g *GitLabSyncer) Validate() error {
 
 	validationErrors := []error{}
 
-	credentialsSecret := &corev1.Secret{}
-	err := g.ReconcilerBase.GetClient().Get(context.TODO(), types.NamespacedName{Name: g.Provider.CredentialsSecret.Name, Namespace: g.Provider.CredentialsSecret.Namespace}, credentialsSecret)
+	credentialsSecret, credentialsDescription, err := getCredentials(context.TODO(), g.ReconcilerBase.GetClient(), g.CredentialsSource, g.CredentialsKeys, g.Provider.CredentialsSecret)
 
 	if err != nil {
 		validationErrors = append(validationErrors, err)
@@ -63,7 +71,7 @@ func (g *GitLabSyncer) Validate() error {
 		_, tokenSecretFound := credentialsSecret.Data[secretTokenKey]
 
 		if !(usernameSecretFound && passwordSecretFound) && !tokenSecretFound {
-			validationErrors = append(validationErrors, fmt.Errorf("Could not find 'username' and `password` or `token` key in secret '%s' in namespace '%s", g.Provider.CredentialsSecret.Name, g.Provider.CredentialsSecret.Namespace))
+			validationErrors = append(validationErrors, fmt.Errorf("Could not find 'username' and `password` or `token` key in %s", credentialsDescription))
 		}
 
 		g.CredentialsSecret = credentialsSecret
@@ -94,6 +102,22 @@ func (g *GitLabSyncer) Validate() error {
 		g.CaCertificate = caResource[resourceCaKey]
 	}
 
+	caBundles, err := getCaBundles(g.Context, g.ReconcilerBase.GetClient(), g.CaBundles)
+
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	} else {
+		g.CaCertificate = appendCaBundles(g.CaCertificate, caBundles)
+	}
+
+	clientCertificate, err := getClientCertificate(g.Context, g.ReconcilerBase.GetClient(), g.ClientCertificateSecret)
+
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	} else {
+		g.ClientCertificate = clientCertificate
+	}
+
 	if g.Provider.URL != nil {
 
 		g.URL, err = url.Parse(*g.Provider.URL)
@@ -104,6 +128,13 @@ func (g *GitLabSyncer) Validate() error {
 
 	}
 
+	var groupPatternErrors []error
+
+	g.groups, groupPatternErrors = compileGroupPatterns("groups", g.Provider.Groups)
+	validationErrors = append(validationErrors, groupPatternErrors...)
+	g.excludedGroups, groupPatternErrors = compileGroupPatterns("excludedGroups", g.Provider.ExcludedGroups)
+	validationErrors = append(validationErrors, groupPatternErrors...)
+
 	return utilerrors.NewAggregate(validationErrors)
 }
 
@@ -122,27 +153,32 @@ func (g *GitLabSyncer) Bind() error {
 		clientFns = append(clientFns, gitlab.WithBaseURL(g.URL.String()))
 	}
 
-	if g.Provider.Insecure == true || len(g.CaCertificate) > 0 {
+	transport := cleanhttp.DefaultPooledTransport()
 
-		transport := cleanhttp.DefaultPooledTransport()
-
-		if g.Provider.Insecure == true {
-			transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-		} else if g.CaCertificate != nil {
-			tlsConfig := &tls.Config{}
-			if tlsConfig.RootCAs == nil {
-				tlsConfig.RootCAs = x509.NewCertPool()
-			}
+	if g.Provider.Insecure == true {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	} else if g.CaCertificate != nil {
+		// Trust the provided certificates in addition to the system certificates
+		tlsConfig := &tls.Config{
+			RootCAs: getRootCAs(g.CaCertificate),
+		}
 
-			tlsConfig.RootCAs.AppendCertsFromPEM(g.CaCertificate)
+		transport.TLSClientConfig = tlsConfig
 
-			transport.TLSClientConfig = tlsConfig
+	}
 
+	// Present the client certificate to providers requiring mutual TLS
+	if g.ClientCertificate != nil {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
 		}
 
-		clientFns = append(clientFns, gitlab.WithHTTPClient(&http.Client{Transport: transport}))
+		transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, *g.ClientCertificate)
 	}
 
+	// GitLab requests are not context aware so the context of the provider is attached to each request
+	clientFns = append(clientFns, gitlab.WithHTTPClient(&http.Client{Transport: &contextTransport{transport: newTracedTransport(newRateLimitedTransport(transport, g.RateLimit)), context: func() context.Context { return g.Context }}}))
+
 	if tokenSecretFound {
 		gitlabClient, err = gitlab.NewOAuthClient(
 			string(tokenSecret),
@@ -159,7 +195,7 @@ func (g *GitLabSyncer) Bind() error {
 			return err
 		}
 	} else {
-		return fmt.Errorf("Could not locate credentials in secret '%s' in namespace '%s'", g.Provider.CredentialsSecret.Name, g.Provider.CredentialsSecret.Namespace)
+		return fmt.Errorf("Could not locate credentials for provider '%s'", g.Name)
 	}
 
 	g.Client = gitlabClient
@@ -178,9 +214,16 @@ func (g *GitLabSyncer) Sync() ([]userv1.Group, error) {
 		return nil, err
 	}
 
+	// Names of groups by ID to resolve the parent of subgroups
+	groupNames := map[int]string{}
+
+	for _, group := range groups {
+		groupNames[group.ID] = group.Name
+	}
+
 	for _, group := range groups {
 
-		if !isGroupAllowed(group.Name, g.Provider.Groups) {
+		if !isGroupSelected(group.Name, g.groups, g.excludedGroups) {
 			continue
 		}
 
@@ -207,6 +250,18 @@ func (g *GitLabSyncer) Sync() ([]userv1.Group, error) {
 		ocpGroup.GetAnnotations()[constants.SyncSourceHost] = g.URL.Host
 		ocpGroup.GetAnnotations()[constants.SyncSourceUID] = strconv.Itoa(group.ID)
 
+		if parentGroupName, found := groupNames[group.ParentID]; found && group.ParentID != 0 {
+			ocpGroup.GetAnnotations()[constants.HierarchyParent] = parentGroupName
+		}
+
+		applyMetadataMappings(g.Context, &ocpGroup, g.MetadataMappings, map[string]string{
+			MetadataDescription: group.Description,
+			MetadataPath:        group.Path,
+			MetadataURL:         group.WebURL,
+			"fullPath":          group.FullPath,
+			"visibility":        string(group.Visibility),
+		})
+
 		for _, groupMember := range groupMembers {
 			ocpGroup.Users = append(ocpGroup.Users, groupMember.Username)
 		}
@@ -226,28 +281,33 @@ func (g *GitLabSyncer) getGroups() ([]*gitlab.Group, error) {
 	opt := &gitlab.ListGroupsOptions{
 		ListOptions: gitlab.ListOptions{
 			PerPage: 50,
-			Page:    1,
 		},
 	}
 
-	for {
+	err := forEachPage(1, func(page int) (int, error) {
 
-		groups, resp, err := g.Client.Groups.ListGroups(opt)
+		opt.Page = page
 
-		if err != nil {
-			return nil, err
-		}
+		var groups []*gitlab.Group
+		var response *gitlab.Response
 
-		for _, t := range groups {
-			allGroups = append(allGroups, t)
-		}
+		err := retryTransientErrors(func() error {
+			var err error
+			groups, response, err = g.Client.Groups.ListGroups(opt)
+			return withGitLabResponseStatus(response, err)
+		})
 
-		if resp.CurrentPage >= resp.TotalPages {
-			break
+		if err != nil {
+			return 0, err
 		}
 
-		opt.Page = resp.NextPage
+		allGroups = append(allGroups, groups...)
+
+		return response.NextPage, nil
+	})
 
+	if err != nil {
+		return nil, err
 	}
 
 	return allGroups, nil
@@ -261,30 +321,49 @@ func (g *GitLabSyncer) getGroupMembers(groupId int) ([]*gitlab.GroupMember, erro
 	opt := &gitlab.ListGroupMembersOptions{
 		ListOptions: gitlab.ListOptions{
 			PerPage: 50,
-			Page:    1,
 		},
 	}
 
-	for {
-		members, resp, err := g.Client.Groups.ListAllGroupMembers(groupId, opt)
+	err := forEachPage(1, func(page int) (int, error) {
+
+		opt.Page = page
+
+		var members []*gitlab.GroupMember
+		var response *gitlab.Response
+
+		err := retryTransientErrors(func() error {
+			var err error
+			members, response, err = g.Client.Groups.ListAllGroupMembers(groupId, opt)
+			return withGitLabResponseStatus(response, err)
+		})
 
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
 
-		for _, u := range members {
-			groupMembers = append(groupMembers, u)
-		}
+		groupMembers = append(groupMembers, members...)
 
-		if resp.CurrentPage >= resp.TotalPages {
-			break
-		}
+		return response.NextPage, nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return groupMembers, nil
 
 }
 
+// withGitLabResponseStatus records the status code of the response of a failed request to GitLab
+func withGitLabResponseStatus(response *gitlab.Response, err error) error {
+
+	if response == nil {
+		return err
+	}
+
+	return withResponseStatus(response.Response, err)
+}
+
 func (g *GitLabSyncer) GetProviderName() string {
 	return g.Name
 }
@@ -0,0 +1,250 @@
+package syncer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	groupNameLogger = logf.Log.WithName("syncer_group_name")
+)
+
+// GroupNameSyncer decorates a GroupSyncer by transforming the names of the synchronized groups
+type GroupNameSyncer struct {
+	GroupSyncer
+	Provider       *redhatcopv1alpha1.Provider
+	ReconcilerBase util.ReconcilerBase
+	Context        context.Context
+	rewrites       []*regexp.Regexp
+	mappings       map[string]string
+	template       *template.Template
+}
+
+// GroupNameTemplateData is the data available to the group name template of a provider
+type GroupNameTemplateData struct {
+	Provider string
+	Group    GroupNameTemplateGroup
+}
+
+// GroupNameTemplateGroup represents a group returned by a provider within a group name template
+type GroupNameTemplateGroup struct {
+	Name       string
+	ID         string
+	Attributes map[string]string
+}
+
+// isGroupNameTransformed determines whether the names of the groups of the provider are transformed
+func isGroupNameTransformed(provider *redhatcopv1alpha1.Provider) bool {
+	return provider.GroupNamePrefix != "" || provider.GroupNameSuffix != "" || len(provider.GroupNameRewrites) > 0 || provider.LowercaseGroupNames ||
+		len(provider.GroupNameMappings) > 0 || provider.GroupNameMappingsRef != nil || provider.GroupNameTemplate != ""
+}
+
+func (g *GroupNameSyncer) Validate() error {
+
+	validationErrors := []error{}
+
+	if err := g.GroupSyncer.Validate(); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	if strings.ContainsAny(g.Provider.GroupNamePrefix, "/%") {
+		validationErrors = append(validationErrors, fmt.Errorf("Invalid group name prefix: '%s'", g.Provider.GroupNamePrefix))
+	}
+
+	if strings.ContainsAny(g.Provider.GroupNameSuffix, "/%") {
+		validationErrors = append(validationErrors, fmt.Errorf("Invalid group name suffix: '%s'", g.Provider.GroupNameSuffix))
+	}
+
+	for _, groupNameRewrite := range g.Provider.GroupNameRewrites {
+		if _, err := regexp.Compile(groupNameRewrite.Pattern); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid group name rewrite pattern: '%s'", groupNameRewrite.Pattern))
+		}
+	}
+
+	if g.Provider.GroupNameTemplate != "" {
+		if _, err := parseGroupNameTemplate(g.Provider.GroupNameTemplate); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid group name template: %s", err))
+		}
+	}
+
+	g.mappings = map[string]string{}
+
+	if g.Provider.GroupNameMappingsRef != nil {
+		mappingsResource, err := getObjectRefData(g.Context, g.ReconcilerBase.GetClient(), g.Provider.GroupNameMappingsRef)
+
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+
+		for sourceName, groupName := range mappingsResource {
+			g.mappings[sourceName] = strings.TrimSpace(string(groupName))
+		}
+	}
+
+	for sourceName, groupName := range g.Provider.GroupNameMappings {
+		g.mappings[sourceName] = groupName
+	}
+
+	for sourceName, groupName := range g.mappings {
+		if groupName == "" || strings.ContainsAny(groupName, "/%") {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid group name mapping of '%s': '%s'", sourceName, groupName))
+		}
+	}
+
+	return utilerrors.NewAggregate(validationErrors)
+}
+
+func (g *GroupNameSyncer) Sync() ([]userv1.Group, error) {
+
+	groups, err := g.GroupSyncer.Sync()
+
+	if err != nil {
+		return nil, err
+	}
+
+	g.rewrites = []*regexp.Regexp{}
+	for _, groupNameRewrite := range g.Provider.GroupNameRewrites {
+		g.rewrites = append(g.rewrites, regexp.MustCompile(groupNameRewrite.Pattern))
+	}
+
+	g.template = nil
+	if g.Provider.GroupNameTemplate != "" {
+		if g.template, err = parseGroupNameTemplate(g.Provider.GroupNameTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	// Names of the groups of the provider are transformed before the hierarchy annotations referencing them
+	groupNames := map[string]string{}
+
+	for i := range groups {
+		if groupNames[groups[i].Name], err = g.getMappedGroupName(groups[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	transformedGroups := []userv1.Group{}
+
+	for i := range groups {
+		groupName := groupNames[groups[i].Name]
+
+		if groupName == "" {
+			syncLogger(g.Context, groupNameLogger).Info("Warning: Skipping Group with Empty Transformed Name", "Provider", g.GetProviderName(), "Group Name", groups[i].Name)
+			continue
+		}
+
+		groups[i].Name = groupName
+
+		// Groups referenced by the hierarchy annotations are renamed consistently
+		for _, annotation := range []string{constants.HierarchyChildren, constants.HierarchyParent, constants.HierarchyParents} {
+			if value, found := groups[i].Annotations[annotation]; found && value != "" {
+				referencedGroupNames := strings.Split(value, ",")
+				for j := range referencedGroupNames {
+					if transformedGroupName, found := groupNames[referencedGroupNames[j]]; found {
+						referencedGroupNames[j] = transformedGroupName
+					} else {
+						referencedGroup := userv1.Group{}
+						referencedGroup.Name = referencedGroupNames[j]
+
+						if referencedGroupNames[j], err = g.getMappedGroupName(referencedGroup); err != nil {
+							return nil, err
+						}
+					}
+				}
+				groups[i].Annotations[annotation] = strings.Join(referencedGroupNames, ",")
+			}
+		}
+
+		transformedGroups = append(transformedGroups, groups[i])
+	}
+
+	return transformedGroups, nil
+}
+
+// getMappedGroupName returns the group name mapped from the name or the identifier of the group in the provider. Mapped group names
+// are not transformed further
+func (g *GroupNameSyncer) getMappedGroupName(group userv1.Group) (string, error) {
+
+	if mappedGroupName, found := g.mappings[group.Name]; found {
+		return mappedGroupName, nil
+	}
+
+	uid := group.GetAnnotations()[constants.SyncSourceUID]
+
+	if mappedGroupName, found := g.mappings[uid]; found && uid != "" {
+		return mappedGroupName, nil
+	}
+
+	groupName := group.Name
+
+	if g.template != nil {
+		var err error
+		if groupName, err = g.executeGroupNameTemplate(group); err != nil {
+			return "", err
+		}
+	}
+
+	return g.getGroupName(groupName), nil
+}
+
+// executeGroupNameTemplate renders the name of the group from the group name template. The attributes of the group are its
+// annotations and labels
+func (g *GroupNameSyncer) executeGroupNameTemplate(group userv1.Group) (string, error) {
+
+	attributes := map[string]string{}
+
+	for key, value := range group.GetLabels() {
+		attributes[key] = value
+	}
+
+	for key, value := range group.GetAnnotations() {
+		attributes[key] = value
+	}
+
+	data := GroupNameTemplateData{
+		Provider: g.Provider.Name,
+		Group: GroupNameTemplateGroup{
+			Name:       group.Name,
+			ID:         group.GetAnnotations()[constants.SyncSourceUID],
+			Attributes: attributes,
+		},
+	}
+
+	var groupName bytes.Buffer
+
+	if err := g.template.Execute(&groupName, data); err != nil {
+		return "", fmt.Errorf("Failed to render group name template for group '%s': %s", group.Name, err)
+	}
+
+	return strings.TrimSpace(groupName.String()), nil
+}
+
+func parseGroupNameTemplate(groupNameTemplate string) (*template.Template, error) {
+	return template.New("groupName").Funcs(sprig.TxtFuncMap()).Option("missingkey=zero").Parse(groupNameTemplate)
+}
+
+// getGroupName applies the group name rewrites, followed by the conversion to lowercase and the prefix and suffix
+func (g *GroupNameSyncer) getGroupName(groupName string) string {
+
+	for i, rewrite := range g.rewrites {
+		groupName = rewrite.ReplaceAllString(groupName, g.Provider.GroupNameRewrites[i].Replacement)
+	}
+
+	if g.Provider.LowercaseGroupNames {
+		groupName = strings.ToLower(groupName)
+	}
+
+	return g.Provider.GroupNamePrefix + groupName + g.Provider.GroupNameSuffix
+}
@@ -0,0 +1,115 @@
+package syncer
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// groupPatternRegexPrefix marks the patterns of groups that are only matched as regular expressions
+const groupPatternRegexPrefix = "regex:"
+
+// GroupPatterns matches the names of groups against patterns compiled once, such as when a syncer is validated, rather than for each
+// group of each synchronization. Patterns are either the name of a group, a glob pattern such as ocp-* or a regular expression that
+// must match the entire name of the group, such as ^team-.*-admins$. Patterns prefixed with regex: are only matched as regular
+// expressions
+type GroupPatterns struct {
+	patterns []groupPattern
+}
+
+type groupPattern struct {
+	name  string
+	glob  bool
+	regex *regexp.Regexp
+}
+
+// NewGroupPatterns compiles the patterns of groups. Invalid patterns are reported by the validation of the GroupSync and are only
+// matched using the forms they are valid in
+func NewGroupPatterns(patterns []string) *GroupPatterns {
+	groupPatterns, _ := compileGroupPatterns("", patterns)
+	return groupPatterns
+}
+
+// compileGroupPatterns compiles the patterns of groups of the field, reporting patterns that are neither valid glob patterns nor valid
+// regular expressions
+func compileGroupPatterns(field string, patterns []string) (*GroupPatterns, []error) {
+
+	groupPatterns := &GroupPatterns{}
+	validationErrors := []error{}
+
+	for _, pattern := range patterns {
+
+		if strings.HasPrefix(pattern, groupPatternRegexPrefix) {
+
+			regex, err := compileGroupPatternRegex(strings.TrimPrefix(pattern, groupPatternRegexPrefix))
+
+			if err != nil {
+				validationErrors = append(validationErrors, fmt.Errorf("Invalid regular expression '%s' in %s: %v", strings.TrimPrefix(pattern, groupPatternRegexPrefix), field, err))
+				continue
+			}
+
+			groupPatterns.patterns = append(groupPatterns.patterns, groupPattern{regex: regex})
+			continue
+		}
+
+		_, globErr := path.Match(pattern, "")
+		regex, regexErr := compileGroupPatternRegex(pattern)
+
+		if globErr != nil && regexErr != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid pattern '%s' in %s, which is neither a glob pattern nor a regular expression: %v", pattern, field, regexErr))
+		}
+
+		groupPatterns.patterns = append(groupPatterns.patterns, groupPattern{name: pattern, glob: globErr == nil, regex: regex})
+	}
+
+	return groupPatterns, validationErrors
+}
+
+// validateGroupPatterns verifies that each of the patterns is either a valid glob pattern or a valid regular expression
+func validateGroupPatterns(field string, patterns []string) []error {
+	_, validationErrors := compileGroupPatterns(field, patterns)
+	return validationErrors
+}
+
+// compileGroupPatternRegex returns the regular expression of a pattern anchored to match the entire name of groups
+func compileGroupPatternRegex(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(fmt.Sprintf("^(?:%s)$", pattern))
+}
+
+// Matches determines whether the name of the group matches any of the patterns
+func (g *GroupPatterns) Matches(groupName string) bool {
+
+	if g == nil {
+		return false
+	}
+
+	for _, pattern := range g.patterns {
+
+		if pattern.name != "" && pattern.name == groupName {
+			return true
+		}
+
+		if pattern.glob {
+			if matched, _ := path.Match(pattern.name, groupName); matched {
+				return true
+			}
+		}
+
+		if pattern.regex != nil && pattern.regex.MatchString(groupName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allows determines whether the name of the group matches any of the patterns. All groups are allowed when no patterns are specified
+func (g *GroupPatterns) Allows(groupName string) bool {
+
+	if g == nil || len(g.patterns) == 0 {
+		return true
+	}
+
+	return g.Matches(groupName)
+}
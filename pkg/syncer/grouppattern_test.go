@@ -0,0 +1,66 @@
+package syncer
+
+import (
+	"testing"
+)
+
+func TestIsGroupMatched(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		groupName string
+		patterns  []string
+		expected  bool
+	}{
+		{name: "exact name", groupName: "team.a", patterns: []string{"team.a"}, expected: true},
+		{name: "exact name with regular expression characters", groupName: "a+b", patterns: []string{"a+b"}, expected: true},
+		{name: "exact name that is not a valid regular expression", groupName: "team(", patterns: []string{"team("}, expected: true},
+		{name: "glob pattern", groupName: "ocp-admins", patterns: []string{"ocp-*"}, expected: true},
+		{name: "glob pattern not matched", groupName: "dev-admins", patterns: []string{"ocp-*"}, expected: false},
+		{name: "unprefixed regular expression", groupName: "team-a-admins", patterns: []string{"^team-.*-admins$"}, expected: true},
+		{name: "unprefixed regular expression matches the entire name", groupName: "team-a-admins-old", patterns: []string{"team-.*-admins"}, expected: false},
+		{name: "unprefixed regular expression alternatives", groupName: "ops", patterns: []string{"admins|ops"}, expected: true},
+		{name: "prefixed regular expression is not a glob pattern", groupName: "ocp-admins", patterns: []string{"regex:ocp-*"}, expected: false},
+		{name: "regular expression", groupName: "team-a-admins", patterns: []string{"regex:team-.*-admins"}, expected: true},
+		{name: "regular expression matches the entire name", groupName: "team-a-admins-old", patterns: []string{"regex:team-.*-admins"}, expected: false},
+		{name: "regular expression alternatives are anchored", groupName: "xadmins", patterns: []string{"regex:admins|ops"}, expected: false},
+		{name: "invalid regular expression", groupName: "team", patterns: []string{"regex:team("}, expected: false},
+		{name: "any of the patterns", groupName: "ops", patterns: []string{"admins", "regex:o.s"}, expected: true},
+		{name: "no patterns", groupName: "ops", patterns: []string{}, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if matched := NewGroupPatterns(test.patterns).Matches(test.groupName); matched != test.expected {
+				t.Errorf("expected matching '%s' against %v to be %t", test.groupName, test.patterns, test.expected)
+			}
+		})
+	}
+}
+
+func TestValidateGroupPatterns(t *testing.T) {
+
+	if errs := validateGroupPatterns("groups", []string{"admins", "ocp-*", "^team-.*$", "team(", "regex:team-.*"}); len(errs) != 0 {
+		t.Errorf("expected valid patterns, found %v", errs)
+	}
+
+	if errs := validateGroupPatterns("groups", []string{"ocp-[", "regex:team("}); len(errs) != 2 {
+		t.Errorf("expected 2 invalid patterns, found %v", errs)
+	}
+}
+
+func TestGroupPatternsAllows(t *testing.T) {
+
+	if !NewGroupPatterns(nil).Allows("admins") {
+		t.Errorf("expected all groups to be allowed without patterns")
+	}
+
+	var groupPatterns *GroupPatterns
+	if !groupPatterns.Allows("admins") || groupPatterns.Matches("admins") {
+		t.Errorf("expected patterns that were not compiled to allow all groups and match none")
+	}
+
+	if NewGroupPatterns([]string{"ocp-*"}).Allows("admins") {
+		t.Errorf("expected group not matching the patterns not to be allowed")
+	}
+}
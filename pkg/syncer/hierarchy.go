@@ -0,0 +1,89 @@
+package syncer
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+)
+
+// HierarchySyncer decorates a GroupSyncer by recording the hierarchy of the synchronized groups exposed by the provider. The parent and
+// child groups of each group are recorded as annotations and the depth of the group in the hierarchy as a label
+type HierarchySyncer struct {
+	GroupSyncer
+}
+
+func (h *HierarchySyncer) Sync() ([]userv1.Group, error) {
+
+	groups, err := h.GroupSyncer.Sync()
+
+	if err != nil {
+		return nil, err
+	}
+
+	parentGroups := map[string][]string{}
+	childGroups := map[string][]string{}
+
+	for i := range groups {
+		parentGroups[groups[i].Name] = getParentGroups(&groups[i])
+
+		for _, parentGroup := range parentGroups[groups[i].Name] {
+			childGroups[parentGroup] = append(childGroups[parentGroup], groups[i].Name)
+		}
+	}
+
+	for i := range groups {
+
+		if groups[i].Annotations == nil {
+			groups[i].Annotations = map[string]string{}
+		}
+
+		if groups[i].Labels == nil {
+			groups[i].Labels = map[string]string{}
+		}
+
+		if parents := parentGroups[groups[i].Name]; len(parents) > 0 {
+			groups[i].Annotations[constants.ParentGroups] = strings.Join(parents, ",")
+		}
+
+		if children := childGroups[groups[i].Name]; len(children) > 0 {
+			sort.Strings(children)
+			groups[i].Annotations[constants.ChildGroups] = strings.Join(children, ",")
+		}
+
+		groups[i].Labels[constants.HierarchyDepth] = strconv.Itoa(getHierarchyDepth(groups[i].Name, parentGroups))
+	}
+
+	return groups, nil
+}
+
+// getParentGroups returns the parent groups of the group recorded by the provider
+func getParentGroups(group *userv1.Group) []string {
+
+	if parentGroup := group.Annotations[constants.HierarchyParent]; parentGroup != "" {
+		return []string{parentGroup}
+	}
+
+	if parentGroups := group.Annotations[constants.HierarchyParents]; parentGroups != "" {
+		return strings.Split(parentGroups, ",")
+	}
+
+	return []string{}
+}
+
+// getHierarchyDepth returns the number of ancestors of the group following the first parent of each group, where root groups have a depth
+// of 0. A parent group not synchronized by the provider ends the hierarchy
+func getHierarchyDepth(groupName string, parentGroups map[string][]string) int {
+
+	depth := 0
+	visited := map[string]bool{groupName: true}
+
+	for parents := parentGroups[groupName]; len(parents) > 0 && !visited[parents[0]]; parents = parentGroups[parents[0]] {
+		visited[parents[0]] = true
+		depth++
+	}
+
+	return depth
+}
@@ -4,58 +4,127 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"crypto/x509"
 
 	"github.com/Nerzal/gocloak/v5"
+	"github.com/go-resty/resty/v2"
 	userv1 "github.com/openshift/api/user/v1"
 	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
 	"github.com/redhat-cop/group-sync-operator/pkg/constants"
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
-	masterRealm = "master"
+	masterRealm                 = "master"
+	keycloakUsernameAttribute   = "username"
+	keycloakEmailAttribute      = "email"
+	keycloakBriefRepresentation = "briefRepresentation"
+	keycloakGroupPathSeparator  = "/"
+	defaultGroupPathSeparator   = "-"
+	keycloakAdminClientID       = "admin-cli"
+	serviceAccountUserPrefix    = "service-account-"
+	// The Keycloak client sends requests to the context path of Keycloak 16 and earlier, which cannot be configured
+	keycloakClientContextPath = "/auth"
 )
 
 var (
 	keycloakLogger = logf.Log.WithName("syncer_keycloak")
-	truthy         = true
 	iterationMax   = 100
+
+	// The full representation includes the attributes of groups and subgroups
+	fullGroupRepresentationParams  = map[string]string{"full": "true", keycloakBriefRepresentation: "false"}
+	briefGroupRepresentationParams = map[string]string{"full": "false", keycloakBriefRepresentation: "true"}
+
+	// Access tokens are renewed when they expire within the margin
+	tokenExpirationMargin = 30 * time.Second
 )
 
+// keycloakGroupSource represents a Keycloak role or organization synchronized as a group
+type keycloakGroupSource struct {
+	name       string
+	realm      redhatcopv1alpha1.KeycloakRealm
+	attributes map[string][]string
+	members    []*gocloak.User
+}
+
+// keycloakOrganization represents an organization, which is available in Keycloak 26 and later
+type keycloakOrganization struct {
+	ID         *string             `json:"id,omitempty"`
+	Name       *string             `json:"name,omitempty"`
+	Alias      *string             `json:"alias,omitempty"`
+	Enabled    *bool               `json:"enabled,omitempty"`
+	Attributes map[string][]string `json:"attributes,omitempty"`
+}
+
+// keycloakGroup represents a group along with the number of subgroups reported by Keycloak 23 and later
+type keycloakGroup struct {
+	gocloak.Group
+	SubGroupCount *int `json:"subGroupCount,omitempty"`
+}
+
 type KeycloakSyncer struct {
-	Name               string
-	GroupSync          *redhatcopv1alpha1.GroupSync
-	Provider           *redhatcopv1alpha1.KeycloakProvider
-	GoCloak            gocloak.GoCloak
-	Context            context.Context
-	Token              *gocloak.JWT
-	CachedGroups       map[string]*gocloak.Group
-	CachedGroupMembers map[string][]*gocloak.User
-	ReconcilerBase     util.ReconcilerBase
-	CredentialsSecret  *corev1.Secret
-	CaCertificate      []byte
+	Name                    string
+	GroupSync               *redhatcopv1alpha1.GroupSync
+	Provider                *redhatcopv1alpha1.KeycloakProvider
+	GoCloak                 gocloak.GoCloak
+	Context                 context.Context
+	Token                   *gocloak.JWT
+	CachedGroups            map[string]*gocloak.Group
+	CachedGroupMembers      map[string][]*gocloak.User
+	CachedGroupRealms       map[string]redhatcopv1alpha1.KeycloakRealm
+	CachedGroupSources      map[string]*keycloakGroupSource
+	ReconcilerBase          util.ReconcilerBase
+	CredentialsSecret       *corev1.Secret
+	CredentialsSource       *redhatcopv1alpha1.CredentialsSource
+	CredentialsKeys         map[string]string
+	MetadataMappings        []redhatcopv1alpha1.MetadataMapping
+	RateLimit               *redhatcopv1alpha1.RateLimit
+	CaCertificate           []byte
+	CaBundles               []*redhatcopv1alpha1.ConfigMapRef
+	ClientCertificateSecret *redhatcopv1alpha1.SecretRef
+	ClientCertificate       *tls.Certificate
+
+	tokenExpiration        time.Time
+	refreshTokenExpiration time.Time
+	groupMemberCache       *responseCache
+	groups                 *GroupPatterns
+	excludedGroups         *GroupPatterns
 }
 
 func (k *KeycloakSyncer) Init() bool {
 
-	k.Context = context.Background()
+	if k.Context == nil {
+		k.Context = context.Background()
+	}
 
 	changed := false
 
 	k.CachedGroupMembers = make(map[string][]*gocloak.User)
 	k.CachedGroups = make(map[string]*gocloak.Group)
+	k.CachedGroupRealms = make(map[string]redhatcopv1alpha1.KeycloakRealm)
+	k.CachedGroupSources = make(map[string]*keycloakGroupSource)
+	k.groupMemberCache = newResponseCache()
 	k.GoCloak = gocloak.NewClient(k.Provider.URL)
 
+	// Requests of the Keycloak client are sent to the context path of the provider
+	if k.getContextPath() != keycloakClientContextPath {
+		k.GoCloak.RestyClient().OnBeforeRequest(func(_ *resty.Client, request *resty.Request) error {
+			request.URL = k.getContextPathURL(request.URL)
+			return nil
+		})
+	}
+
 	if k.Provider.LoginRealm == "" {
 		k.Provider.LoginRealm = masterRealm
 		changed = true
@@ -66,6 +135,12 @@ func (k *KeycloakSyncer) Init() bool {
 		changed = true
 	}
 
+	if k.Provider.ExcludeServiceAccounts == nil {
+		excludeServiceAccounts := true
+		k.Provider.ExcludeServiceAccounts = &excludeServiceAccounts
+		changed = true
+	}
+
 	return changed
 
 }
@@ -75,21 +150,30 @@ func (k *KeycloakSyncer) Validate() error {
 	validationErrors := []error{}
 
 	// Verify Secret Containing Username and Password Exists with Valid Keys
-	credentialsSecret := &corev1.Secret{}
-	err := k.ReconcilerBase.GetClient().Get(context.TODO(), types.NamespacedName{Name: k.Provider.CredentialsSecret.Name, Namespace: k.Provider.CredentialsSecret.Namespace}, credentialsSecret)
+	credentialsSecret, credentialsDescription, err := getCredentials(context.TODO(), k.ReconcilerBase.GetClient(), k.CredentialsSource, k.CredentialsKeys, k.Provider.CredentialsSecret)
 
 	if err != nil {
 		validationErrors = append(validationErrors, err)
 	} else {
 
-		// Username key validation
-		if _, found := credentialsSecret.Data[secretUsernameKey]; !found {
-			validationErrors = append(validationErrors, fmt.Errorf("Could not find 'username' key in secret '%s' in namespace '%s", k.Provider.CredentialsSecret.Name, k.Provider.CredentialsSecret.Namespace))
-		}
+		_, clientIDFound := credentialsSecret.Data[secretClientIDKey]
+		_, clientSecretFound := credentialsSecret.Data[secretClientSecretKey]
+
+		// Client credentials are used instead of a username and password when present
+		if !clientIDFound && !clientSecretFound {
 
-		// Password key validation
-		if _, found := credentialsSecret.Data[secretPasswordKey]; !found {
-			validationErrors = append(validationErrors, fmt.Errorf("Could not find 'password' key in secret '%s' in namespace '%s", k.Provider.CredentialsSecret.Name, k.Provider.CredentialsSecret.Namespace))
+			// Username key validation
+			if _, found := credentialsSecret.Data[secretUsernameKey]; !found {
+				validationErrors = append(validationErrors, fmt.Errorf("Could not find 'username' key in %s", credentialsDescription))
+			}
+
+			// Password key validation
+			if _, found := credentialsSecret.Data[secretPasswordKey]; !found {
+				validationErrors = append(validationErrors, fmt.Errorf("Could not find 'password' key in %s", credentialsDescription))
+			}
+
+		} else if !clientIDFound || !clientSecretFound {
+			validationErrors = append(validationErrors, fmt.Errorf("Could not find both 'clientId' and 'clientSecret' keys in %s", credentialsDescription))
 		}
 
 		k.CredentialsSecret = credentialsSecret
@@ -100,6 +184,38 @@ func (k *KeycloakSyncer) Validate() error {
 		validationErrors = append(validationErrors, err)
 	}
 
+	if k.Provider.Realm == "" && len(k.Provider.Realms) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("Either realm or realms must be specified"))
+	}
+
+	for _, realm := range k.Provider.Realms {
+		if realm.Name == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("Realm name not provided"))
+		}
+	}
+
+	var groupPatternErrors []error
+
+	k.groups, groupPatternErrors = compileGroupPatterns("groups", k.Provider.Groups)
+	validationErrors = append(validationErrors, groupPatternErrors...)
+	k.excludedGroups, groupPatternErrors = compileGroupPatterns("excludedGroups", k.Provider.ExcludedGroups)
+	validationErrors = append(validationErrors, groupPatternErrors...)
+
+	for _, attributeMapping := range k.Provider.AttributeMappings {
+		if errs := validation.IsQualifiedName(attributeMapping.Name); len(errs) > 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid attribute mapping name '%s': %s", attributeMapping.Name, strings.Join(errs, ", ")))
+		}
+	}
+
+	if k.Provider.RetryWaitTime != nil && k.Provider.RetryMaxWaitTime != nil && k.Provider.RetryWaitTime.Duration > k.Provider.RetryMaxWaitTime.Duration {
+		validationErrors = append(validationErrors, fmt.Errorf("retryWaitTime cannot be greater than retryMaxWaitTime"))
+	}
+
+	// Group names cannot contain path separators
+	if strings.ContainsAny(k.getGroupPathSeparator(), "/%") {
+		validationErrors = append(validationErrors, fmt.Errorf("groupPathSeparator cannot contain '/' or '%%'"))
+	}
+
 	providerCaResource := determineFromDeprecatedObjectRef(k.Provider.Ca, k.Provider.CaSecret)
 	if providerCaResource != nil {
 
@@ -109,12 +225,7 @@ func (k *KeycloakSyncer) Validate() error {
 			validationErrors = append(validationErrors, err)
 		}
 
-		var resourceCaKey string
-		if providerCaResource.Key != "" {
-			resourceCaKey = providerCaResource.Key
-		} else {
-			resourceCaKey = defaultResourceCaKey
-		}
+		resourceCaKey := getResourceCaKey(providerCaResource, caResource)
 
 		// Certificate key validation
 		if _, found := caResource[resourceCaKey]; !found {
@@ -124,6 +235,22 @@ func (k *KeycloakSyncer) Validate() error {
 		k.CaCertificate = caResource[resourceCaKey]
 	}
 
+	caBundles, err := getCaBundles(k.Context, k.ReconcilerBase.GetClient(), k.CaBundles)
+
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	} else {
+		k.CaCertificate = appendCaBundles(k.CaCertificate, caBundles)
+	}
+
+	clientCertificate, err := getClientCertificate(k.Context, k.ReconcilerBase.GetClient(), k.ClientCertificateSecret)
+
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	} else {
+		k.ClientCertificate = clientCertificate
+	}
+
 	return utilerrors.NewAggregate(validationErrors)
 
 }
@@ -132,108 +259,244 @@ func (k *KeycloakSyncer) Bind() error {
 
 	restyClient := k.GoCloak.RestyClient()
 
-	if k.Provider.Insecure == true {
-		restyClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
-	}
+	// The TLS configuration is applied once as each TLS configuration applied to the resty client replaces the previous one
+	tlsConfig := &tls.Config{InsecureSkipVerify: k.Provider.Insecure}
 
 	// Add trusted certificate if provided
 	if len(k.CaCertificate) > 0 {
 
-		tlsConfig := &tls.Config{}
+		// Trust the provided certificates in addition to the system certificates
+		if systemCertPool, err := x509.SystemCertPool(); err == nil {
+			tlsConfig.RootCAs = systemCertPool
+		}
+
 		if tlsConfig.RootCAs == nil {
 			tlsConfig.RootCAs = x509.NewCertPool()
 		}
 
 		tlsConfig.RootCAs.AppendCertsFromPEM(k.CaCertificate)
+	}
 
-		restyClient.SetTLSClientConfig(tlsConfig)
+	// Present the client certificate to providers requiring mutual TLS
+	if k.ClientCertificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*k.ClientCertificate}
 	}
 
-	k.GoCloak.SetRestyClient(restyClient)
+	restyClient.SetTLSClientConfig(tlsConfig)
 
-	token, err := k.GoCloak.LoginAdmin(string(k.CredentialsSecret.Data[secretUsernameKey]), string(k.CredentialsSecret.Data[secretPasswordKey]), k.Provider.LoginRealm)
+	if k.Provider.Timeout != nil {
+		restyClient.SetTimeout(k.Provider.Timeout.Duration)
+	}
 
-	k.Token = token
+	if k.Provider.RetryCount != nil && *k.Provider.RetryCount > 0 {
 
-	if err != nil {
+		restyClient.SetRetryCount(*k.Provider.RetryCount)
+
+		if k.Provider.RetryWaitTime != nil {
+			restyClient.SetRetryWaitTime(k.Provider.RetryWaitTime.Duration)
+		}
+
+		if k.Provider.RetryMaxWaitTime != nil {
+			restyClient.SetRetryMaxWaitTime(k.Provider.RetryMaxWaitTime.Duration)
+		}
+
+		// Retry requests while Keycloak is unavailable, such as during a restart, in addition to connection errors
+		restyClient.AddRetryCondition(func(response *resty.Response, err error) bool {
+			return response != nil && (response.StatusCode() >= http.StatusInternalServerError || response.StatusCode() == http.StatusTooManyRequests)
+		})
+	}
+
+	// Keycloak requests are not context aware so the context of the provider is attached to each request
+	restyClient.OnBeforeRequest(func(_ *resty.Client, request *resty.Request) error {
+		request.SetContext(k.Context)
+		return nil
+	})
+
+	// The transport of the resty client is left untouched as it is configured through the resty client
+	if k.RateLimit != nil {
+		limiter := newRateLimiter(k.RateLimit)
+
+		restyClient.OnBeforeRequest(func(_ *resty.Client, request *resty.Request) error {
+			return limiter.Wait(request.Context())
+		})
+	}
+
+	// The transport is traced once the TLS configuration of the resty client is complete as the resty client can no longer configure a
+	// decorated transport
+	restyClient.GetClient().Transport = newTracedTransport(restyClient.GetClient().Transport)
+
+	k.GoCloak.SetRestyClient(restyClient)
+
+	if err := k.login(); err != nil {
 		return err
 	}
 
-	keycloakLogger.Info("Successfully Authenticated with Keycloak Provider")
+	syncLogger(k.Context, keycloakLogger).Info("Successfully Authenticated with Keycloak Provider")
 
 	return nil
 }
 
-func (k *KeycloakSyncer) Sync() ([]userv1.Group, error) {
+func (k *KeycloakSyncer) login() error {
+
+	var token *gocloak.JWT
+	var err error
 
-	// Get Groups
-	groups, err := k.getGroups()
+	if clientID, clientIDFound := k.CredentialsSecret.Data[secretClientIDKey]; clientIDFound {
+		token, err = k.GoCloak.LoginClient(string(clientID), string(k.CredentialsSecret.Data[secretClientSecretKey]), k.Provider.LoginRealm)
+	} else {
+		token, err = k.GoCloak.LoginAdmin(string(k.CredentialsSecret.Data[secretUsernameKey]), string(k.CredentialsSecret.Data[secretPasswordKey]), k.Provider.LoginRealm)
+	}
 
 	if err != nil {
-		keycloakLogger.Error(err, "Failed to get Groups", "Provider", k.Name)
-		return nil, err
+		return err
 	}
 
-	for _, group := range groups {
+	k.setToken(token)
 
-		if _, groupFound := k.CachedGroups[*group.ID]; !groupFound {
-			k.processGroupsAndMembers(group, nil, k.Provider.Scope)
+	return nil
+}
+
+// getAccessToken returns the access token, renewing the token when it is about to expire. The refresh token is used when available
+// and otherwise the credentials are used to authenticate again
+func (k *KeycloakSyncer) getAccessToken() (string, error) {
+
+	// Tokens without an expiration are not renewed
+	if k.tokenExpiration.IsZero() || time.Now().Add(tokenExpirationMargin).Before(k.tokenExpiration) {
+		return k.Token.AccessToken, nil
+	}
+
+	syncLogger(k.Context, keycloakLogger).Info("Renewing Keycloak Access Token", "Provider", k.Name)
+
+	if k.Token.RefreshToken != "" && (k.refreshTokenExpiration.IsZero() || time.Now().Add(tokenExpirationMargin).Before(k.refreshTokenExpiration)) {
+
+		clientID := keycloakAdminClientID
+		if credentialsClientID, clientIDFound := k.CredentialsSecret.Data[secretClientIDKey]; clientIDFound {
+			clientID = string(credentialsClientID)
 		}
+
+		token, err := k.GoCloak.RefreshToken(k.Token.RefreshToken, clientID, string(k.CredentialsSecret.Data[secretClientSecretKey]), k.Provider.LoginRealm)
+
+		if err == nil {
+			k.setToken(token)
+			return k.Token.AccessToken, nil
+		}
+
+		syncLogger(k.Context, keycloakLogger).Error(err, "Failed to Refresh Keycloak Access Token", "Provider", k.Name)
 	}
 
-	ocpGroups := []userv1.Group{}
+	if err := k.login(); err != nil {
+		return "", err
+	}
 
-	for _, cachedGroup := range k.CachedGroups {
+	return k.Token.AccessToken, nil
+}
+
+// setToken sets the token along with the times at which the access and refresh tokens expire
+func (k *KeycloakSyncer) setToken(token *gocloak.JWT) {
 
-		groupAttributes := map[string]string{}
+	now := time.Now()
 
-		for key, value := range cachedGroup.Attributes {
-			// we add the annotation that qualify for OCP annotations and log for the ones that don't
-			if errs := validation.IsQualifiedName(key); len(errs) == 0 {
-				groupAttributes[key] = strings.Join(value, "'")
-			} else {
-				keycloakLogger.Info("unable to add annotation to", "group", cachedGroup.Name, "key", key, "value", value)
+	k.Token = token
+	k.tokenExpiration = time.Time{}
+	k.refreshTokenExpiration = time.Time{}
+
+	if token.ExpiresIn > 0 {
+		k.tokenExpiration = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	if token.RefreshExpiresIn > 0 {
+		k.refreshTokenExpiration = now.Add(time.Duration(token.RefreshExpiresIn) * time.Second)
+	}
+}
+
+func (k *KeycloakSyncer) Sync() ([]userv1.Group, error) {
+
+	for _, realm := range k.getRealms() {
+
+		if k.Provider.SyncGroups == nil || *k.Provider.SyncGroups {
+
+			// Get Groups
+			groups, err := k.getGroups(realm.Name)
+
+			if err != nil {
+				syncLogger(k.Context, keycloakLogger).Error(err, "Failed to get Groups", "Provider", k.Name, "Realm", realm.Name)
+				return nil, err
+			}
+
+			for _, group := range groups {
+
+				if _, groupFound := k.CachedGroups[*group.ID]; !groupFound {
+					if err := k.processGroupsAndMembers(realm, group, nil, k.Provider.Scope); err != nil {
+						syncLogger(k.Context, keycloakLogger).Error(err, "Failed to get Group Members", "Provider", k.Name, "Realm", realm.Name, "Group", *group.Name)
+						return nil, err
+					}
+				}
 			}
 		}
 
-		ocpGroup := userv1.Group{
-			TypeMeta: v1.TypeMeta{
-				Kind:       "Group",
-				APIVersion: userv1.GroupVersion.String(),
-			},
-			ObjectMeta: v1.ObjectMeta{
-				Name:        *cachedGroup.Name,
-				Annotations: groupAttributes,
-				Labels:      map[string]string{},
-			},
-			Users: []string{},
+		if k.Provider.SyncOrganizations {
+			if err := k.processOrganizations(realm); err != nil {
+				syncLogger(k.Context, keycloakLogger).Error(err, "Failed to get Organizations", "Provider", k.Name, "Realm", realm.Name)
+				return nil, err
+			}
 		}
 
-		url, err := url.Parse(k.Provider.URL)
+		if k.Provider.SyncRealmRoles {
+			if err := k.processRealmRoles(realm); err != nil {
+				syncLogger(k.Context, keycloakLogger).Error(err, "Failed to get Realm Roles", "Provider", k.Name, "Realm", realm.Name)
+				return nil, err
+			}
+		}
 
-		if err != nil {
-			return nil, err
+		for _, clientID := range k.Provider.ClientRoles {
+			if err := k.processClientRoles(realm, clientID); err != nil {
+				syncLogger(k.Context, keycloakLogger).Error(err, "Failed to get Client Roles", "Provider", k.Name, "Realm", realm.Name, "Client", clientID)
+				return nil, err
+			}
 		}
+	}
+
+	url, err := url.Parse(k.Provider.URL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ocpGroups := []userv1.Group{}
+
+	for _, cachedGroup := range k.CachedGroups {
+
+		realm := k.CachedGroupRealms[*cachedGroup.ID]
+
+		metadata := map[string]string{}
+
+		if cachedGroup.Path != nil {
+			metadata[MetadataPath] = *cachedGroup.Path
+		}
+
+		ocpGroup := k.newOcpGroup(k.getGroupName(realm, cachedGroup), *cachedGroup.ID, cachedGroup.Attributes, metadata, k.CachedGroupMembers[*cachedGroup.ID], url.Host)
 
 		childrenGroups := []string{}
 
 		for _, subgroup := range cachedGroup.SubGroups {
-			childrenGroups = append(childrenGroups, *subgroup.Name)
+
+			if k.excludedGroups.Matches(*subgroup.Name) {
+				continue
+			}
+
+			childrenGroups = append(childrenGroups, k.getGroupName(realm, subgroup))
 		}
 
 		parentGroups := []string{}
 
 		for _, group := range k.CachedGroups {
 			for _, subgroup := range group.SubGroups {
-				if *subgroup.Name == *cachedGroup.Name {
-					parentGroups = append(parentGroups, *group.Name)
+				if *subgroup.ID == *cachedGroup.ID {
+					parentGroups = append(parentGroups, k.getGroupName(k.CachedGroupRealms[*group.ID], group))
 				}
 			}
 		}
 
-		// Set Host Specific Details
-		ocpGroup.GetAnnotations()[constants.SyncSourceHost] = url.Host
-		ocpGroup.GetAnnotations()[constants.SyncSourceUID] = *cachedGroup.ID
 		if len(childrenGroups) > 0 {
 			ocpGroup.GetAnnotations()[constants.HierarchyChildren] = strings.Join(childrenGroups, ",")
 		}
@@ -244,26 +507,94 @@ func (k *KeycloakSyncer) Sync() ([]userv1.Group, error) {
 			ocpGroup.GetAnnotations()[constants.HierarchyParents] = strings.Join(parentGroups, ",")
 		}
 
-		for _, user := range k.CachedGroupMembers[*cachedGroup.ID] {
-			ocpGroup.Users = append(ocpGroup.Users, *user.Username)
-		}
-
 		ocpGroups = append(ocpGroups, ocpGroup)
 
 	}
 
+	for sourceID, cachedGroupSource := range k.CachedGroupSources {
+		ocpGroups = append(ocpGroups, k.newOcpGroup(cachedGroupSource.realm.Prefix+cachedGroupSource.name, sourceID, cachedGroupSource.attributes, nil, cachedGroupSource.members, url.Host))
+	}
+
 	return ocpGroups, nil
 }
 
-func (k *KeycloakSyncer) processGroupsAndMembers(group, parentGroup *gocloak.Group, scope redhatcopv1alpha1.SyncScope) error {
+// newOcpGroup creates a group containing the provided members along with the attributes of the corresponding Keycloak group or role. The
+// attributes, along with the provided metadata, are available to the metadata mappings of the provider
+func (k *KeycloakSyncer) newOcpGroup(name string, uid string, attributes map[string][]string, metadata map[string]string, members []*gocloak.User, host string) userv1.Group {
+
+	groupAttributes := map[string]string{}
+
+	for key, value := range attributes {
+		// we add the annotation that qualify for OCP annotations and log for the ones that don't
+		if errs := validation.IsQualifiedName(key); len(errs) == 0 {
+			groupAttributes[key] = strings.Join(value, "'")
+		} else {
+			syncLogger(k.Context, keycloakLogger).Info("unable to add annotation to", "group", name, "key", key, "value", value)
+		}
+	}
+
+	ocpGroup := userv1.Group{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Group",
+			APIVersion: userv1.GroupVersion.String(),
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:        name,
+			Annotations: groupAttributes,
+			Labels:      map[string]string{},
+		},
+		Users: []string{},
+	}
+
+	k.applyAttributeMappings(&ocpGroup, attributes)
+
+	// Set Host Specific Details
+	ocpGroup.GetAnnotations()[constants.SyncSourceHost] = host
+	ocpGroup.GetAnnotations()[constants.SyncSourceUID] = uid
+
+	groupMetadata := map[string]string{}
+
+	for key, value := range attributes {
+		groupMetadata[key] = strings.Join(value, ",")
+	}
+
+	for key, value := range metadata {
+		groupMetadata[key] = value
+	}
+
+	applyMetadataMappings(k.Context, &ocpGroup, k.MetadataMappings, groupMetadata)
+
+	for _, user := range members {
+
+		if k.Provider.ExcludeDisabledUsers && user.Enabled != nil && !*user.Enabled {
+			continue
+		}
+
+		if *k.Provider.ExcludeServiceAccounts && isServiceAccountUser(user) {
+			continue
+		}
+
+		if username, found := k.getUsername(user); found {
+			ocpGroup.Users = append(ocpGroup.Users, username)
+		} else {
+			syncLogger(k.Context, keycloakLogger).Info(fmt.Sprintf("Warning: Username for user cannot be found in Group '%s'", name), "User ID", *user.ID)
+		}
+	}
+
+	return ocpGroup
+}
+
+func (k *KeycloakSyncer) processGroupsAndMembers(realm redhatcopv1alpha1.KeycloakRealm, group, parentGroup *gocloak.Group, scope redhatcopv1alpha1.SyncScope) error {
 
-	if parentGroup == nil && !isGroupAllowed(*group.Name, k.Provider.Groups) {
+	// Subgroups are only subject to the excludedGroups deny list
+	if (parentGroup == nil && !k.isGroupAllowed(*group.Name)) || k.excludedGroups.Matches(*group.Name) {
 		return nil
 	}
 
 	k.CachedGroups[*group.ID] = group
+	k.CachedGroupRealms[*group.ID] = realm
 
-	groupMembers, err := k.getGroupMembers(*group.ID)
+	groupMembers, err := k.getGroupMembers(realm.Name, *group.ID)
 
 	if err != nil {
 		return err
@@ -281,7 +612,108 @@ func (k *KeycloakSyncer) processGroupsAndMembers(group, parentGroup *gocloak.Gro
 	if redhatcopv1alpha1.SubSyncScope == scope {
 		for _, subGroup := range group.SubGroups {
 			if _, subGroupFound := k.CachedGroups[*subGroup.ID]; !subGroupFound {
-				k.processGroupsAndMembers(subGroup, group, scope)
+				if err := k.processGroupsAndMembers(realm, subGroup, group, scope); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// processRealmRoles caches the realm roles of the realm along with the users effectively granted each role
+func (k *KeycloakSyncer) processRealmRoles(realm redhatcopv1alpha1.KeycloakRealm) error {
+
+	accessToken, err := k.getAccessToken()
+
+	if err != nil {
+		return err
+	}
+
+	roles, err := k.GoCloak.GetRealmRoles(accessToken, realm.Name)
+
+	if err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+
+		if !k.isGroupAllowed(*role.Name) {
+			continue
+		}
+
+		roleMembers, err := k.getRoleMembers(realm.Name, "roles", *role.Name)
+
+		if err != nil {
+			return err
+		}
+
+		k.CachedGroupSources[*role.ID] = &keycloakGroupSource{
+			name:       *role.Name,
+			realm:      realm,
+			attributes: role.Attributes,
+			members:    roleMembers,
+		}
+	}
+
+	return nil
+}
+
+// processClientRoles caches the roles of the client along with the users effectively granted each role. Groups are named
+// using the format <client>-<role>
+func (k *KeycloakSyncer) processClientRoles(realm redhatcopv1alpha1.KeycloakRealm, clientID string) error {
+
+	accessToken, err := k.getAccessToken()
+
+	if err != nil {
+		return err
+	}
+
+	clients, err := k.GoCloak.GetClients(accessToken, realm.Name, gocloak.GetClientsParams{ClientID: &clientID})
+
+	if err != nil {
+		return err
+	}
+
+	for _, client := range clients {
+
+		// The client ID parameter may match partially
+		if *client.ClientID != clientID {
+			continue
+		}
+
+		accessToken, err := k.getAccessToken()
+
+		if err != nil {
+			return err
+		}
+
+		roles, err := k.GoCloak.GetClientRoles(accessToken, realm.Name, *client.ID)
+
+		if err != nil {
+			return err
+		}
+
+		for _, role := range roles {
+
+			roleName := fmt.Sprintf("%s-%s", clientID, *role.Name)
+
+			if !k.isGroupAllowed(roleName) {
+				continue
+			}
+
+			roleMembers, err := k.getRoleMembers(realm.Name, "clients", *client.ID, "roles", *role.Name)
+
+			if err != nil {
+				return err
+			}
+
+			k.CachedGroupSources[*role.ID] = &keycloakGroupSource{
+				name:       roleName,
+				realm:      realm,
+				attributes: role.Attributes,
+				members:    roleMembers,
 			}
 		}
 	}
@@ -289,6 +721,216 @@ func (k *KeycloakSyncer) processGroupsAndMembers(group, parentGroup *gocloak.Gro
 	return nil
 }
 
+// processOrganizations caches the enabled organizations of the realm along with their members
+func (k *KeycloakSyncer) processOrganizations(realm redhatcopv1alpha1.KeycloakRealm) error {
+
+	retrievedOrganizations := 0
+
+	return forEachPage(0, func(page int) (int, error) {
+
+		organizations := []*keycloakOrganization{}
+
+		if err := k.getAdminResource(realm.Name, retrievedOrganizations, map[string]string{keycloakBriefRepresentation: "false"}, &organizations, "organizations"); err != nil {
+			return 0, err
+		}
+
+		// Only an empty page indicates that all organizations have been retrieved, as pages may hold fewer organizations than requested
+		if len(organizations) == 0 {
+			return page, nil
+		}
+
+		retrievedOrganizations += len(organizations)
+
+		for _, organization := range organizations {
+
+			if (organization.Enabled != nil && !*organization.Enabled) || !k.isGroupAllowed(*organization.Name) {
+				continue
+			}
+
+			organizationMembers, err := k.getOrganizationMembers(realm.Name, *organization.ID)
+
+			if err != nil {
+				return 0, err
+			}
+
+			k.CachedGroupSources[*organization.ID] = &keycloakGroupSource{
+				name:       *organization.Name,
+				realm:      realm,
+				attributes: organization.Attributes,
+				members:    organizationMembers,
+			}
+		}
+
+		return page + 1, nil
+	})
+}
+
+func (k *KeycloakSyncer) getOrganizationMembers(realm string, organizationID string) ([]*gocloak.User, error) {
+
+	members := []*gocloak.User{}
+
+	err := forEachPage(0, func(page int) (int, error) {
+
+		organizationMembers := []*gocloak.User{}
+
+		usersParams := map[string]string{keycloakBriefRepresentation: strconv.FormatBool(!k.hasCustomUserNameAttributes())}
+
+		if err := k.getAdminResource(realm, len(members), usersParams, &organizationMembers, "organizations", organizationID, "members"); err != nil {
+			return 0, err
+		}
+
+		if len(organizationMembers) == 0 {
+			return page, nil
+		}
+
+		members = append(members, organizationMembers...)
+
+		return page + 1, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// getRoleMembers returns the users granted the role located at the provided path either directly or through membership in a group
+// granted the role, including the subgroups of the group
+func (k *KeycloakSyncer) getRoleMembers(realm string, rolePath ...string) ([]*gocloak.User, error) {
+
+	members := []*gocloak.User{}
+
+	err := forEachPage(0, func(page int) (int, error) {
+
+		users := []*gocloak.User{}
+
+		usersParams := map[string]string{keycloakBriefRepresentation: strconv.FormatBool(!k.hasCustomUserNameAttributes())}
+
+		if err := k.getAdminResource(realm, len(members), usersParams, &users, append(rolePath, "users")...); err != nil {
+			return 0, err
+		}
+
+		if len(users) == 0 {
+			return page, nil
+		}
+
+		members = append(members, users...)
+
+		return page + 1, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	retrievedGroups := 0
+
+	err = forEachPage(0, func(page int) (int, error) {
+
+		groups := []*gocloak.Group{}
+
+		if err := k.getAdminResource(realm, retrievedGroups, map[string]string{}, &groups, append(rolePath, "groups")...); err != nil {
+			return 0, err
+		}
+
+		if len(groups) == 0 {
+			return page, nil
+		}
+
+		retrievedGroups += len(groups)
+
+		for _, group := range groups {
+
+			groupMembers, err := k.getGroupTreeMembers(realm, *group.ID)
+
+			if err != nil {
+				return 0, err
+			}
+
+			usersToAdd, _ := k.diff(groupMembers, members)
+			members = append(members, usersToAdd...)
+		}
+
+		return page + 1, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// getGroupTreeMembers returns the members of the group along with the members of each of its subgroups
+func (k *KeycloakSyncer) getGroupTreeMembers(realm string, groupId string) ([]*gocloak.User, error) {
+
+	members, err := k.getGroupMembers(realm, groupId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	group := &keycloakGroup{}
+
+	if err := k.getAdminResource(realm, 0, map[string]string{}, group, "groups", groupId); err != nil {
+		return nil, err
+	}
+
+	subGroups, err := k.getSubGroups(realm, group)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subGroup := range subGroups {
+
+		subGroupMembers, err := k.getGroupTreeMembers(realm, *subGroup.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		usersToAdd, _ := k.diff(subGroupMembers, members)
+		members = append(members, usersToAdd...)
+	}
+
+	return members, nil
+}
+
+// getAdminResource retrieves a page of the admin resource of the realm located at the provided path. Used for resources that cannot
+// be paged using the Keycloak client. The provided parameters take precedence over the paging parameters
+func (k *KeycloakSyncer) getAdminResource(realm string, first int, params map[string]string, result interface{}, path ...string) error {
+
+	resourcePath := []string{strings.TrimRight(k.Provider.URL, "/") + k.getContextPath(), "admin", "realms", url.PathEscape(realm)}
+	for _, pathElement := range path {
+		resourcePath = append(resourcePath, url.PathEscape(pathElement))
+	}
+
+	accessToken, err := k.getAccessToken()
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := k.GoCloak.RestyClient().R().
+		SetAuthToken(accessToken).
+		SetQueryParams(map[string]string{"first": strconv.Itoa(first), "max": strconv.Itoa(iterationMax)}).
+		SetQueryParams(params).
+		SetResult(result).
+		Get(strings.Join(resourcePath, "/"))
+
+	if err != nil {
+		return err
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("Failed to retrieve Keycloak resource '%s': %s", strings.Join(path, "/"), resp.Status())
+	}
+
+	return nil
+}
+
 func (k *KeycloakSyncer) diff(lhsSlice, rhsSlice []*gocloak.User) (lhsOnly []*gocloak.User, rhsOnly []*gocloak.User) {
 	return k.singleDiff(lhsSlice, rhsSlice), k.singleDiff(rhsSlice, lhsSlice)
 }
@@ -311,36 +953,135 @@ func (k *KeycloakSyncer) singleDiff(lhsSlice, rhsSlice []*gocloak.User) (lhsOnly
 	return lhsOnly
 }
 
-func (k *KeycloakSyncer) setGroupsAttributes(groups []*gocloak.Group) {
-	for _, group := range groups {
+// getRealms returns the realms to synchronize, including the realm specified by the realm property
+func (k *KeycloakSyncer) getRealms() []redhatcopv1alpha1.KeycloakRealm {
 
-		g, err := k.GoCloak.GetGroup(k.Token.AccessToken, k.Provider.Realm, *group.ID)
+	realms := []redhatcopv1alpha1.KeycloakRealm{}
 
-		if err != nil {
-			continue
+	if k.Provider.Realm != "" {
+		realms = append(realms, redhatcopv1alpha1.KeycloakRealm{Name: k.Provider.Realm})
+	}
+
+	return append(realms, k.Provider.Realms...)
+}
+
+// isGroupAllowed determines whether the group matches the groups allow list, when specified, and does not match the excludedGroups
+// deny list
+func (k *KeycloakSyncer) isGroupAllowed(groupName string) bool {
+	return isGroupSelected(groupName, k.groups, k.excludedGroups)
+}
+
+// getGroupName returns the name of the group, or the path of the group when useGroupPath is enabled, prefixed with the prefix of
+// the realm containing the group
+func (k *KeycloakSyncer) getGroupName(realm redhatcopv1alpha1.KeycloakRealm, group *gocloak.Group) string {
+
+	if k.Provider.UseGroupPath && group.Path != nil {
+		return realm.Prefix + strings.ReplaceAll(strings.TrimPrefix(*group.Path, keycloakGroupPathSeparator), keycloakGroupPathSeparator, k.getGroupPathSeparator())
+	}
+
+	return realm.Prefix + *group.Name
+}
+
+func (k *KeycloakSyncer) getGroupPathSeparator() string {
+
+	if k.Provider.GroupPathSeparator == nil {
+		return defaultGroupPathSeparator
+	}
+
+	return *k.Provider.GroupPathSeparator
+}
+
+// getContextPath returns the context path of the provider without a trailing slash, which is empty when Keycloak is served from the
+// root of the URL
+func (k *KeycloakSyncer) getContextPath() string {
+
+	if k.Provider.ContextPath == nil {
+		return keycloakClientContextPath
+	}
+
+	if contextPath := strings.Trim(*k.Provider.ContextPath, "/"); contextPath != "" {
+		return "/" + contextPath
+	}
+
+	return ""
+}
+
+// getContextPathURL replaces the context path of the URLs of the Keycloak client with the context path of the provider. URLs already
+// using the context path of the provider, such as the URLs of retried requests, are left untouched
+func (k *KeycloakSyncer) getContextPathURL(requestURL string) string {
+
+	baseURL := strings.TrimRight(k.Provider.URL, "/")
+	clientPath := strings.TrimLeft(strings.TrimPrefix(requestURL, baseURL), "/")
+
+	if !strings.HasPrefix(requestURL, baseURL) || !(strings.HasPrefix(clientPath, "auth/realms/") || strings.HasPrefix(clientPath, "auth/admin/realms/")) {
+		return requestURL
+	}
+
+	return baseURL + k.getContextPath() + strings.TrimPrefix(clientPath, "auth")
+}
+
+func (k *KeycloakSyncer) getGroups(realm string) ([]*gocloak.Group, error) {
+
+	if k.Provider.PerformanceMode {
+		return k.getAllowedGroups(realm)
+	}
+
+	groups := []*gocloak.Group{}
+
+	err := forEachPage(0, func(page int) (int, error) {
+
+		groupsResponse := []*keycloakGroup{}
+
+		if err := k.getAdminResource(realm, len(groups), fullGroupRepresentationParams, &groupsResponse, "groups"); err != nil {
+			return 0, err
 		}
 
-		if g.Attributes != nil && len(g.Attributes) > 0 {
-			group.Attributes = g.Attributes
+		// Keycloak may return fewer groups than requested even when more groups are available, so only an empty page indicates that
+		// all groups have been retrieved
+		if len(groupsResponse) == 0 {
+			return page, nil
 		}
 
-		if group.SubGroups != nil && len(group.SubGroups) > 0 {
-			k.setGroupsAttributes(group.SubGroups)
+		for _, group := range groupsResponse {
+
+			subGroups, err := k.getSubGroups(realm, group)
+
+			if err != nil {
+				return 0, err
+			}
+
+			group.SubGroups = subGroups
+			groups = append(groups, &group.Group)
 		}
+
+		return page + 1, nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
+
+	return groups, nil
 }
 
-func (k *KeycloakSyncer) getGroups() ([]*gocloak.Group, error) {
+// getAllowedGroups returns the top level groups that are allowed to be synchronized and that are not empty. The brief representation
+// of groups is retrieved first so that the full representation is only retrieved for the groups that are synchronized
+func (k *KeycloakSyncer) getAllowedGroups(realm string) ([]*gocloak.Group, error) {
+
+	groupsCount := &gocloak.GroupsCount{}
+
+	if err := k.getAdminResource(realm, 0, map[string]string{"top": "true"}, groupsCount, "groups", "count"); err != nil {
+		return nil, err
+	}
+
 	groups := []*gocloak.Group{}
 
-	iteration := 0
+	// Pages start after the groups retrieved as pages may hold fewer groups than requested
+	for first := 0; first < groupsCount.Count; {
 
-	for {
-		gIteration := iteration * iterationMax
-		groupsParams := gocloak.GetGroupsParams{First: &gIteration, Max: &iterationMax, BriefRepresentation: &truthy}
-		groupsResponse, err := k.GoCloak.GetGroups(k.Token.AccessToken, k.Provider.Realm, groupsParams)
+		groupsResponse := []*keycloakGroup{}
 
-		if err != nil {
+		if err := k.getAdminResource(realm, first, briefGroupRepresentationParams, &groupsResponse, "groups"); err != nil {
 			return nil, err
 		}
 
@@ -348,41 +1089,228 @@ func (k *KeycloakSyncer) getGroups() ([]*gocloak.Group, error) {
 			break
 		}
 
-		k.setGroupsAttributes(groupsResponse)
+		first += len(groupsResponse)
+
+		for _, briefGroup := range groupsResponse {
+
+			if !k.isGroupAllowed(*briefGroup.Name) {
+				continue
+			}
+
+			empty, err := k.isGroupEmpty(realm, briefGroup)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if empty {
+				syncLogger(k.Context, keycloakLogger).V(1).Info("Skipping Empty Group", "Provider", k.Name, "Realm", realm, "Group", *briefGroup.Name)
+				continue
+			}
+
+			group := &keycloakGroup{}
+
+			if err := k.getAdminResource(realm, 0, fullGroupRepresentationParams, group, "groups", *briefGroup.ID); err != nil {
+				return nil, err
+			}
+
+			subGroups, err := k.getSubGroups(realm, group)
 
-		groups = append(groups, groupsResponse...)
-		iteration = iteration + 1
+			if err != nil {
+				return nil, err
+			}
 
+			group.SubGroups = subGroups
+			groups = append(groups, &group.Group)
+		}
 	}
 
 	return groups, nil
 }
 
-func (k *KeycloakSyncer) getGroupMembers(groupId string) ([]*gocloak.User, error) {
+// isGroupEmpty determines whether the group has neither subgroups nor members
+func (k *KeycloakSyncer) isGroupEmpty(realm string, group *keycloakGroup) (bool, error) {
+
+	if len(group.SubGroups) > 0 || (group.SubGroupCount != nil && *group.SubGroupCount > 0) {
+		return false, nil
+	}
+
 	members := []*gocloak.User{}
 
-	iteration := 0
+	if err := k.getAdminResource(realm, 0, map[string]string{"max": "1", keycloakBriefRepresentation: "true"}, &members, "groups", *group.ID, "members"); err != nil {
+		return false, err
+	}
 
-	for {
+	return len(members) == 0, nil
+}
 
-		uIteration := iteration * iterationMax
-		groupMemberParams := gocloak.GetGroupsParams{First: &uIteration, Max: &iterationMax, BriefRepresentation: &truthy}
-		groupMembers, err := k.GoCloak.GetGroupMembers(k.Token.AccessToken, k.Provider.Realm, groupId, groupMemberParams)
+// getSubGroups returns the tree of subgroups of the group. Keycloak 23 and later no longer include subgroups in the representation
+// of groups, so subgroups are retrieved using the children endpoint when the group reports subgroups that are not present
+func (k *KeycloakSyncer) getSubGroups(realm string, group *keycloakGroup) ([]*gocloak.Group, error) {
 
-		if err != nil {
-			return nil, err
+	if group.SubGroupCount == nil || *group.SubGroupCount == 0 || len(group.SubGroups) > 0 {
+		return group.SubGroups, nil
+	}
+
+	subGroups := []*gocloak.Group{}
+
+	err := forEachPage(0, func(page int) (int, error) {
+
+		children := []*keycloakGroup{}
+
+		if err := k.getAdminResource(realm, len(subGroups), fullGroupRepresentationParams, &children, "groups", *group.ID, "children"); err != nil {
+			return 0, err
 		}
 
-		if len(groupMembers) == 0 {
-			break
+		if len(children) == 0 {
+			return page, nil
+		}
+
+		for _, child := range children {
+
+			childSubGroups, err := k.getSubGroups(realm, child)
+
+			if err != nil {
+				return 0, err
+			}
+
+			child.SubGroups = childSubGroups
+			subGroups = append(subGroups, &child.Group)
 		}
 
-		members = append(members, groupMembers...)
-		iteration = iteration + 1
+		return page + 1, nil
+	})
 
+	if err != nil {
+		return nil, err
 	}
 
-	return members, nil
+	return subGroups, nil
+}
+
+// getGroupMembers returns the members of the group. Members are cached for the duration of the synchronization as the members of
+// groups granted several roles are otherwise retrieved for each role
+func (k *KeycloakSyncer) getGroupMembers(realm string, groupId string) ([]*gocloak.User, error) {
+
+	cachedMembers, err := k.groupMemberCache.get(fmt.Sprintf("%s/%s", realm, groupId), func() (interface{}, error) {
+
+		members := []*gocloak.User{}
+
+		err := forEachPage(0, func(page int) (int, error) {
+
+			// Pages start after the members retrieved as pages may hold fewer members than requested
+			first := len(members)
+			// Custom attributes are only included in the full representation of users
+			briefRepresentation := !k.hasCustomUserNameAttributes()
+			groupMemberParams := gocloak.GetGroupsParams{First: &first, Max: &iterationMax, BriefRepresentation: &briefRepresentation}
+			accessToken, err := k.getAccessToken()
+
+			if err != nil {
+				return 0, err
+			}
+
+			groupMembers, err := k.GoCloak.GetGroupMembers(accessToken, realm, groupId, groupMemberParams)
+
+			if err != nil {
+				return 0, err
+			}
+
+			// Keycloak may return fewer members than requested even when more members are available, such as when the server caps the
+			// size of pages, so only an empty page indicates that all members have been retrieved
+			if len(groupMembers) == 0 {
+				return page, nil
+			}
+
+			members = append(members, groupMembers...)
+
+			return page + 1, nil
+		})
+
+		return members, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers append to the members returned, which must not change the cached members
+	return append([]*gocloak.User{}, cachedMembers.([]*gocloak.User)...), nil
+}
+
+// applyAttributeMappings sets the labels and annotations of the group from the mapped attributes. Labels are set using the first
+// value of the attribute while annotations contain each of the values separated by commas
+func (k *KeycloakSyncer) applyAttributeMappings(ocpGroup *userv1.Group, attributes map[string][]string) {
+
+	for _, attributeMapping := range k.Provider.AttributeMappings {
+
+		values, found := attributes[attributeMapping.Attribute]
+
+		if !found || len(values) == 0 {
+			continue
+		}
+
+		if attributeMapping.Target == redhatcopv1alpha1.LabelAttributeMappingTarget {
+			if errs := validation.IsValidLabelValue(values[0]); len(errs) > 0 {
+				syncLogger(k.Context, keycloakLogger).Info("unable to add label to", "group", ocpGroup.Name, "key", attributeMapping.Name, "value", values[0])
+				continue
+			}
+
+			ocpGroup.GetLabels()[attributeMapping.Name] = values[0]
+		} else {
+			ocpGroup.GetAnnotations()[attributeMapping.Name] = strings.Join(values, ",")
+		}
+	}
+}
+
+// getUsername returns the value of the first username attribute present on the user. Attributes other than username and email
+// refer to custom user attributes
+func (k *KeycloakSyncer) getUsername(user *gocloak.User) (string, bool) {
+	return resolveUsername(k.Provider.UserNameAttributes, keycloakUsernameAttribute, func(attribute string) (string, bool) {
+		return getKeycloakUserAttribute(user, attribute)
+	})
+}
+
+// hasCustomUserNameAttributes determines whether any of the username attributes refer to custom user attributes
+func (k *KeycloakSyncer) hasCustomUserNameAttributes() bool {
+
+	if k.Provider.UserNameAttributes == nil {
+		return false
+	}
+
+	for _, usernameAttribute := range *k.Provider.UserNameAttributes {
+		if usernameAttribute != keycloakUsernameAttribute && usernameAttribute != keycloakEmailAttribute {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isServiceAccountUser determines whether the user is the service account of a client
+func isServiceAccountUser(user *gocloak.User) bool {
+	return user.ServiceAccountClientID != nil || (user.Username != nil && strings.HasPrefix(*user.Username, serviceAccountUserPrefix))
+}
+
+func getKeycloakUserAttribute(user *gocloak.User, attribute string) (string, bool) {
+
+	var value *string
+
+	switch attribute {
+	case keycloakUsernameAttribute:
+		value = user.Username
+	case keycloakEmailAttribute:
+		value = user.Email
+	default:
+		if values := user.Attributes[attribute]; len(values) > 0 {
+			value = &values[0]
+		}
+	}
+
+	if value == nil || *value == "" {
+		return "", false
+	}
+
+	return *value, true
 }
 
 func (k *KeycloakSyncer) GetProviderName() string {
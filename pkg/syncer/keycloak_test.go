@@ -0,0 +1,412 @@
+package syncer
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v5"
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	fakeKeycloakRealm        = "ocp"
+	fakeKeycloakGroupID      = "large-group"
+	fakeKeycloakAccessToken  = "token"
+	fakeKeycloakRenewedToken = "renewed-token"
+)
+
+// newFakeKeycloak returns a server emulating the paging of the Keycloak groups and group members endpoints. Requests are only
+// authorized using the provided access token, which is also returned when authenticating
+func newFakeKeycloak(t *testing.T, memberCount int, accessToken string) *httptest.Server {
+	return newFakeKeycloakWithPageSize(t, memberCount, accessToken, 100)
+}
+
+// newFakeKeycloakWithPageSize returns a server emulating a Keycloak server returning at most pageSize items per page regardless of the
+// number of items requested
+func newFakeKeycloakWithPageSize(t *testing.T, memberCount int, accessToken string, pageSize int) *httptest.Server {
+	return newFakeKeycloakWithContextPath(t, memberCount, accessToken, pageSize, "/auth")
+}
+
+// newFakeKeycloakWithContextPath returns a server emulating a Keycloak server served under the provided context path
+func newFakeKeycloakWithContextPath(t *testing.T, memberCount int, accessToken string, pageSize int, contextPath string) *httptest.Server {
+	return httptest.NewServer(newFakeKeycloakHandler(t, memberCount, accessToken, pageSize, contextPath))
+}
+
+// newFakeKeycloakHandler returns the handler of the fake Keycloak server
+func newFakeKeycloakHandler(t *testing.T, memberCount int, accessToken string, pageSize int, contextPath string) http.Handler {
+
+	members := []*gocloak.User{}
+	for i := 0; i < memberCount; i++ {
+		members = append(members, &gocloak.User{
+			ID:       gocloak.StringP(fmt.Sprintf("user-%d", i)),
+			Username: gocloak.StringP(fmt.Sprintf("user%d", i)),
+		})
+	}
+
+	groups := []*gocloak.Group{
+		{
+			ID:   gocloak.StringP(fakeKeycloakGroupID),
+			Name: gocloak.StringP(fakeKeycloakGroupID),
+			Path: gocloak.StringP("/" + fakeKeycloakGroupID),
+		},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token", contextPath, masterRealm), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&gocloak.JWT{AccessToken: accessToken, ExpiresIn: 300})
+	})
+
+	mux.HandleFunc(fmt.Sprintf("%s/admin/realms/%s/groups", contextPath, fakeKeycloakRealm), authorized(accessToken, func(w http.ResponseWriter, r *http.Request) {
+		writePage(t, w, r, groups, pageSize)
+	}))
+
+	mux.HandleFunc(fmt.Sprintf("%s/admin/realms/%s/groups/%s/members", contextPath, fakeKeycloakRealm, fakeKeycloakGroupID), authorized(accessToken, func(w http.ResponseWriter, r *http.Request) {
+		writePage(t, w, r, members, pageSize)
+	}))
+
+	return mux
+}
+
+func authorized(accessToken string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+accessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// writePage writes the page of items requested using the first and max query parameters. Like Keycloak, at most pageSize items are
+// returned
+func writePage(t *testing.T, w http.ResponseWriter, r *http.Request, items interface{}, pageSize int) {
+
+	first, _ := strconv.Atoi(r.URL.Query().Get("first"))
+	max, err := strconv.Atoi(r.URL.Query().Get("max"))
+	if err != nil || max > pageSize {
+		max = pageSize
+	}
+
+	var page interface{}
+
+	switch typedItems := items.(type) {
+	case []*gocloak.User:
+		page = typedItems[min(first, len(typedItems)):min(first+max, len(typedItems))]
+	case []*gocloak.Group:
+		page = typedItems[min(first, len(typedItems)):min(first+max, len(typedItems))]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(page); err != nil {
+		t.Fatalf("failed to encode page: %v", err)
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func newFakeKeycloakSyncer(url string) *KeycloakSyncer {
+
+	keycloakSyncer := &KeycloakSyncer{
+		Name: "keycloak",
+		Provider: &redhatcopv1alpha1.KeycloakProvider{
+			Realm: fakeKeycloakRealm,
+			URL:   url,
+		},
+	}
+
+	keycloakSyncer.Init()
+	keycloakSyncer.Token = &gocloak.JWT{AccessToken: fakeKeycloakAccessToken}
+	keycloakSyncer.CredentialsSecret = &corev1.Secret{
+		Data: map[string][]byte{
+			secretUsernameKey: []byte("admin"),
+			secretPasswordKey: []byte("password"),
+		},
+	}
+
+	return keycloakSyncer
+}
+
+func TestKeycloakGetGroupMembersPaging(t *testing.T) {
+
+	server := newFakeKeycloak(t, 250, fakeKeycloakAccessToken)
+	defer server.Close()
+
+	members, err := newFakeKeycloakSyncer(server.URL).getGroupMembers(fakeKeycloakRealm, fakeKeycloakGroupID)
+
+	if err != nil {
+		t.Fatalf("unexpected error retrieving group members: %v", err)
+	}
+
+	if len(members) != 250 {
+		t.Fatalf("expected 250 group members, found %d", len(members))
+	}
+
+	usernames := map[string]bool{}
+	for _, member := range members {
+		usernames[*member.Username] = true
+	}
+
+	if len(usernames) != 250 {
+		t.Fatalf("expected 250 unique group members, found %d", len(usernames))
+	}
+}
+
+func TestKeycloakGetGroupMembersServerPageSize(t *testing.T) {
+
+	// The server returns fewer members than requested on every page
+	server := newFakeKeycloakWithPageSize(t, 120, fakeKeycloakAccessToken, 50)
+	defer server.Close()
+
+	members, err := newFakeKeycloakSyncer(server.URL).getGroupMembers(fakeKeycloakRealm, fakeKeycloakGroupID)
+
+	if err != nil {
+		t.Fatalf("unexpected error retrieving group members: %v", err)
+	}
+
+	if len(members) != 120 {
+		t.Fatalf("expected 120 group members, found %d", len(members))
+	}
+}
+
+func TestKeycloakSyncLargeGroup(t *testing.T) {
+
+	server := newFakeKeycloak(t, 101, fakeKeycloakAccessToken)
+	defer server.Close()
+
+	groups, err := newFakeKeycloakSyncer(server.URL).Sync()
+
+	if err != nil {
+		t.Fatalf("unexpected error synchronizing groups: %v", err)
+	}
+
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 group, found %d", len(groups))
+	}
+
+	if len(groups[0].Users) != 101 {
+		t.Fatalf("expected 101 users in group '%s', found %d", groups[0].Name, len(groups[0].Users))
+	}
+}
+
+func TestKeycloakRenewExpiredToken(t *testing.T) {
+
+	server := newFakeKeycloak(t, 10, fakeKeycloakRenewedToken)
+	defer server.Close()
+
+	keycloakSyncer := newFakeKeycloakSyncer(server.URL)
+	keycloakSyncer.tokenExpiration = time.Now().Add(-time.Minute)
+
+	members, err := keycloakSyncer.getGroupMembers(fakeKeycloakRealm, fakeKeycloakGroupID)
+
+	if err != nil {
+		t.Fatalf("unexpected error retrieving group members: %v", err)
+	}
+
+	if len(members) != 10 {
+		t.Fatalf("expected 10 group members, found %d", len(members))
+	}
+
+	if keycloakSyncer.Token.AccessToken != fakeKeycloakRenewedToken {
+		t.Fatalf("expected access token to be renewed")
+	}
+}
+
+func TestKeycloakSyncWithoutContextPath(t *testing.T) {
+
+	// Emulates Keycloak 17 and later, which no longer serve Keycloak under /auth by default
+	server := newFakeKeycloakWithContextPath(t, 101, fakeKeycloakRenewedToken, 100, "")
+	defer server.Close()
+
+	contextPath := ""
+
+	keycloakSyncer := newFakeKeycloakSyncer(server.URL)
+	keycloakSyncer.Provider.ContextPath = &contextPath
+	keycloakSyncer.Init()
+	keycloakSyncer.tokenExpiration = time.Now().Add(-time.Minute)
+
+	groups, err := keycloakSyncer.Sync()
+
+	if err != nil {
+		t.Fatalf("unexpected error synchronizing groups: %v", err)
+	}
+
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 group, found %d", len(groups))
+	}
+
+	if len(groups[0].Users) != 101 {
+		t.Fatalf("expected 101 users in group '%s', found %d", groups[0].Name, len(groups[0].Users))
+	}
+
+	if keycloakSyncer.Token.AccessToken != fakeKeycloakRenewedToken {
+		t.Fatalf("expected access token to be renewed")
+	}
+}
+
+func TestKeycloakSyncChildGroups(t *testing.T) {
+
+	subGroupCount := 1
+	noSubGroups := 0
+
+	parentGroup := &keycloakGroup{
+		Group:         gocloak.Group{ID: gocloak.StringP("parent"), Name: gocloak.StringP("parent"), Path: gocloak.StringP("/parent")},
+		SubGroupCount: &subGroupCount,
+	}
+
+	childGroup := &keycloakGroup{
+		Group:         gocloak.Group{ID: gocloak.StringP("child"), Name: gocloak.StringP("child"), Path: gocloak.StringP("/parent/child")},
+		SubGroupCount: &noSubGroups,
+	}
+
+	// Emulates Keycloak 23 and later where subgroups are only available using the children endpoint
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("/auth/admin/realms/%s/groups", fakeKeycloakRealm), func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]*keycloakGroup{parentGroup})
+	})
+	mux.HandleFunc(fmt.Sprintf("/auth/admin/realms/%s/groups/parent/children", fakeKeycloakRealm), func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]*keycloakGroup{childGroup})
+	})
+	mux.HandleFunc(fmt.Sprintf("/auth/admin/realms/%s/groups/parent/members", fakeKeycloakRealm), func(w http.ResponseWriter, r *http.Request) {
+		writePage(t, w, r, []*gocloak.User{{ID: gocloak.StringP("user-1"), Username: gocloak.StringP("user1")}}, 100)
+	})
+	mux.HandleFunc(fmt.Sprintf("/auth/admin/realms/%s/groups/child/members", fakeKeycloakRealm), func(w http.ResponseWriter, r *http.Request) {
+		writePage(t, w, r, []*gocloak.User{{ID: gocloak.StringP("user-2"), Username: gocloak.StringP("user2")}}, 100)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	groups, err := newFakeKeycloakSyncer(server.URL).Sync()
+
+	if err != nil {
+		t.Fatalf("unexpected error synchronizing groups: %v", err)
+	}
+
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, found %d", len(groups))
+	}
+
+	for _, group := range groups {
+		if group.Name == "parent" {
+			if group.Annotations[constants.HierarchyChildren] != "child" {
+				t.Fatalf("expected group 'parent' to have child 'child', found '%s'", group.Annotations[constants.HierarchyChildren])
+			}
+
+			if len(group.Users) != 2 {
+				t.Fatalf("expected 2 users in group 'parent', found %d", len(group.Users))
+			}
+		}
+	}
+}
+
+func TestKeycloakSyncGroupMembersError(t *testing.T) {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("/auth/admin/realms/%s/groups", fakeKeycloakRealm), func(w http.ResponseWriter, r *http.Request) {
+		writePage(t, w, r, []*gocloak.Group{{ID: gocloak.StringP("group"), Name: gocloak.StringP("group"), Path: gocloak.StringP("/group")}}, 100)
+	})
+	mux.HandleFunc(fmt.Sprintf("/auth/admin/realms/%s/groups/group/members", fakeKeycloakRealm), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// The group must not be synchronized without its members
+	if groups, err := newFakeKeycloakSyncer(server.URL).Sync(); err == nil {
+		t.Fatalf("expected an error synchronizing groups, found %d groups", len(groups))
+	}
+}
+
+func TestKeycloakGetRoleMembersServerPageSize(t *testing.T) {
+
+	users := []*gocloak.User{}
+	for i := 0; i < 120; i++ {
+		users = append(users, &gocloak.User{ID: gocloak.StringP(fmt.Sprintf("user-%d", i)), Username: gocloak.StringP(fmt.Sprintf("user%d", i))})
+	}
+
+	// The server returns fewer users than requested on every page
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("/auth/admin/realms/%s/roles/admin/users", fakeKeycloakRealm), func(w http.ResponseWriter, r *http.Request) {
+		writePage(t, w, r, users, 50)
+	})
+	mux.HandleFunc(fmt.Sprintf("/auth/admin/realms/%s/roles/admin/groups", fakeKeycloakRealm), func(w http.ResponseWriter, r *http.Request) {
+		writePage(t, w, r, []*gocloak.Group{}, 50)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	members, err := newFakeKeycloakSyncer(server.URL).getRoleMembers(fakeKeycloakRealm, "roles", "admin")
+
+	if err != nil {
+		t.Fatalf("unexpected error retrieving role members: %v", err)
+	}
+
+	if len(members) != 120 {
+		t.Fatalf("expected 120 role members, found %d", len(members))
+	}
+}
+
+func TestKeycloakGetGroupsServerPageSize(t *testing.T) {
+
+	groups := []*gocloak.Group{}
+	for i := 0; i < 120; i++ {
+		groups = append(groups, &gocloak.Group{ID: gocloak.StringP(fmt.Sprintf("group-%d", i)), Name: gocloak.StringP(fmt.Sprintf("group%d", i))})
+	}
+
+	// The server returns fewer groups than requested on every page
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("/auth/admin/realms/%s/groups", fakeKeycloakRealm), func(w http.ResponseWriter, r *http.Request) {
+		writePage(t, w, r, groups, 50)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	retrievedGroups, err := newFakeKeycloakSyncer(server.URL).getGroups(fakeKeycloakRealm)
+
+	if err != nil {
+		t.Fatalf("unexpected error retrieving groups: %v", err)
+	}
+
+	if len(retrievedGroups) != 120 {
+		t.Fatalf("expected 120 groups, found %d", len(retrievedGroups))
+	}
+}
+
+func TestKeycloakBindInsecureWithCaAndClientCertificate(t *testing.T) {
+
+	server := httptest.NewUnstartedServer(newFakeKeycloakHandler(t, 1, fakeKeycloakAccessToken, 100, "/auth"))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	clientCertificate := server.TLS.Certificates[0]
+
+	keycloakSyncer := newFakeKeycloakSyncer(server.URL)
+	keycloakSyncer.Provider.Insecure = true
+	// The CA does not contain the certificate of the server, which is only accepted as verification is disabled
+	keycloakSyncer.CaCertificate = []byte("not a certificate")
+	keycloakSyncer.ClientCertificate = &clientCertificate
+
+	if err := keycloakSyncer.Bind(); err != nil {
+		t.Fatalf("unexpected error binding to Keycloak: %v", err)
+	}
+}
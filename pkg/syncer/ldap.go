@@ -2,12 +2,16 @@ package syncer
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	legacyconfigv1 "github.com/openshift/api/legacyconfig/v1"
 	userv1 "github.com/openshift/api/user/v1"
@@ -22,7 +26,6 @@ import (
 	"github.com/redhat-cop/operator-utils/pkg/util"
 	"gopkg.in/ldap.v2"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -39,17 +42,24 @@ type LdapSyncer struct {
 	ReconcilerBase    util.ReconcilerBase
 	Context           context.Context
 	CredentialsSecret *corev1.Secret
+	CredentialsSource *redhatcopv1alpha1.CredentialsSource
+	CredentialsKeys   map[string]string
+	MetadataMappings  []redhatcopv1alpha1.MetadataMapping
 	URL               *url.URL
 	CaCertificate     []byte
+	CaBundles         []*redhatcopv1alpha1.ConfigMapRef
 	CaCertificateFile string
 	Whitelist         []string
 	Blacklist         []string
 	Syncer            *syncgroups.LDAPGroupSyncer
+	excludedGroups    *GroupPatterns
 }
 
 func (l *LdapSyncer) Init() bool {
 
-	l.Context = context.Background()
+	if l.Context == nil {
+		l.Context = context.Background()
+	}
 
 	if l.Provider.Whitelist == nil {
 		l.Whitelist = []string{}
@@ -69,9 +79,8 @@ func (l *LdapSyncer) Init() bool {
 func (l *LdapSyncer) Validate() error {
 	validationErrors := []error{}
 
-	if l.Provider.CredentialsSecret != nil {
-		credentialsSecret := &corev1.Secret{}
-		err := l.ReconcilerBase.GetClient().Get(l.Context, types.NamespacedName{Name: l.Provider.CredentialsSecret.Name, Namespace: l.Provider.CredentialsSecret.Namespace}, credentialsSecret)
+	if l.Provider.CredentialsSecret != nil || l.CredentialsSource != nil {
+		credentialsSecret, _, err := getCredentials(l.Context, l.ReconcilerBase.GetClient(), l.CredentialsSource, l.CredentialsKeys, l.Provider.CredentialsSecret)
 
 		if err != nil {
 			validationErrors = append(validationErrors, err)
@@ -105,6 +114,17 @@ func (l *LdapSyncer) Validate() error {
 		l.CaCertificate = caResource[resourceCaKey]
 	}
 
+	// CA bundles are only trusted for secure connections as a CA certificate cannot be specified along with insecure=true
+	if !l.Provider.Insecure {
+		caBundles, err := getCaBundles(l.Context, l.ReconcilerBase.GetClient(), l.CaBundles)
+
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		} else {
+			l.CaCertificate = appendCaBundles(l.CaCertificate, caBundles)
+		}
+	}
+
 	if l.Provider.URL == nil {
 		validationErrors = append(validationErrors, fmt.Errorf("LDAP URL must be provided"))
 	} else {
@@ -163,6 +183,11 @@ func (l *LdapSyncer) Validate() error {
 		validationErrors = append(validationErrors, field.Required(field.NewPath("schema"), fmt.Sprintf("exactly one schema-specific config is required;  one of %v", []string{"rfc2307", "activeDirectory", "augmentedActiveDirectory"})))
 	}
 
+	var groupPatternErrors []error
+
+	l.excludedGroups, groupPatternErrors = compileGroupPatterns("excludedGroups", l.Provider.ExcludedGroups)
+	validationErrors = append(validationErrors, groupPatternErrors...)
+
 	return utilerrors.NewAggregate(validationErrors)
 }
 
@@ -196,6 +221,9 @@ func (l *LdapSyncer) Bind() error {
 
 	errorHandler := l.CreateErrorHandler()
 
+	// Connections to the server are bounded by the deadline of the context of the syncer, such as the timeout of the provider
+	clientConfig = &ldapContextClientConfig{Config: clientConfig, context: l.Context, scheme: l.URL.Scheme, caCertificate: l.CaCertificate, insecure: l.Provider.Insecure}
+
 	syncBuilder, err := buildSyncBuilder(clientConfig, l.Provider, errorHandler)
 	if err != nil {
 		return err
@@ -242,6 +270,16 @@ func (l *LdapSyncer) Sync() ([]userv1.Group, error) {
 
 	if len(syncErrors) == 0 {
 		for _, group := range openshiftGroups {
+			if l.excludedGroups.Matches(group.Name) {
+				continue
+			}
+
+			// Groups are identified by the UID of the LDAP entry, such as its distinguished name
+			applyMetadataMappings(l.Context, group, l.MetadataMappings, map[string]string{
+				MetadataID:  group.Annotations[syncgroups.LDAPUIDAnnotation],
+				MetadataURL: group.Annotations[syncgroups.LDAPURLAnnotation],
+			})
+
 			ocpGroups = append(ocpGroups, *group)
 		}
 	}
@@ -440,7 +478,7 @@ func (l *LdapSyncer) GetGroupNameMappings() map[string]string {
 
 func (l *LdapSyncer) getLdapCredentialValue(key string) string {
 
-	if l.Provider.CredentialsSecret != nil {
+	if l.CredentialsSecret != nil {
 		if value, ok := l.CredentialsSecret.Data[key]; ok {
 			return string(value)
 		}
@@ -452,3 +490,73 @@ func (l *LdapSyncer) getLdapCredentialValue(key string) string {
 func (l *LdapSyncer) GetPrune() bool {
 	return l.Provider.Prune
 }
+
+// ldapContextClientConfig connects to the LDAP server within the deadline of a context. Dialing the server and negotiating TLS give up
+// once the deadline is exceeded and each request made on the connection times out at the deadline
+type ldapContextClientConfig struct {
+	ldapclient.Config
+	context       context.Context
+	scheme        string
+	caCertificate []byte
+	insecure      bool
+}
+
+func (c *ldapContextClientConfig) Connect() (ldap.Client, error) {
+
+	host := c.Host()
+	serverName := host
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		serverName = hostname
+	}
+
+	tlsConfig := &tls.Config{ServerName: serverName}
+	if len(c.caCertificate) > 0 {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(c.caCertificate) {
+			return nil, fmt.Errorf("Could not parse the CA certificate of LDAP server '%s'", host)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	dialer := &net.Dialer{Timeout: ldap.DefaultTimeout}
+	conn, err := dialer.DialContext(c.context, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, hasDeadline := c.context.Deadline()
+
+	isTLS := c.scheme == string(ldaputil.SchemeLDAPS)
+	if isTLS {
+		if hasDeadline {
+			conn.SetDeadline(deadline)
+		}
+
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		// The deadline of the connection only bounds the handshake, requests are bounded by the timeout of the connection
+		tlsConn.SetDeadline(time.Time{})
+		conn = tlsConn
+	}
+
+	ldapConn := ldap.NewConn(conn, isTLS)
+	ldapConn.Start()
+
+	if hasDeadline {
+		ldapConn.SetTimeout(time.Until(deadline))
+	}
+
+	// Connections are upgraded to TLS unless an insecure connection is desired
+	if !isTLS && !c.insecure {
+		if err := ldapConn.StartTLS(tlsConfig); err != nil {
+			ldapConn.Close()
+			return nil, err
+		}
+	}
+
+	return ldapConn, nil
+}
@@ -0,0 +1,61 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/openshift/library-go/pkg/security/ldapclient"
+)
+
+func TestLdapContextClientConfigDeadline(t *testing.T) {
+
+	// Server accepting connections without ever responding, such as an LDAP server holding connections open
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	clientConfig, err := ldapclient.NewLDAPClientConfig(fmt.Sprintf("ldap://%s", listener.Addr().String()), "", "", "", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	timeoutContext, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	contextClientConfig := &ldapContextClientConfig{Config: clientConfig, context: timeoutContext, scheme: "ldap", insecure: true}
+
+	conn, err := contextClientConfig.Connect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- conn.Bind("cn=admin", "password")
+	}()
+
+	select {
+	case err := <-result:
+		if err == nil {
+			t.Errorf("expected binding to an unresponsive server to fail")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected binding to an unresponsive server to time out at the deadline of the context")
+	}
+}
@@ -0,0 +1,117 @@
+package syncer
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// syncLoggingKey is the key of the logging configuration of a synchronization in contexts
+type syncLoggingKey struct{}
+
+// syncLogging configures the lines logged by syncers during a synchronization. The values, such as the ID of the synchronization, are
+// attached to each line and the verbosity of the provider, when set, overrides the verbosity of the operator
+type syncLogging struct {
+	values    []interface{}
+	verbosity *int
+}
+
+// WithSyncLogValues returns a context attaching the values to each line logged by the syncers created with the context
+func WithSyncLogValues(parent context.Context, keysAndValues ...interface{}) context.Context {
+
+	logging := getSyncLogging(parent)
+	logging.values = append(append([]interface{}{}, logging.values...), keysAndValues...)
+
+	return context.WithValue(parent, syncLoggingKey{}, &logging)
+}
+
+// withProviderLogLevel returns a context overriding the verbosity of the operator with the log level of the provider. The context is
+// returned unchanged when the provider has no log level
+func withProviderLogLevel(parent context.Context, provider *redhatcopv1alpha1.Provider) context.Context {
+
+	if provider.LogLevel == "" {
+		return parent
+	}
+
+	verbosity := getLogLevelVerbosity(provider.LogLevel)
+
+	logging := getSyncLogging(parent)
+	logging.verbosity = &verbosity
+
+	return context.WithValue(parent, syncLoggingKey{}, &logging)
+}
+
+// getSyncLogging returns a copy of the logging configuration of the context
+func getSyncLogging(parent context.Context) syncLogging {
+
+	if parent == nil {
+		return syncLogging{}
+	}
+
+	if logging, found := parent.Value(syncLoggingKey{}).(*syncLogging); found {
+		return *logging
+	}
+
+	return syncLogging{}
+}
+
+// syncLogger returns the logger of a syncer configured according to the logging configuration of the context
+func syncLogger(parent context.Context, logger logr.Logger) logr.Logger {
+
+	logging := getSyncLogging(parent)
+
+	if len(logging.values) > 0 {
+		logger = logger.WithValues(logging.values...)
+	}
+
+	if logging.verbosity != nil {
+		logger = &verbosityLogger{Logger: logger, maxVerbosity: *logging.verbosity}
+	}
+
+	return logger
+}
+
+// getLogLevelVerbosity returns the verbosity of the lines logged at the log level. Only errors are logged below a verbosity of 0
+func getLogLevelVerbosity(logLevel redhatcopv1alpha1.LogLevel) int {
+
+	switch logLevel {
+	case redhatcopv1alpha1.ErrorLogLevel:
+		return -1
+	case redhatcopv1alpha1.DebugLogLevel:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// verbosityLogger logs the informational messages up to the maximum verbosity regardless of the verbosity of the underlying logger,
+// so that debugging messages of a provider can be logged without increasing the verbosity of the operator. Errors are always logged
+type verbosityLogger struct {
+	logr.Logger
+	verbosity    int
+	maxVerbosity int
+}
+
+func (l *verbosityLogger) Enabled() bool {
+	return l.verbosity <= l.maxVerbosity
+}
+
+func (l *verbosityLogger) Info(msg string, keysAndValues ...interface{}) {
+	if l.Enabled() {
+		l.Logger.Info(msg, keysAndValues...)
+	}
+}
+
+func (l *verbosityLogger) V(level int) logr.Logger {
+	return &verbosityLogger{Logger: l.Logger, verbosity: l.verbosity + level, maxVerbosity: l.maxVerbosity}
+}
+
+func (l *verbosityLogger) WithValues(keysAndValues ...interface{}) logr.Logger {
+	return &verbosityLogger{Logger: l.Logger.WithValues(keysAndValues...), verbosity: l.verbosity, maxVerbosity: l.maxVerbosity}
+}
+
+func (l *verbosityLogger) WithName(name string) logr.Logger {
+	return &verbosityLogger{Logger: l.Logger.WithName(name), verbosity: l.verbosity, maxVerbosity: l.maxVerbosity}
+}
@@ -0,0 +1,267 @@
+package syncer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	mappingWebhookLogger = logf.Log.WithName("syncer_mapping_webhook")
+)
+
+const (
+	defaultMappingWebhookTimeout = 30 * time.Second
+)
+
+// MappingWebhookGroup is the representation of a group exchanged with a mapping webhook
+type MappingWebhookGroup struct {
+	Name        string            `json:"name"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Users       []string          `json:"users"`
+}
+
+// MappingWebhookRequest is the payload sent to a mapping webhook
+type MappingWebhookRequest struct {
+	Provider string                `json:"provider"`
+	Groups   []MappingWebhookGroup `json:"groups"`
+}
+
+// MappingWebhookResponse is the payload expected from a mapping webhook
+type MappingWebhookResponse struct {
+	Groups []MappingWebhookGroup `json:"groups"`
+}
+
+// MappingWebhookSyncer decorates a GroupSyncer by passing the synchronized groups through an external mapping webhook
+type MappingWebhookSyncer struct {
+	GroupSyncer
+	MappingWebhook *redhatcopv1alpha1.MappingWebhook
+	ReconcilerBase util.ReconcilerBase
+	Context        context.Context
+	Token          []byte
+	CaCertificate  []byte
+}
+
+func (m *MappingWebhookSyncer) Init() bool {
+
+	if m.Context == nil {
+		m.Context = context.Background()
+	}
+
+	return m.GroupSyncer.Init()
+}
+
+func (m *MappingWebhookSyncer) Validate() error {
+
+	validationErrors := []error{}
+
+	if err := m.GroupSyncer.Validate(); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	if _, err := url.ParseRequestURI(m.MappingWebhook.URL); err != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("Invalid Mapping Webhook URL: '%s'", m.MappingWebhook.URL))
+	}
+
+	if m.MappingWebhook.CredentialsSecret != nil {
+
+		credentialsResource, err := getObjectRefData(m.Context, m.ReconcilerBase.GetClient(), m.MappingWebhook.CredentialsSecret)
+
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+
+		tokenKey := secretTokenKey
+		if m.MappingWebhook.CredentialsSecret.Key != "" {
+			tokenKey = m.MappingWebhook.CredentialsSecret.Key
+		}
+
+		if _, found := credentialsResource[tokenKey]; !found {
+			validationErrors = append(validationErrors, fmt.Errorf("Could not find '%s' key in %s '%s' in namespace '%s", tokenKey, m.MappingWebhook.CredentialsSecret.Kind, m.MappingWebhook.CredentialsSecret.Name, m.MappingWebhook.CredentialsSecret.Namespace))
+		}
+
+		m.Token = credentialsResource[tokenKey]
+	}
+
+	if m.MappingWebhook.Ca != nil {
+
+		caResource, err := getObjectRefData(m.Context, m.ReconcilerBase.GetClient(), m.MappingWebhook.Ca)
+
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+
+		resourceCaKey := defaultResourceCaKey
+		if m.MappingWebhook.Ca.Key != "" {
+			resourceCaKey = m.MappingWebhook.Ca.Key
+		}
+
+		// Certificate key validation
+		if _, found := caResource[resourceCaKey]; !found {
+			validationErrors = append(validationErrors, fmt.Errorf("Could not find '%s' key in %s '%s' in namespace '%s", resourceCaKey, m.MappingWebhook.Ca.Kind, m.MappingWebhook.Ca.Name, m.MappingWebhook.Ca.Namespace))
+		}
+
+		m.CaCertificate = caResource[resourceCaKey]
+	}
+
+	return utilerrors.NewAggregate(validationErrors)
+}
+
+func (m *MappingWebhookSyncer) Sync() ([]userv1.Group, error) {
+
+	groups, err := m.GroupSyncer.Sync()
+
+	if err != nil {
+		return nil, err
+	}
+
+	mappedGroups, err := m.mapGroups(groups)
+
+	if err != nil {
+		syncLogger(m.Context, mappingWebhookLogger).Error(err, "Failed to map groups using mapping webhook", "Provider", m.GetProviderName(), "URL", m.MappingWebhook.URL)
+		return nil, err
+	}
+
+	return mappedGroups, nil
+}
+
+func (m *MappingWebhookSyncer) mapGroups(groups []userv1.Group) ([]userv1.Group, error) {
+
+	mappingRequest := MappingWebhookRequest{
+		Provider: m.GetProviderName(),
+		Groups:   []MappingWebhookGroup{},
+	}
+
+	for _, group := range groups {
+		mappingRequest.Groups = append(mappingRequest.Groups, MappingWebhookGroup{
+			Name:        group.Name,
+			Annotations: group.GetAnnotations(),
+			Labels:      group.GetLabels(),
+			Users:       group.Users,
+		})
+	}
+
+	body, err := json.Marshal(mappingRequest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, m.MappingWebhook.URL, bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	request = request.WithContext(m.Context)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+
+	if len(m.Token) > 0 {
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", string(m.Token)))
+	}
+
+	response, err := m.getHTTPClient().Do(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("Mapping webhook returned unexpected status code %d: %s", response.StatusCode, string(responseBody))
+	}
+
+	mappingResponse := MappingWebhookResponse{}
+
+	if err := json.Unmarshal(responseBody, &mappingResponse); err != nil {
+		return nil, err
+	}
+
+	ocpGroups := []userv1.Group{}
+
+	for _, mappedGroup := range mappingResponse.Groups {
+
+		if mappedGroup.Name == "" {
+			syncLogger(m.Context, mappingWebhookLogger).Info("Warning: Skipping Group record with empty name returned by mapping webhook", "Provider", m.GetProviderName())
+			continue
+		}
+
+		ocpGroup := userv1.Group{
+			TypeMeta: v1.TypeMeta{
+				Kind:       "Group",
+				APIVersion: userv1.GroupVersion.String(),
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name:        mappedGroup.Name,
+				Annotations: map[string]string{},
+				Labels:      map[string]string{},
+			},
+			Users: []string{},
+		}
+
+		for key, value := range mappedGroup.Annotations {
+			ocpGroup.GetAnnotations()[key] = value
+		}
+
+		for key, value := range mappedGroup.Labels {
+			ocpGroup.GetLabels()[key] = value
+		}
+
+		ocpGroup.Users = append(ocpGroup.Users, mappedGroup.Users...)
+
+		ocpGroups = append(ocpGroups, ocpGroup)
+	}
+
+	return ocpGroups, nil
+}
+
+func (m *MappingWebhookSyncer) getHTTPClient() *http.Client {
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if m.MappingWebhook.Insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	} else if len(m.CaCertificate) > 0 {
+		tlsConfig := &tls.Config{
+			RootCAs: x509.NewCertPool(),
+		}
+
+		tlsConfig.RootCAs.AppendCertsFromPEM(m.CaCertificate)
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	timeout := defaultMappingWebhookTimeout
+	if m.MappingWebhook.Timeout != nil {
+		timeout = m.MappingWebhook.Timeout.Duration
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+}
@@ -0,0 +1,108 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"k8s.io/apimachinery/pkg/util/validation"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	metadataLogger = logf.Log.WithName("syncer_metadata")
+)
+
+// Metadata fields exposed by the providers. The name and the identifier of the group are exposed by every provider
+const (
+	MetadataName        = "name"
+	MetadataID          = "id"
+	MetadataDescription = "description"
+	MetadataEmail       = "email"
+	MetadataPath        = "path"
+	MetadataURL         = "url"
+)
+
+// applyMetadataMappings sets the labels and annotations of the group from the metadata of the group in the provider. Metadata fields
+// that are not exposed or are empty are ignored, as are values that are not valid label values, which are logged according to the logging
+// configuration of the context
+func applyMetadataMappings(context context.Context, ocpGroup *userv1.Group, metadataMappings []redhatcopv1alpha1.MetadataMapping, metadata map[string]string) {
+
+	if len(metadataMappings) == 0 {
+		return
+	}
+
+	values := map[string]string{
+		MetadataName: ocpGroup.Name,
+		MetadataID:   ocpGroup.GetAnnotations()[constants.SyncSourceUID],
+	}
+
+	for key, value := range metadata {
+		values[key] = value
+	}
+
+	if ocpGroup.Labels == nil {
+		ocpGroup.Labels = map[string]string{}
+	}
+
+	if ocpGroup.Annotations == nil {
+		ocpGroup.Annotations = map[string]string{}
+	}
+
+	for _, metadataMapping := range metadataMappings {
+
+		value, found := values[metadataMapping.Source]
+
+		if !found || value == "" {
+			continue
+		}
+
+		if metadataMapping.Target == redhatcopv1alpha1.LabelAttributeMappingTarget {
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				syncLogger(context, metadataLogger).Info("unable to add label to", "group", ocpGroup.Name, "key", metadataMapping.Name, "value", value)
+				continue
+			}
+
+			ocpGroup.Labels[metadataMapping.Name] = value
+		} else {
+			ocpGroup.Annotations[metadataMapping.Name] = value
+		}
+	}
+}
+
+// getMetadataSources returns the names of the metadata fields referenced by the mappings
+func getMetadataSources(metadataMappings []redhatcopv1alpha1.MetadataMapping) []string {
+
+	sources := []string{}
+
+	for _, metadataMapping := range metadataMappings {
+		if !containsString(sources, metadataMapping.Source) {
+			sources = append(sources, metadataMapping.Source)
+		}
+	}
+
+	return sources
+}
+
+// validateMetadataMappings verifies that each mapping references a metadata field and that the keys of the labels and annotations are
+// valid
+func validateMetadataMappings(providerName string, metadataMappings []redhatcopv1alpha1.MetadataMapping) []error {
+
+	validationErrors := []error{}
+
+	for _, metadataMapping := range metadataMappings {
+
+		if metadataMapping.Source == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("Metadata mapping '%s' of provider '%s' does not specify a source", metadataMapping.Name, providerName))
+		}
+
+		if errs := validation.IsQualifiedName(metadataMapping.Name); len(errs) > 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid metadata mapping name '%s' of provider '%s': %s", metadataMapping.Name, providerName, strings.Join(errs, ", ")))
+		}
+	}
+
+	return validationErrors
+}
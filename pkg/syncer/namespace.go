@@ -0,0 +1,98 @@
+package syncer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	defaultGroupNamespaceTemplate    = "{{ .Group }}"
+	defaultGroupNamespaceClusterRole = "admin"
+)
+
+// GroupNamespaceTemplateData is the data available to the template rendering the names of the namespaces of groups
+type GroupNamespaceTemplateData struct {
+	Group    string
+	Provider string
+}
+
+// GroupNamespaceRenderer renders the names of the namespaces created for synchronized groups
+type GroupNamespaceRenderer struct {
+	GroupNamespaces *redhatcopv1alpha1.GroupNamespaces
+	template        *template.Template
+	groups          *GroupPatterns
+}
+
+// NewGroupNamespaceRenderer parses the name template and compiles the groups of the group namespaces
+func NewGroupNamespaceRenderer(groupNamespaces *redhatcopv1alpha1.GroupNamespaces) (*GroupNamespaceRenderer, error) {
+
+	nameTemplate, err := parseGroupNamespaceTemplate(groupNamespaces.NameTemplate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &GroupNamespaceRenderer{GroupNamespaces: groupNamespaces, template: nameTemplate, groups: NewGroupPatterns(groupNamespaces.Groups)}, nil
+}
+
+// GetNamespaceName returns the name of the namespace of the group synchronized by the provider. No namespace is created for groups not
+// matching the groups of the group namespaces
+func (g *GroupNamespaceRenderer) GetNamespaceName(groupName string, providerName string) (string, bool, error) {
+
+	if !g.groups.Allows(groupName) {
+		return "", false, nil
+	}
+
+	var namespaceName bytes.Buffer
+
+	if err := g.template.Execute(&namespaceName, GroupNamespaceTemplateData{Group: groupName, Provider: providerName}); err != nil {
+		return "", false, fmt.Errorf("Failed to render namespace name template for group '%s': %s", groupName, err)
+	}
+
+	name := strings.TrimSpace(namespaceName.String())
+
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return "", false, fmt.Errorf("Invalid namespace name '%s' rendered for group '%s': %s", name, groupName, strings.Join(errs, ", "))
+	}
+
+	return name, true, nil
+}
+
+// GetClusterRole returns the ClusterRole bound to the groups in their namespaces
+func (g *GroupNamespaceRenderer) GetClusterRole() string {
+
+	if g.GroupNamespaces.ClusterRole == "" {
+		return defaultGroupNamespaceClusterRole
+	}
+
+	return g.GroupNamespaces.ClusterRole
+}
+
+func parseGroupNamespaceTemplate(nameTemplate string) (*template.Template, error) {
+
+	if nameTemplate == "" {
+		nameTemplate = defaultGroupNamespaceTemplate
+	}
+
+	return template.New("namespaceName").Funcs(sprig.TxtFuncMap()).Option("missingkey=zero").Parse(nameTemplate)
+}
+
+func (m *GroupSyncMgr) validateGroupNamespaces() []error {
+	validationErrors := []error{}
+
+	groupNamespaces := m.GroupSync.Spec.GroupNamespaces
+
+	if _, err := parseGroupNamespaceTemplate(groupNamespaces.NameTemplate); err != nil {
+		validationErrors = append(validationErrors, fmt.Errorf("Invalid namespace name template: %s", err))
+	}
+
+	validationErrors = append(validationErrors, validateGroupPatterns("groups of group namespaces", groupNamespaces.Groups)...)
+
+	return validationErrors
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 	"sync"
 
+	"github.com/go-logr/logr"
 	"github.com/okta/okta-sdk-golang/v2/okta/query"
 
 	"github.com/okta/okta-sdk-golang/v2/okta"
@@ -18,7 +20,6 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -38,14 +39,26 @@ type OktaSyncer struct {
 	cachedGroups       map[string]*okta.Group
 	cachedGroupMembers map[string][]*okta.User
 	credentialsSecret  *corev1.Secret
+	CredentialsSource  *v1alpha1.CredentialsSource
+	CredentialsKeys    map[string]string
+	MetadataMappings   []v1alpha1.MetadataMapping
+	RateLimit          *v1alpha1.RateLimit
 	goOkta             *okta.Client
 	GroupSync          *v1alpha1.GroupSync
 	Name               string
 	Provider           *v1alpha1.OktaProvider
 	ReconcilerBase     util.ReconcilerBase
+	Context            context.Context
+	groups             *GroupPatterns
+	excludedGroups     *GroupPatterns
 }
 
 func (o *OktaSyncer) Init() bool {
+
+	if o.Context == nil {
+		o.Context = context.Background()
+	}
+
 	o.cachedGroupMembers = make(map[string][]*okta.User)
 	o.cachedGroups = make(map[string]*okta.Group)
 
@@ -64,13 +77,13 @@ func (o *OktaSyncer) Init() bool {
 func (o *OktaSyncer) Validate() error {
 	const validations = 2
 	validationErrors := make([]error, validations)
-	credentialsSecret, err := o.getSecrets()
+	credentialsSecret, credentialsDescription, err := getCredentials(context.TODO(), o.ReconcilerBase.GetClient(), o.CredentialsSource, o.CredentialsKeys, o.Provider.CredentialsSecret)
 
 	if err != nil {
 		validationErrors = append(validationErrors, err)
 	} else {
 		if _, found := credentialsSecret.Data[secretOktaTokenKey]; !found {
-			validationErrors = append(validationErrors, fmt.Errorf("could not find api token '%s' in namespace '%s", o.Provider.CredentialsSecret.Name, o.Provider.CredentialsSecret.Namespace))
+			validationErrors = append(validationErrors, fmt.Errorf("could not find api token in %s", credentialsDescription))
 		}
 
 		o.credentialsSecret = credentialsSecret
@@ -80,27 +93,31 @@ func (o *OktaSyncer) Validate() error {
 		validationErrors = append(validationErrors, err)
 	}
 
-	return utilerrors.NewAggregate(validationErrors)
-}
+	var groupPatternErrors []error
 
-func (o OktaSyncer) getSecrets() (*corev1.Secret, error) {
-	credentialsSecret := &corev1.Secret{}
-	nameSpacedName := types.NamespacedName{
-		Name:      o.Provider.CredentialsSecret.Name,
-		Namespace: o.Provider.CredentialsSecret.Namespace,
-	}
-	err := o.ReconcilerBase.GetClient().Get(context.TODO(), nameSpacedName, credentialsSecret)
-	return credentialsSecret, err
+	o.groups, groupPatternErrors = compileGroupPatterns("groups", o.Provider.Groups)
+	validationErrors = append(validationErrors, groupPatternErrors...)
+	o.excludedGroups, groupPatternErrors = compileGroupPatterns("excludedGroups", o.Provider.ExcludedGroups)
+	validationErrors = append(validationErrors, groupPatternErrors...)
+
+	return utilerrors.NewAggregate(validationErrors)
 }
 
 func (o *OktaSyncer) Bind() error {
 	var err error
 
-	_, o.goOkta, err = okta.NewClient(context.TODO(),
+	configSetters := []okta.ConfigSetter{
 		okta.WithOrgUrl(o.Provider.URL),
-		okta.WithToken(string(o.credentialsSecret.Data[secretOktaTokenKey])))
+		okta.WithToken(string(o.credentialsSecret.Data[secretOktaTokenKey])),
+	}
+
+	if o.RateLimit != nil {
+		configSetters = append(configSetters, okta.WithHttpClient(http.Client{Transport: newRateLimitedTransport(nil, o.RateLimit)}))
+	}
+
+	_, o.goOkta, err = okta.NewClient(context.TODO(), configSetters...)
 	if err != nil {
-		oktaLogger.Error(err, "establishing new okta client")
+		syncLogger(o.Context, oktaLogger).Error(err, "establishing new okta client")
 		return err
 	}
 
@@ -111,14 +128,14 @@ func (o *OktaSyncer) Sync() ([]userv1.Group, error) {
 
 	groups, err := o.getGroups()
 	if err != nil {
-		oktaLogger.Error(err, "failed to get Groups", "Provider", o.Name)
+		syncLogger(o.Context, oktaLogger).Error(err, "failed to get Groups", "Provider", o.Name)
 		return nil, err
 	}
 
 	for _, group := range groups {
 		if _, groupFound := o.cachedGroups[group.Id]; !groupFound {
 			if err := o.processGroupsAndMembers(group); err != nil {
-				oktaLogger.Error(err, "processing groups and members")
+				syncLogger(o.Context, oktaLogger).Error(err, "processing groups and members")
 			}
 		}
 	}
@@ -134,7 +151,7 @@ func (o *OktaSyncer) Sync() ([]userv1.Group, error) {
 		groupAttributes := o.mapAttributes(cachedGroup)
 		for key, value := range groupAttributes {
 			if errs := validation.IsQualifiedName(key); len(errs) != 0 {
-				oktaLogger.Info("unable to add annotation to", "group", cachedGroup.Profile.Name, "key", key, "value", value)
+				syncLogger(o.Context, oktaLogger).Info("unable to add annotation to", "group", cachedGroup.Profile.Name, "key", key, "value", value)
 			} else {
 				validatedGroupAttr[key] = value
 			}
@@ -156,12 +173,14 @@ func (o *OktaSyncer) Sync() ([]userv1.Group, error) {
 		ocpGroup.GetAnnotations()[constants.SyncSourceHost] = providerUrl.Host
 		ocpGroup.GetAnnotations()[constants.SyncSourceUID] = cachedGroup.Id
 
+		applyMetadataMappings(o.Context, &ocpGroup, o.MetadataMappings, groupAttributes)
+
 		users := o.cachedGroupMembers[cachedGroup.Id]
 		for _, user := range users {
 			profile := *user.Profile
 			if user.Status == activeStatus {
 				if userName, ok := profile[o.Provider.ProfileKey].(string); !ok {
-					oktaLogger.Info("attribute unavailable on okta user profile " + o.Provider.ProfileKey)
+					syncLogger(o.Context, oktaLogger).Info("attribute unavailable on okta user profile " + o.Provider.ProfileKey)
 				} else if o.Provider.ExtractLoginUsername {
 					userName = strings.Split(userName, "@")[0]
 					ocpGroup.Users = append(ocpGroup.Users, userName)
@@ -184,7 +203,7 @@ func (o OktaSyncer) getGroups() ([]*okta.Group, error) {
 	appGroups, resp, err := o.goOkta.Application.ListApplicationGroupAssignments(context.TODO(), o.Provider.AppId, query.NewQueryParams(query.WithLimit(int64(o.Provider.GroupLimit))))
 
 	if err != nil {
-		oktaLogger.Error(err, "getting groups for specified application")
+		syncLogger(o.Context, oktaLogger).Error(err, "getting groups for specified application")
 		return nil, err
 	}
 
@@ -193,7 +212,7 @@ func (o OktaSyncer) getGroups() ([]*okta.Group, error) {
 		resp, err = resp.Next(context.TODO(), &nextAppGroups)
 
 		if err != nil {
-			oktaLogger.Error(err, "getting groups for specified application")
+			syncLogger(o.Context, oktaLogger).Error(err, "getting groups for specified application")
 			return nil, err
 		}
 
@@ -210,7 +229,7 @@ func (o OktaSyncer) fetchGroupsAsync(appGroups []*okta.ApplicationGroupAssignmen
 	groupCh := make(chan *okta.Group, len(appGroups))
 	wg.Add(len(appGroups))
 	for _, appGroup := range appGroups {
-		go getGroup(appGroup, groupCh, o.goOkta.Group, wg)
+		go getGroup(appGroup, groupCh, o.goOkta.Group, wg, syncLogger(o.Context, oktaLogger))
 	}
 
 	wg.Wait()
@@ -228,11 +247,11 @@ func (o OktaSyncer) fetchGroupsAsync(appGroups []*okta.ApplicationGroupAssignmen
 	return groups, nil
 }
 
-func getGroup(app *okta.ApplicationGroupAssignment, groupChan chan *okta.Group, resource *okta.GroupResource, wg *sync.WaitGroup) {
+func getGroup(app *okta.ApplicationGroupAssignment, groupChan chan *okta.Group, resource *okta.GroupResource, wg *sync.WaitGroup, logger logr.Logger) {
 	defer wg.Done()
 	group, _, err := resource.GetGroup(context.TODO(), app.Id)
 	if err != nil {
-		oktaLogger.Error(err, "fetching group id "+app.Id)
+		logger.Error(err, "fetching group id "+app.Id)
 	} else {
 		groupChan <- group
 	}
@@ -255,17 +274,30 @@ func (o OktaSyncer) mapAttributes(group *okta.Group) map[string]string {
 
 func (o *OktaSyncer) processGroupsAndMembers(group *okta.Group) error {
 
-	if !isGroupAllowed(group.Profile.Name, o.Provider.Groups) {
+	if !isGroupSelected(group.Profile.Name, o.groups, o.excludedGroups) {
 		return nil
 	}
 
 	o.cachedGroups[group.Id] = group
-	users, _, err := o.goOkta.Group.ListGroupUsers(context.TODO(), group.Id, nil)
+	users, resp, err := o.goOkta.Group.ListGroupUsers(context.TODO(), group.Id, nil)
 	if err != nil {
-		oktaLogger.Error(err, "failed to get users", "Provider", o.Name)
+		syncLogger(o.Context, oktaLogger).Error(err, "failed to get users", "Provider", o.Name)
 		return err
 	}
 
+	// Members of large groups are returned in several pages
+	for resp.HasNextPage() {
+		var nextUsers []*okta.User
+		resp, err = resp.Next(context.TODO(), &nextUsers)
+
+		if err != nil {
+			syncLogger(o.Context, oktaLogger).Error(err, "failed to get users", "Provider", o.Name)
+			return err
+		}
+
+		users = append(users, nextUsers...)
+	}
+
 	o.cachedGroupMembers[group.Id] = users
 	return nil
 }
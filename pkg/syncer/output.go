@@ -0,0 +1,33 @@
+package syncer
+
+import (
+	"fmt"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+func (m *GroupSyncMgr) validateOutput() []error {
+	validationErrors := []error{}
+
+	if m.GroupSync.Spec.Output != redhatcopv1alpha1.ConfigMapGroupOutput {
+		return validationErrors
+	}
+
+	if m.GroupSync.Spec.RoleBindingsOnly {
+		validationErrors = append(validationErrors, fmt.Errorf("roleBindingsOnly cannot be enabled when groups are written to a ConfigMap"))
+	}
+
+	// Remote clusters are distributed OpenShift Groups
+	if len(m.GroupSync.Spec.Clusters) > 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("Clusters cannot be specified when groups are written to a ConfigMap"))
+	}
+
+	// The ConfigMap is written from the groups of all providers
+	for _, provider := range m.GroupSync.Spec.Providers {
+		if provider.Schedule != "" {
+			validationErrors = append(validationErrors, fmt.Errorf("Provider '%s' cannot specify a schedule when groups are written to a ConfigMap", provider.Name))
+		}
+	}
+
+	return validationErrors
+}
@@ -0,0 +1,72 @@
+package syncer
+
+import (
+	"fmt"
+)
+
+// maxPages bounds the pages retrieved from a resource of a group source so that a group source that never reports its last page
+// cannot stall a synchronization
+const maxPages = 100000
+
+// forEachPage retrieves each page of a resource paged using page numbers or offsets, starting with the first page, until fetchPage
+// returns a next page that does not follow the page retrieved, such as 0 once the last page was retrieved
+func forEachPage(firstPage int, fetchPage func(page int) (nextPage int, err error)) error {
+
+	page := firstPage
+
+	for retrievedPages := 0; retrievedPages < maxPages; retrievedPages++ {
+
+		nextPage, err := fetchPage(page)
+
+		if err != nil {
+			return err
+		}
+
+		if nextPage <= page {
+			return nil
+		}
+
+		page = nextPage
+	}
+
+	return fmt.Errorf("Exceeded the maximum of %d pages", maxPages)
+}
+
+// forEachCursorPage retrieves each page of a resource paged using cursors, starting without a cursor, until fetchPage returns an
+// empty cursor. A group source returning the cursor of the page retrieved again is reported rather than retrieved indefinitely
+func forEachCursorPage(fetchPage func(cursor string) (nextCursor string, err error)) error {
+
+	cursor := ""
+
+	for retrievedPages := 0; retrievedPages < maxPages; retrievedPages++ {
+
+		nextCursor, err := fetchPage(cursor)
+
+		if err != nil {
+			return err
+		}
+
+		if nextCursor == "" {
+			return nil
+		}
+
+		if nextCursor == cursor {
+			return fmt.Errorf("Group source returned the cursor '%s' of the page retrieved", cursor)
+		}
+
+		cursor = nextCursor
+	}
+
+	return fmt.Errorf("Exceeded the maximum of %d pages", maxPages)
+}
+
+// getNextPage returns the page following a page retrieved using page sizes, such as offsets, for group sources that do not report
+// whether more pages are available. A partial page is the last page
+func getNextPage(page int, pageLength int, pageSize int) int {
+
+	if pageLength < pageSize {
+		return page
+	}
+
+	return page + 1
+}
@@ -0,0 +1,266 @@
+package syncer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	"github.com/redhat-cop/group-sync-operator/pkg/plugin"
+	"github.com/redhat-cop/operator-utils/pkg/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	pluginLogger = logf.Log.WithName("syncer_plugin")
+)
+
+const (
+	// pluginMaxMessageSize is the maximum size of the groups returned by a plugin, exceeding the default of gRPC for large group sources
+	pluginMaxMessageSize = 64 * 1024 * 1024
+	// pluginCheckpointKey is the key of the checkpoint returned by the plugin in the checkpoint store of the provider
+	pluginCheckpointKey = "checkpoint"
+)
+
+// PluginSyncer retrieves the groups of an out-of-tree provider from a plugin serving the provider plugin gRPC protocol
+type PluginSyncer struct {
+	Name                    string
+	GroupSync               *redhatcopv1alpha1.GroupSync
+	Provider                *redhatcopv1alpha1.PluginProvider
+	Context                 context.Context
+	ReconcilerBase          util.ReconcilerBase
+	CredentialsSource       *redhatcopv1alpha1.CredentialsSource
+	CredentialsKeys         map[string]string
+	MetadataMappings        []redhatcopv1alpha1.MetadataMapping
+	Credentials             map[string]string
+	CaCertificate           []byte
+	CaBundles               []*redhatcopv1alpha1.ConfigMapRef
+	ClientCertificateSecret *redhatcopv1alpha1.SecretRef
+	ClientCertificate       *tls.Certificate
+	checkpointStore         CheckpointStore
+}
+
+func (p *PluginSyncer) Init() bool {
+
+	if p.Context == nil {
+		p.Context = context.Background()
+	}
+
+	return false
+}
+
+func (p *PluginSyncer) Validate() error {
+
+	validationErrors := []error{}
+
+	if p.Provider.Address == "" {
+		validationErrors = append(validationErrors, fmt.Errorf("Plugin address must be specified"))
+	}
+
+	// Credentials are optional as plugins may authenticate to their group source by other means
+	pluginCredentials, err := getPluginCredentials(p.Context, p.ReconcilerBase.GetClient(), p.CredentialsSource, p.CredentialsKeys, p.Provider.CredentialsSecret)
+
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	} else {
+		p.Credentials = pluginCredentials
+	}
+
+	if p.Provider.Ca != nil {
+
+		caResource, err := getObjectRefData(p.Context, p.ReconcilerBase.GetClient(), p.Provider.Ca)
+
+		if err != nil {
+			validationErrors = append(validationErrors, err)
+		}
+
+		resourceCaKey := getResourceCaKey(p.Provider.Ca, caResource)
+
+		// Certificate key validation
+		if _, found := caResource[resourceCaKey]; !found {
+			validationErrors = append(validationErrors, fmt.Errorf("Could not find '%s' key in %s '%s' in namespace '%s", resourceCaKey, p.Provider.Ca.Kind, p.Provider.Ca.Name, p.Provider.Ca.Namespace))
+		}
+
+		p.CaCertificate = caResource[resourceCaKey]
+	}
+
+	caBundles, err := getCaBundles(p.Context, p.ReconcilerBase.GetClient(), p.CaBundles)
+
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	} else {
+		p.CaCertificate = appendCaBundles(p.CaCertificate, caBundles)
+	}
+
+	clientCertificate, err := getClientCertificate(p.Context, p.ReconcilerBase.GetClient(), p.ClientCertificateSecret)
+
+	if err != nil {
+		validationErrors = append(validationErrors, err)
+	} else {
+		p.ClientCertificate = clientCertificate
+	}
+
+	return utilerrors.NewAggregate(validationErrors)
+}
+
+func (p *PluginSyncer) Bind() error {
+
+	return p.withClient(func(pluginClient *plugin.Client) error {
+		_, err := pluginClient.Bind(p.Context, p.getRequest())
+		return err
+	})
+}
+
+func (p *PluginSyncer) Sync() ([]userv1.Group, error) {
+
+	request := p.getRequest()
+
+	if p.checkpointStore != nil {
+		checkpoint, err := p.checkpointStore.GetCheckpoint(pluginCheckpointKey)
+		if err != nil {
+			return nil, err
+		}
+		request.Checkpoint = checkpoint
+	}
+
+	var response *plugin.SyncResponse
+
+	err := p.withClient(func(pluginClient *plugin.Client) error {
+		var err error
+		response, err = pluginClient.Sync(p.Context, request)
+		return err
+	})
+
+	if err != nil {
+		syncLogger(p.Context, pluginLogger).Error(err, "Failed to retrieve groups from plugin", "Provider", p.Name, "Address", p.Provider.Address)
+		return nil, err
+	}
+
+	if p.checkpointStore != nil {
+		p.checkpointStore.SetCheckpoint(pluginCheckpointKey, response.Checkpoint)
+	}
+
+	return getPluginGroups(p.Context, p.Name, p.Provider.Address, response.Groups, p.MetadataMappings), nil
+}
+
+// SetCheckpointStore stores the checkpoints returned by the plugin so that the plugin can synchronize incrementally
+func (p *PluginSyncer) SetCheckpointStore(checkpointStore CheckpointStore) {
+	p.checkpointStore = checkpointStore
+}
+
+func (p *PluginSyncer) GetProviderName() string {
+	return p.Name
+}
+
+func (p *PluginSyncer) GetPrune() bool {
+	return p.Provider.Prune
+}
+
+func (p *PluginSyncer) getRequest() *plugin.Request {
+	return &plugin.Request{
+		Provider:    p.Name,
+		Parameters:  p.Provider.Parameters,
+		Credentials: p.Credentials,
+	}
+}
+
+// withClient connects to the plugin for the duration of the call so that no connection is left open between synchronizations
+func (p *PluginSyncer) withClient(call func(pluginClient *plugin.Client) error) error {
+
+	conn, err := grpc.DialContext(p.Context, p.Provider.Address, p.getTransportOption(), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(pluginMaxMessageSize)))
+
+	if err != nil {
+		return fmt.Errorf("Failed to connect to plugin '%s': %w", p.Provider.Address, err)
+	}
+
+	defer conn.Close()
+
+	return call(plugin.NewClient(conn))
+}
+
+func (p *PluginSyncer) getTransportOption() grpc.DialOption {
+
+	if p.Provider.Plaintext {
+		return grpc.WithInsecure()
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs: getRootCAs(p.CaCertificate),
+	}
+
+	if p.ClientCertificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*p.ClientCertificate}
+	}
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+}
+
+// getPluginCredentials returns the entries of the credentials of a plugin, if any
+func getPluginCredentials(context context.Context, c client.Client, credentialsSource *redhatcopv1alpha1.CredentialsSource, credentialsKeys map[string]string, credentialsSecret *redhatcopv1alpha1.ObjectRef) (map[string]string, error) {
+
+	if credentialsSecret == nil && credentialsSource == nil {
+		return nil, nil
+	}
+
+	secret, _, err := getCredentials(context, c, credentialsSource, credentialsKeys, credentialsSecret)
+
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := map[string]string{}
+
+	for key, value := range secret.Data {
+		credentials[key] = string(value)
+	}
+
+	return credentials, nil
+}
+
+// getPluginGroups converts the groups returned by a plugin to OpenShift groups
+func getPluginGroups(context context.Context, providerName string, sourceHost string, groups []plugin.Group, metadataMappings []redhatcopv1alpha1.MetadataMapping) []userv1.Group {
+
+	ocpGroups := []userv1.Group{}
+
+	for _, group := range groups {
+
+		if group.Name == "" {
+			syncLogger(context, pluginLogger).Info("Warning: Skipping Group record with empty name returned by plugin", "Provider", providerName)
+			continue
+		}
+
+		ocpGroup := userv1.Group{
+			TypeMeta: v1.TypeMeta{
+				Kind:       "Group",
+				APIVersion: userv1.GroupVersion.String(),
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name:        group.Name,
+				Annotations: map[string]string{},
+				Labels:      map[string]string{},
+			},
+			Users: []string{},
+		}
+
+		ocpGroup.GetAnnotations()[constants.SyncSourceHost] = sourceHost
+
+		if group.ID != "" {
+			ocpGroup.GetAnnotations()[constants.SyncSourceUID] = group.ID
+		}
+
+		applyMetadataMappings(context, &ocpGroup, metadataMappings, group.Attributes)
+
+		ocpGroup.Users = append(ocpGroup.Users, group.Users...)
+
+		ocpGroups = append(ocpGroups, ocpGroup)
+	}
+
+	return ocpGroups
+}
@@ -0,0 +1,96 @@
+package syncer
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"github.com/redhat-cop/group-sync-operator/pkg/plugin"
+	"google.golang.org/grpc"
+)
+
+// checkpointPluginServer returns its groups along with the checkpoint of the changes of its group source
+type checkpointPluginServer struct {
+	checkpoints []string
+}
+
+func (s *checkpointPluginServer) Bind(context context.Context, request *plugin.Request) (*plugin.BindResponse, error) {
+	return &plugin.BindResponse{}, nil
+}
+
+func (s *checkpointPluginServer) Sync(context context.Context, request *plugin.Request) (*plugin.SyncResponse, error) {
+
+	s.checkpoints = append(s.checkpoints, request.Checkpoint)
+
+	if request.Checkpoint == "" {
+		return &plugin.SyncResponse{Groups: []plugin.Group{{Name: "admins", Users: []string{"alice"}}, {Name: "developers", Users: []string{"bob"}}}, Checkpoint: "1"}, nil
+	}
+
+	return &plugin.SyncResponse{Groups: []plugin.Group{{Name: "admins", Users: []string{"alice"}}, {Name: "developers", Users: []string{"bob", "carol"}}}, Checkpoint: "2"}, nil
+}
+
+// memoryCheckpointStore keeps the checkpoints of a provider in memory
+type memoryCheckpointStore map[string]string
+
+func (s memoryCheckpointStore) GetCheckpoint(key string) (string, error) {
+	return s[key], nil
+}
+
+func (s memoryCheckpointStore) SetCheckpoint(key string, value string) {
+	s[key] = value
+}
+
+func TestPluginSyncCheckpoint(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pluginServer := &checkpointPluginServer{}
+
+	server := grpc.NewServer()
+	plugin.RegisterGroupProviderServer(server, pluginServer)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	checkpointStore := memoryCheckpointStore{}
+
+	pluginSyncer := &PluginSyncer{
+		Name:     "plugin",
+		Provider: &redhatcopv1alpha1.PluginProvider{Address: listener.Addr().String(), Plaintext: true},
+	}
+	pluginSyncer.Init()
+	pluginSyncer.SetCheckpointStore(checkpointStore)
+
+	groups, err := pluginSyncer.Sync()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(groups) != 2 {
+		t.Errorf("expected 2 groups, found %d", len(groups))
+	}
+
+	if checkpoint := checkpointStore[pluginCheckpointKey]; checkpoint != "1" {
+		t.Errorf("expected checkpoint '1' to be stored, found '%s'", checkpoint)
+	}
+
+	groups, err = pluginSyncer.Sync()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(groups) != 2 || len(groups[1].Users) != 2 {
+		t.Errorf("expected the groups updated since checkpoint '1', found %v", groups)
+	}
+
+	if checkpoint := checkpointStore[pluginCheckpointKey]; checkpoint != "2" {
+		t.Errorf("expected checkpoint '2' to be stored, found '%s'", checkpoint)
+	}
+
+	if len(pluginServer.checkpoints) != 2 || pluginServer.checkpoints[0] != "" || pluginServer.checkpoints[1] != "1" {
+		t.Errorf("expected the plugin to receive checkpoints ['' '1'], found %q", pluginServer.checkpoints)
+	}
+}
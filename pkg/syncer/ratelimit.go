@@ -0,0 +1,50 @@
+package syncer
+
+import (
+	"net/http"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedTransport delays the requests issued to a provider exceeding the rate limit of the provider
+type rateLimitedTransport struct {
+	transport http.RoundTripper
+	limiter   *rate.Limiter
+}
+
+func (r *rateLimitedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+
+	if err := r.limiter.Wait(request.Context()); err != nil {
+		return nil, err
+	}
+
+	return r.transport.RoundTrip(request)
+}
+
+// newRateLimitedTransport limits the requests issued using the transport to the rate limit. The transport is returned unchanged
+// when no rate limit is specified
+func newRateLimitedTransport(transport http.RoundTripper, rateLimit *redhatcopv1alpha1.RateLimit) http.RoundTripper {
+
+	if rateLimit == nil {
+		return transport
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &rateLimitedTransport{transport: transport, limiter: newRateLimiter(rateLimit)}
+}
+
+// newRateLimiter returns a limiter allowing the requests per second of the rate limit with bursts defaulting to the requests per second
+func newRateLimiter(rateLimit *redhatcopv1alpha1.RateLimit) *rate.Limiter {
+
+	burst := int(rateLimit.Burst)
+
+	if burst < 1 {
+		burst = int(rateLimit.RequestsPerSecond)
+	}
+
+	return rate.NewLimiter(rate.Limit(rateLimit.RequestsPerSecond), burst)
+}
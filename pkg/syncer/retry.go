@@ -0,0 +1,26 @@
+package syncer
+
+import (
+	"fmt"
+)
+
+func (m *GroupSyncMgr) validateRetryIntervals() []error {
+	validationErrors := []error{}
+
+	retryInterval := m.GroupSync.Spec.RetryInterval
+	maxRetryInterval := m.GroupSync.Spec.MaxRetryInterval
+
+	if retryInterval != nil && retryInterval.Duration <= 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("Invalid retry interval: '%s'", retryInterval.Duration.String()))
+	}
+
+	if maxRetryInterval != nil && maxRetryInterval.Duration <= 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("Invalid max retry interval: '%s'", maxRetryInterval.Duration.String()))
+	}
+
+	if retryInterval != nil && maxRetryInterval != nil && retryInterval.Duration > maxRetryInterval.Duration {
+		validationErrors = append(validationErrors, fmt.Errorf("Retry interval '%s' cannot exceed the max retry interval '%s'", retryInterval.Duration.String(), maxRetryInterval.Duration.String()))
+	}
+
+	return validationErrors
+}
@@ -0,0 +1,89 @@
+package syncer
+
+import (
+	"fmt"
+	"strings"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// IsGroupBound determines whether the group synchronized by the provider is bound to the role of the role binding. Groups are bound
+// when they match one of the groups of the role binding, compiled as the provided patterns, and are synchronized by one of its providers
+func IsGroupBound(roleBinding *redhatcopv1alpha1.GroupRoleBinding, groups *GroupPatterns, groupName string, providerName string) bool {
+
+	if len(roleBinding.Providers) > 0 && !containsString(roleBinding.Providers, providerName) {
+		return false
+	}
+
+	return groups.Allows(groupName)
+}
+
+func (m *GroupSyncMgr) validateRoleBindings() []error {
+	validationErrors := []error{}
+
+	if m.GroupSync.Spec.RoleBindingsOnly && len(m.GroupSync.Spec.RoleBindings) == 0 && len(m.GroupSync.Spec.RoleMappings) == 0 {
+		validationErrors = append(validationErrors, fmt.Errorf("Role bindings or role mappings must be specified when roleBindingsOnly is enabled"))
+	}
+
+	providerNames := []string{}
+	for _, provider := range m.GroupSync.Spec.Providers {
+		providerNames = append(providerNames, provider.Name)
+
+		// Role bindings are computed from the groups of all providers as groups are not written
+		if m.GroupSync.Spec.RoleBindingsOnly && provider.Schedule != "" {
+			validationErrors = append(validationErrors, fmt.Errorf("Provider '%s' cannot specify a schedule when roleBindingsOnly is enabled", provider.Name))
+		}
+	}
+
+	roleBindingNames := map[string]bool{}
+
+	for _, roleBinding := range m.GroupSync.Spec.RoleBindings {
+
+		if errs := validation.IsDNS1123Subdomain(roleBinding.Name); len(errs) > 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid role binding name '%s': %s", roleBinding.Name, strings.Join(errs, ", ")))
+		}
+
+		if roleBinding.RoleRef.Name == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("Role binding '%s' does not reference a role", roleBinding.Name))
+		}
+
+		for _, namespace := range roleBinding.Namespaces {
+
+			if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+				validationErrors = append(validationErrors, fmt.Errorf("Invalid namespace '%s' of role binding '%s': %s", namespace, roleBinding.Name, strings.Join(errs, ", ")))
+			}
+
+			key := fmt.Sprintf("%s/%s", namespace, roleBinding.Name)
+
+			if roleBindingNames[key] {
+				validationErrors = append(validationErrors, fmt.Errorf("Role binding '%s' is specified more than once in namespace '%s'", roleBinding.Name, namespace))
+			}
+
+			roleBindingNames[key] = true
+		}
+
+		for _, roleBindingProvider := range roleBinding.Providers {
+			if !containsString(providerNames, roleBindingProvider) {
+				validationErrors = append(validationErrors, fmt.Errorf("Provider '%s' of role binding '%s' is not a configured provider", roleBindingProvider, roleBinding.Name))
+			}
+		}
+
+		validationErrors = append(validationErrors, validateGroupPatterns(fmt.Sprintf("groups of role binding '%s'", roleBinding.Name), roleBinding.Groups)...)
+	}
+
+	for _, roleMapping := range m.GroupSync.Spec.RoleMappings {
+
+		if roleMapping.ClusterRole == "" {
+			validationErrors = append(validationErrors, fmt.Errorf("Role mapping does not reference a ClusterRole"))
+		}
+
+		if len(roleMapping.Groups) == 0 {
+			validationErrors = append(validationErrors, fmt.Errorf("Role mapping of ClusterRole '%s' does not specify groups", roleMapping.ClusterRole))
+		}
+
+		validationErrors = append(validationErrors, validateGroupPatterns(fmt.Sprintf("groups of role mapping of ClusterRole '%s'", roleMapping.ClusterRole), roleMapping.Groups)...)
+	}
+
+	return validationErrors
+}
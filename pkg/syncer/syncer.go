@@ -3,6 +3,7 @@ package syncer
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	userv1 "github.com/openshift/api/user/v1"
 	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
@@ -11,16 +12,20 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	secretUsernameKey    = "username"
-	secretPasswordKey    = "password"
-	secretTokenKey       = "token"
-	privateKey           = "privateKey"
-	appId                = "appId"
-	defaultResourceCaKey = "ca.crt"
+	secretUsernameKey     = "username"
+	secretPasswordKey     = "password"
+	secretTokenKey        = "token"
+	secretClientIDKey     = "clientId"
+	secretClientSecretKey = "clientSecret"
+	privateKey            = "privateKey"
+	appId                 = "appId"
+	defaultResourceCaKey  = "ca.crt"
+	trustedCaBundleKey    = "ca-bundle.crt"
 )
 
 type GroupSyncer interface {
@@ -33,18 +38,24 @@ type GroupSyncer interface {
 }
 
 type GroupSyncMgr struct {
-	GroupSyncers []GroupSyncer
-	GroupSync    *redhatcopv1alpha1.GroupSync
+	GroupSyncers     []GroupSyncer
+	GroupSync        *redhatcopv1alpha1.GroupSync
+	CheckpointStores map[string]*ConfigMapCheckpointStore
+	reconcilerBase   util.ReconcilerBase
+	caBundle         *redhatcopv1alpha1.ConfigMapRef
 }
 
-func GetGroupSyncMgr(groupSync *redhatcopv1alpha1.GroupSync, reconcilerBase util.ReconcilerBase) (GroupSyncMgr, error) {
+// GetGroupSyncMgr returns a syncer for each provider of the GroupSync. The CA certificates of the bundle configured for the operator, when
+// specified, are trusted by each provider in addition to the CA certificates configured for the provider
+func GetGroupSyncMgr(context context.Context, groupSync *redhatcopv1alpha1.GroupSync, reconcilerBase util.ReconcilerBase, caBundle *redhatcopv1alpha1.ConfigMapRef) (GroupSyncMgr, error) {
 
 	syncers := []GroupSyncer{}
 	syncersError := []error{}
+	checkpointStores := map[string]*ConfigMapCheckpointStore{}
 
-	for _, provider := range groupSync.Spec.Providers {
+	for i := range groupSync.Spec.Providers {
 
-		syncer, err := getGroupSyncerForProvider(groupSync, &provider, reconcilerBase)
+		syncer, err := getDecoratedGroupSyncer(context, groupSync, &groupSync.Spec.Providers[i], reconcilerBase, caBundle, checkpointStores)
 
 		if err != nil {
 			syncersError = append(syncersError, err)
@@ -54,35 +65,129 @@ func GetGroupSyncMgr(groupSync *redhatcopv1alpha1.GroupSync, reconcilerBase util
 
 	}
 
-	return GroupSyncMgr{GroupSync: groupSync, GroupSyncers: syncers}, utilerrors.NewAggregate(syncersError)
+	return GroupSyncMgr{GroupSync: groupSync, GroupSyncers: syncers, CheckpointStores: checkpointStores, reconcilerBase: reconcilerBase, caBundle: caBundle}, utilerrors.NewAggregate(syncersError)
 }
 
-func getGroupSyncerForProvider(groupSync *redhatcopv1alpha1.GroupSync, provider *redhatcopv1alpha1.Provider, reconcilerBase util.ReconcilerBase) (GroupSyncer, error) {
+// GetProviderSyncer returns a new syncer for the provider using the context given, such as a context bounded by the timeout of the
+// provider, so that the requests of the syncer are cancelled along with the context. The syncer shares the checkpoints of the provider
+// with the syncers of the manager and is initialized but not validated
+func (m *GroupSyncMgr) GetProviderSyncer(context context.Context, providerName string) (GroupSyncer, error) {
+
+	for i := range m.GroupSync.Spec.Providers {
+		if m.GroupSync.Spec.Providers[i].Name != providerName {
+			continue
+		}
+
+		syncer, err := getDecoratedGroupSyncer(context, m.GroupSync, &m.GroupSync.Spec.Providers[i], m.reconcilerBase, m.caBundle, m.CheckpointStores)
+		if err != nil {
+			return nil, err
+		}
+
+		syncer.Init()
+
+		return syncer, nil
+	}
+
+	return nil, fmt.Errorf("Could not find provider '%s'", providerName)
+}
+
+// getDecoratedGroupSyncer returns the syncer of the provider along with the decorators transforming the groups of the provider. Syncers
+// synchronizing incrementally are given the checkpoint store of the provider, which is added to the checkpoint stores when not found
+func getDecoratedGroupSyncer(context context.Context, groupSync *redhatcopv1alpha1.GroupSync, provider *redhatcopv1alpha1.Provider, reconcilerBase util.ReconcilerBase, caBundle *redhatcopv1alpha1.ConfigMapRef, checkpointStores map[string]*ConfigMapCheckpointStore) (GroupSyncer, error) {
+
+	caBundles := []*redhatcopv1alpha1.ConfigMapRef{}
+
+	if caBundle != nil {
+		caBundles = append(caBundles, caBundle)
+	}
+
+	if provider.CaBundleConfigMapRef != nil {
+		caBundles = append(caBundles, provider.CaBundleConfigMapRef)
+	}
+
+	// The syncers of the provider share a context resolving the span of the phase of the provider in progress and configuring the
+	// lines they log according to the log level of the provider
+	providerContext := newPhaseContext(withProviderLogLevel(context, provider))
+
+	syncer, err := getGroupSyncerForProvider(providerContext, groupSync, provider, reconcilerBase, caBundles)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Checkpoints are provided to the syncer of the provider before it is decorated
+	if incrementalSyncer, ok := syncer.(IncrementalSyncer); ok {
+		checkpointStore, found := checkpointStores[provider.Name]
+		if !found {
+			checkpointStore = &ConfigMapCheckpointStore{GroupSync: groupSync, ProviderName: provider.Name, ReconcilerBase: reconcilerBase, Context: context}
+			checkpointStores[provider.Name] = checkpointStore
+		}
+		incrementalSyncer.SetCheckpointStore(checkpointStore)
+	}
+
+	if isFiltered(provider) {
+		syncer = &FilterSyncer{GroupSyncer: syncer, Provider: provider}
+	}
+
+	if provider.MappingWebhook != nil {
+		syncer = &MappingWebhookSyncer{GroupSyncer: syncer, MappingWebhook: provider.MappingWebhook, ReconcilerBase: reconcilerBase, Context: providerContext}
+	}
+
+	if isGroupNameTransformed(provider) {
+		syncer = &GroupNameSyncer{GroupSyncer: syncer, Provider: provider, ReconcilerBase: reconcilerBase, Context: providerContext}
+	}
+
+	if isUsernameTransformed(provider) {
+		syncer = &UsernameSyncer{GroupSyncer: syncer, Provider: provider, Context: providerContext}
+	}
+
+	// The hierarchy is recorded once the names of the groups are transformed
+	if provider.HierarchyMetadata {
+		syncer = &HierarchySyncer{GroupSyncer: syncer}
+	}
+
+	// Phases are traced including the decorators of the provider
+	return &TracingSyncer{GroupSyncer: syncer, Context: providerContext}, nil
+}
+
+func getGroupSyncerForProvider(context context.Context, groupSync *redhatcopv1alpha1.GroupSync, provider *redhatcopv1alpha1.Provider, reconcilerBase util.ReconcilerBase, caBundles []*redhatcopv1alpha1.ConfigMapRef) (GroupSyncer, error) {
 
 	switch {
 	case provider.Okta != nil:
 		{
-			return &OktaSyncer{GroupSync: groupSync, Provider: provider.Okta, Name: provider.Name, ReconcilerBase: reconcilerBase}, nil
+			return &OktaSyncer{GroupSync: groupSync, Provider: provider.Okta, Name: provider.Name, ReconcilerBase: reconcilerBase, CredentialsSource: provider.CredentialsSource, CredentialsKeys: provider.CredentialsKeys, MetadataMappings: provider.MetadataMappings, RateLimit: provider.RateLimit, Context: context}, nil
 		}
 	case provider.Keycloak != nil:
 		{
-			return &KeycloakSyncer{GroupSync: groupSync, Provider: provider.Keycloak, Name: provider.Name, ReconcilerBase: reconcilerBase}, nil
+			return &KeycloakSyncer{GroupSync: groupSync, Provider: provider.Keycloak, Name: provider.Name, ReconcilerBase: reconcilerBase, CredentialsSource: provider.CredentialsSource, CredentialsKeys: provider.CredentialsKeys, MetadataMappings: provider.MetadataMappings, RateLimit: provider.RateLimit, CaBundles: caBundles, ClientCertificateSecret: provider.ClientCertificateSecretRef, Context: context}, nil
 		}
 	case provider.GitHub != nil:
 		{
-			return &GitHubSyncer{GroupSync: groupSync, Provider: provider.GitHub, Name: provider.Name, ReconcilerBase: reconcilerBase}, nil
+			return &GitHubSyncer{GroupSync: groupSync, Provider: provider.GitHub, Name: provider.Name, ReconcilerBase: reconcilerBase, CredentialsSource: provider.CredentialsSource, CredentialsKeys: provider.CredentialsKeys, MetadataMappings: provider.MetadataMappings, RateLimit: provider.RateLimit, CaBundles: caBundles, ClientCertificateSecret: provider.ClientCertificateSecretRef, Context: context}, nil
 		}
 	case provider.GitLab != nil:
 		{
-			return &GitLabSyncer{GroupSync: groupSync, Provider: provider.GitLab, Name: provider.Name, ReconcilerBase: reconcilerBase}, nil
+			return &GitLabSyncer{GroupSync: groupSync, Provider: provider.GitLab, Name: provider.Name, ReconcilerBase: reconcilerBase, CredentialsSource: provider.CredentialsSource, CredentialsKeys: provider.CredentialsKeys, MetadataMappings: provider.MetadataMappings, RateLimit: provider.RateLimit, CaBundles: caBundles, ClientCertificateSecret: provider.ClientCertificateSecretRef, Context: context}, nil
 		}
 	case provider.Azure != nil:
 		{
-			return &AzureSyncer{GroupSync: groupSync, Provider: provider.Azure, Name: provider.Name, ReconcilerBase: reconcilerBase}, nil
+			return &AzureSyncer{GroupSync: groupSync, Provider: provider.Azure, Name: provider.Name, ReconcilerBase: reconcilerBase, CredentialsSource: provider.CredentialsSource, CredentialsKeys: provider.CredentialsKeys, MetadataMappings: provider.MetadataMappings, RateLimit: provider.RateLimit, Timeout: provider.Timeout, Context: context}, nil
 		}
 	case provider.Ldap != nil:
 		{
-			return &LdapSyncer{GroupSync: groupSync, Provider: provider.Ldap, Name: provider.Name, ReconcilerBase: reconcilerBase}, nil
+			return &LdapSyncer{GroupSync: groupSync, Provider: provider.Ldap, Name: provider.Name, ReconcilerBase: reconcilerBase, CredentialsSource: provider.CredentialsSource, CredentialsKeys: provider.CredentialsKeys, MetadataMappings: provider.MetadataMappings, CaBundles: caBundles, Context: context}, nil
+		}
+	case provider.Plugin != nil:
+		{
+			return &PluginSyncer{GroupSync: groupSync, Provider: provider.Plugin, Name: provider.Name, ReconcilerBase: reconcilerBase, CredentialsSource: provider.CredentialsSource, CredentialsKeys: provider.CredentialsKeys, MetadataMappings: provider.MetadataMappings, CaBundles: caBundles, ClientCertificateSecret: provider.ClientCertificateSecretRef, Context: context}, nil
+		}
+	case provider.Exec != nil:
+		{
+			return &ExecSyncer{GroupSync: groupSync, Provider: provider.Exec, Name: provider.Name, ReconcilerBase: reconcilerBase, CredentialsSource: provider.CredentialsSource, CredentialsKeys: provider.CredentialsKeys, MetadataMappings: provider.MetadataMappings, Context: context}, nil
+		}
+	case provider.Fake != nil:
+		{
+			return &FakeSyncer{GroupSync: groupSync, Provider: provider.Fake, Name: provider.Name, ReconcilerBase: reconcilerBase, MetadataMappings: provider.MetadataMappings, Context: context}, nil
 		}
 	}
 
@@ -105,8 +210,10 @@ func (m *GroupSyncMgr) SetDefaults() bool {
 
 }
 
-func (m *GroupSyncMgr) Validate() error {
+// Validate validates the GroupSync and each of its providers. The validation errors of each provider are also returned by provider name
+func (m *GroupSyncMgr) Validate() (map[string]error, error) {
 	syncersError := []error{}
+	providerErrors := map[string]error{}
 
 	// Validate Cron Schedule
 	if m.GroupSync.Spec.Schedule != "" {
@@ -115,31 +222,140 @@ func (m *GroupSyncMgr) Validate() error {
 		}
 	}
 
+	// Validate Provider Cron Schedules, Client Certificates, Metadata Mappings and User Provisioning
+	for _, provider := range m.GroupSync.Spec.Providers {
+		if provider.Schedule != "" {
+			if _, err := cron.ParseStandard(provider.Schedule); err != nil {
+				syncersError = append(syncersError, fmt.Errorf("Failed to validate cron schedule of provider '%s': %s", provider.Name, provider.Schedule))
+			}
+		}
+
+		if provider.Timeout != nil && provider.Timeout.Duration <= 0 {
+			syncersError = append(syncersError, fmt.Errorf("Invalid timeout of provider '%s': '%s'", provider.Name, provider.Timeout.Duration.String()))
+		}
+
+		// Rate limits apply to the HTTP clients of providers
+		if provider.RateLimit != nil && (provider.Ldap != nil || provider.Plugin != nil || provider.Exec != nil || provider.Fake != nil) {
+			syncersError = append(syncersError, fmt.Errorf("Rate limits are not supported by provider '%s'", provider.Name))
+		}
+
+		// Client certificates are presented by providers communicating over HTTP and by plugins
+		if provider.ClientCertificateSecretRef != nil && provider.ProviderType != nil && provider.Keycloak == nil && provider.GitHub == nil && provider.GitLab == nil && provider.Plugin == nil {
+			syncersError = append(syncersError, fmt.Errorf("Client certificates are not supported by provider '%s'", provider.Name))
+		}
+
+		for expectedKey, key := range provider.CredentialsKeys {
+			if expectedKey == "" || key == "" {
+				syncersError = append(syncersError, fmt.Errorf("Invalid credentials key mapping of provider '%s': '%s' to '%s'", provider.Name, expectedKey, key))
+			}
+		}
+
+		syncersError = append(syncersError, validateMetadataMappings(provider.Name, provider.MetadataMappings)...)
+
+		if provider.UserProvisioning != nil {
+			syncersError = append(syncersError, m.validateUserProvisioning(provider.Name, provider.UserProvisioning)...)
+		}
+	}
+
+	// Validate Prune Threshold
+	if m.GroupSync.Spec.PruneMaxDelete != nil {
+		if err := validatePruneMaxDelete(m.GroupSync.Spec.PruneMaxDelete); err != nil {
+			syncersError = append(syncersError, err)
+		}
+	}
+
+	// Validate Prune Grace Period
+	if m.GroupSync.Spec.PruneGracePeriod != nil && m.GroupSync.Spec.PruneGracePeriod.Duration < 0 {
+		syncersError = append(syncersError, fmt.Errorf("Invalid prune grace period: '%s'", m.GroupSync.Spec.PruneGracePeriod.Duration.String()))
+	}
+
+	// Validate Retry Intervals
+	syncersError = append(syncersError, m.validateRetryIntervals()...)
+
+	// Validate Migration
+	if m.GroupSync.Spec.Migration != nil {
+		syncersError = append(syncersError, m.validateMigration()...)
+	}
+
+	// Validate Role Bindings and Role Mappings
+	if len(m.GroupSync.Spec.RoleBindings) > 0 || len(m.GroupSync.Spec.RoleMappings) > 0 || m.GroupSync.Spec.RoleBindingsOnly {
+		syncersError = append(syncersError, m.validateRoleBindings()...)
+	}
+
+	// Validate Group Namespaces
+	if m.GroupSync.Spec.GroupNamespaces != nil {
+		syncersError = append(syncersError, m.validateGroupNamespaces()...)
+	}
+
+	// Validate Output
+	syncersError = append(syncersError, m.validateOutput()...)
+
+	// Validate Remote Clusters
+	if len(m.GroupSync.Spec.Clusters) > 0 {
+		syncersError = append(syncersError, m.validateClusters()...)
+	}
+
 	for _, syncer := range m.GroupSyncers {
 		err := syncer.Validate()
 
 		if err != nil {
 			syncersError = append(syncersError, err)
+			providerErrors[syncer.GetProviderName()] = err
 		}
 
 	}
 
-	return utilerrors.NewAggregate(syncersError)
+	return providerErrors, utilerrors.NewAggregate(syncersError)
 
 }
 
-func isGroupAllowed(groupName string, allowedGroups []string) bool {
-	if allowedGroups == nil || len(allowedGroups) == 0 {
-		return true
+func (m *GroupSyncMgr) validateMigration() []error {
+	validationErrors := []error{}
+
+	migration := m.GroupSync.Spec.Migration
+
+	providerNames := []string{}
+	for _, provider := range m.GroupSync.Spec.Providers {
+		providerNames = append(providerNames, provider.Name)
 	}
 
-	for _, allowedGroup := range allowedGroups {
-		if allowedGroup == groupName {
-			return true
+	for _, migrationProvider := range migration.Providers {
+		if !containsString(providerNames, migrationProvider) {
+			validationErrors = append(validationErrors, fmt.Errorf("Migration provider '%s' is not a configured provider", migrationProvider))
 		}
 	}
 
-	return false
+	if !containsString(migration.Providers, migration.SourceOfTruth) {
+		validationErrors = append(validationErrors, fmt.Errorf("Migration source of truth '%s' must be one of the migration providers", migration.SourceOfTruth))
+	}
+
+	return validationErrors
+}
+
+func validatePruneMaxDelete(pruneMaxDelete *intstr.IntOrString) error {
+
+	value, err := intstr.GetValueFromIntOrPercent(pruneMaxDelete, 100, false)
+
+	if err != nil || value < 0 || (pruneMaxDelete.Type == intstr.String && value > 100) {
+		return fmt.Errorf("Invalid prune max delete: '%s'", pruneMaxDelete.String())
+	}
+
+	return nil
+}
+
+// contextTransport attaches the context of the synchronization to requests issued by clients that are not context aware
+type contextTransport struct {
+	transport http.RoundTripper
+	context   func() context.Context
+}
+
+func (c *contextTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return c.transport.RoundTrip(request.WithContext(c.context()))
+}
+
+// isGroupSelected determines whether the group is part of the allowed groups and does not match any of the excluded groups
+func isGroupSelected(groupName string, allowedGroups *GroupPatterns, excludedGroups *GroupPatterns) bool {
+	return allowedGroups.Allows(groupName) && !excludedGroups.Matches(groupName)
 }
 
 func getObjectRefData(context context.Context, client client.Client, resource *redhatcopv1alpha1.ObjectRef) (map[string][]byte, error) {
@@ -179,6 +395,23 @@ func getObjectRefData(context context.Context, client client.Client, resource *r
 	return nil, nil
 }
 
+// getResourceCaKey returns the key containing the CA certificate in the resource. When no key is specified, the key of the trusted CA
+// bundle injected into ConfigMaps by the Cluster Network Operator is used when the default key is not present
+func getResourceCaKey(resource *redhatcopv1alpha1.ObjectRef, resourceData map[string][]byte) string {
+
+	if resource.Key != "" {
+		return resource.Key
+	}
+
+	if _, found := resourceData[defaultResourceCaKey]; !found && resource.Kind == redhatcopv1alpha1.ConfigMapObjectRefKind {
+		if _, found := resourceData[trustedCaBundleKey]; found {
+			return trustedCaBundleKey
+		}
+	}
+
+	return defaultResourceCaKey
+}
+
 func determineFromDeprecatedObjectRef(objectRef *redhatcopv1alpha1.ObjectRef, deprecatedObjectRef *redhatcopv1alpha1.ObjectRef) *redhatcopv1alpha1.ObjectRef {
 
 	if objectRef != nil {
@@ -188,3 +421,30 @@ func determineFromDeprecatedObjectRef(objectRef *redhatcopv1alpha1.ObjectRef, de
 	return deprecatedObjectRef
 
 }
+
+// resolveUsername returns the value of the first of the username attributes present on a user, or the value of the default attribute
+// when no username attributes are specified
+func resolveUsername(usernameAttributes *[]string, defaultAttribute string, getAttribute func(attribute string) (string, bool)) (string, bool) {
+
+	if usernameAttributes == nil {
+		return getAttribute(defaultAttribute)
+	}
+
+	for _, usernameAttribute := range *usernameAttributes {
+		if username, found := getAttribute(usernameAttribute); found {
+			return username, true
+		}
+	}
+
+	return "", false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
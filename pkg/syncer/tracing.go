@@ -0,0 +1,98 @@
+package syncer
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/redhat-cop/group-sync-operator/pkg/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingSyncer decorates a GroupSyncer by recording a span for each of the Validate, Bind and Sync phases of the provider. Requests
+// issued by the provider while a phase is in progress are recorded as children of the span of the phase
+type TracingSyncer struct {
+	GroupSyncer
+	Context *phaseContext
+}
+
+func (t *TracingSyncer) Validate() error {
+	return t.trace("Validate", func(span trace.Span) error {
+		return t.GroupSyncer.Validate()
+	})
+}
+
+func (t *TracingSyncer) Bind() error {
+	return t.trace("Bind", func(span trace.Span) error {
+		return t.GroupSyncer.Bind()
+	})
+}
+
+func (t *TracingSyncer) Sync() ([]userv1.Group, error) {
+
+	var groups []userv1.Group
+
+	err := t.trace("Sync", func(span trace.Span) error {
+		var err error
+		groups, err = t.GroupSyncer.Sync()
+		span.SetAttributes(tracing.GroupsAttribute.Int(len(groups)))
+		return err
+	})
+
+	return groups, err
+}
+
+// trace runs the phase of the provider within a span that is a child of the span of the reconciliation
+func (t *TracingSyncer) trace(phase string, run func(span trace.Span) error) error {
+
+	_, span := tracing.Tracer().Start(t.Context.Context, phase, trace.WithAttributes(tracing.ProviderAttribute.String(t.GetProviderName())))
+	defer span.End()
+
+	t.Context.setSpan(span)
+	defer t.Context.setSpan(nil)
+
+	err := run(span)
+	tracing.RecordError(span, err)
+
+	return err
+}
+
+// phaseContext is the context held by the syncers of a provider. Syncers keep the context they are created with, so the span of the
+// phase in progress is resolved when values of the context are looked up rather than when the context is created
+type phaseContext struct {
+	context.Context
+	mutex sync.RWMutex
+	span  trace.Span
+}
+
+func newPhaseContext(parent context.Context) *phaseContext {
+	return &phaseContext{Context: parent}
+}
+
+func (c *phaseContext) Value(key interface{}) interface{} {
+
+	c.mutex.RLock()
+	span := c.span
+	c.mutex.RUnlock()
+
+	if span == nil {
+		return c.Context.Value(key)
+	}
+
+	return trace.ContextWithSpan(c.Context, span).Value(key)
+}
+
+func (c *phaseContext) setSpan(span trace.Span) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.span = span
+}
+
+// newTracedTransport records a span for each request issued using the transport as a child of the span of the context of the request.
+// The default transport is used when no transport is specified
+func newTracedTransport(transport http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(transport)
+}
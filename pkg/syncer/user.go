@@ -0,0 +1,24 @@
+package syncer
+
+import (
+	"fmt"
+	"strings"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+func (m *GroupSyncMgr) validateUserProvisioning(providerName string, userProvisioning *redhatcopv1alpha1.UserProvisioning) []error {
+	validationErrors := []error{}
+
+	// Users are only created along with OpenShift Groups
+	if m.GroupSync.Spec.RoleBindingsOnly || m.GroupSync.Spec.Output == redhatcopv1alpha1.ConfigMapGroupOutput {
+		validationErrors = append(validationErrors, fmt.Errorf("Provider '%s' cannot provision users when groups are not written as OpenShift Groups", providerName))
+	}
+
+	// Identities are named after the identity provider and the user name separated by a colon
+	if strings.Contains(userProvisioning.IdentityProvider, ":") {
+		validationErrors = append(validationErrors, fmt.Errorf("Invalid identity provider '%s' of provider '%s': must not contain ':'", userProvisioning.IdentityProvider, providerName))
+	}
+
+	return validationErrors
+}
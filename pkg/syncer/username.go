@@ -0,0 +1,103 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	userv1 "github.com/openshift/api/user/v1"
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	usernameLogger = logf.Log.WithName("syncer_username")
+)
+
+// UsernameSyncer decorates a GroupSyncer by transforming the names of the users of the synchronized groups
+type UsernameSyncer struct {
+	GroupSyncer
+	Provider *redhatcopv1alpha1.Provider
+	Context  context.Context
+	rewrites []*regexp.Regexp
+}
+
+// isUsernameTransformed determines whether the names of the users of the provider are transformed
+func isUsernameTransformed(provider *redhatcopv1alpha1.Provider) bool {
+	return (provider.UsernameCase != "" && provider.UsernameCase != redhatcopv1alpha1.PreserveUsernameCase) || len(provider.UsernameRewrites) > 0
+}
+
+func (u *UsernameSyncer) Validate() error {
+
+	validationErrors := []error{}
+
+	if err := u.GroupSyncer.Validate(); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
+	for _, usernameRewrite := range u.Provider.UsernameRewrites {
+		if _, err := regexp.Compile(usernameRewrite.Pattern); err != nil {
+			validationErrors = append(validationErrors, fmt.Errorf("Invalid username rewrite pattern: '%s'", usernameRewrite.Pattern))
+		}
+	}
+
+	return utilerrors.NewAggregate(validationErrors)
+}
+
+func (u *UsernameSyncer) Sync() ([]userv1.Group, error) {
+
+	groups, err := u.GroupSyncer.Sync()
+
+	if err != nil {
+		return nil, err
+	}
+
+	u.rewrites = []*regexp.Regexp{}
+	for _, usernameRewrite := range u.Provider.UsernameRewrites {
+		u.rewrites = append(u.rewrites, regexp.MustCompile(usernameRewrite.Pattern))
+	}
+
+	for i := range groups {
+
+		// Users whose transformed names are identical are merged into a single user
+		found := map[string]bool{}
+		users := []string{}
+
+		for _, user := range groups[i].Users {
+			username := u.getUsername(user)
+
+			if username == "" {
+				syncLogger(u.Context, usernameLogger).Info("Warning: Skipping User with Empty Transformed Name", "Provider", u.GetProviderName(), "Group Name", groups[i].Name, "User Name", user)
+				continue
+			}
+
+			if !found[username] {
+				found[username] = true
+				users = append(users, username)
+			}
+		}
+
+		groups[i].Users = users
+	}
+
+	return groups, nil
+}
+
+// getUsername applies the username rewrites, followed by the conversion of the case
+func (u *UsernameSyncer) getUsername(username string) string {
+
+	for i, rewrite := range u.rewrites {
+		username = rewrite.ReplaceAllString(username, u.Provider.UsernameRewrites[i].Replacement)
+	}
+
+	switch u.Provider.UsernameCase {
+	case redhatcopv1alpha1.LowerUsernameCase:
+		return strings.ToLower(username)
+	case redhatcopv1alpha1.UpperUsernameCase:
+		return strings.ToUpper(username)
+	}
+
+	return username
+}
@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer creating the spans of the operator
+const TracerName = "github.com/redhat-cop/group-sync-operator"
+
+// ServiceName is the name of the service the spans of the operator are exported as
+const ServiceName = "group-sync-operator"
+
+// Attributes of the spans of the operator
+const (
+	GroupSyncNamespaceAttribute = attribute.Key("groupsync.namespace")
+	GroupSyncNameAttribute      = attribute.Key("groupsync.name")
+	SyncRunIDAttribute          = attribute.Key("groupsync.sync_run_id")
+	ProviderAttribute           = attribute.Key("groupsync.provider")
+	GroupsAttribute             = attribute.Key("groupsync.groups")
+)
+
+// Tracer returns the tracer creating the spans of the operator. Spans are discarded unless an exporter was set up
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Setup exports the spans of the operator over gRPC to the OTLP collector listening on the endpoint and returns a function flushing
+// the spans not yet exported. Spans of requests carrying a sampled trace context are sampled along with a ratio of other spans
+func Setup(context context.Context, endpoint string, insecure bool, samplingRatio float64) (func(context.Context) error, error) {
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+
+	if insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(context, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(ServiceName))),
+	)
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return tracerProvider.Shutdown, nil
+}
+
+// RecordError marks the span as failed with the error. Spans are left untouched when the error is nil
+func RecordError(span trace.Span, err error) {
+
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
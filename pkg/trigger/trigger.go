@@ -0,0 +1,178 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-cop/group-sync-operator/pkg/constants"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	redhatcopv1alpha1 "github.com/redhat-cop/group-sync-operator/api/v1alpha1"
+)
+
+// Path is the path prefix of the endpoint. Synchronizations are triggered by POST requests to /sync/{namespace}/{name}
+const Path = "/sync/"
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// Handler triggers an immediate synchronization of a GroupSync by annotating it. Callers authenticate using a bearer token and must
+// be allowed to update the GroupSync
+type Handler struct {
+	Client client.Client
+	Log    logr.Logger
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key, err := getGroupSyncKey(req.URL.Path)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	logger := h.Log.WithValues("groupsync", key)
+
+	userInfo, err := h.authenticate(req.Context(), req)
+
+	if err != nil {
+		logger.Info("Synchronization Request Not Authenticated", "Reason", err.Error())
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	allowed, err := h.authorize(req.Context(), userInfo, key)
+
+	if err != nil {
+		logger.Error(err, "Failed to Authorize Synchronization Request")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if !allowed {
+		logger.Info("Synchronization Request Forbidden", "User", userInfo.Username)
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	instance := &redhatcopv1alpha1.GroupSync{}
+
+	if err := h.Client.Get(req.Context(), key, instance); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("GroupSync '%s' not found", key), http.StatusNotFound)
+			return
+		}
+		logger.Error(err, "Failed to Retrieve GroupSync")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := RequestSync(req.Context(), h.Client, instance); err != nil {
+		logger.Error(err, "Failed to Request Synchronization")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("Synchronization Requested", "User", userInfo.Username)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// authenticate validates the bearer token of the request and returns the user it belongs to
+func (h *Handler) authenticate(context context.Context, req *http.Request) (*authenticationv1.UserInfo, error) {
+
+	authorization := req.Header.Get("Authorization")
+
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return nil, fmt.Errorf("Bearer token not provided")
+	}
+
+	tokenReview := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer ")),
+		},
+	}
+
+	if err := h.Client.Create(context, tokenReview); err != nil {
+		return nil, err
+	}
+
+	if !tokenReview.Status.Authenticated {
+		return nil, fmt.Errorf("Invalid bearer token: %s", tokenReview.Status.Error)
+	}
+
+	return &tokenReview.Status.User, nil
+}
+
+// authorize determines whether the user is allowed to update the GroupSync
+func (h *Handler) authorize(context context.Context, userInfo *authenticationv1.UserInfo, key types.NamespacedName) (bool, error) {
+
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range userInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	subjectAccessReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			UID:    userInfo.UID,
+			Groups: userInfo.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: key.Namespace,
+				Name:      key.Name,
+				Verb:      "update",
+				Group:     redhatcopv1alpha1.GroupVersion.Group,
+				Resource:  "groupsyncs",
+			},
+		},
+	}
+
+	if err := h.Client.Create(context, subjectAccessReview); err != nil {
+		return false, err
+	}
+
+	return subjectAccessReview.Status.Allowed, nil
+}
+
+// getGroupSyncKey parses the namespace and name of the GroupSync from the path of the request
+func getGroupSyncKey(path string) (types.NamespacedName, error) {
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, Path), "/"), "/")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return types.NamespacedName{}, fmt.Errorf("Invalid path '%s', expected %s{namespace}/{name}", path, Path)
+	}
+
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+}
+
+// RequestSync annotates the GroupSync to request an immediate synchronization. The current time is used as the value of the annotation
+// so that a synchronization is requested even when a previous request has not been cleared yet
+func RequestSync(context context.Context, c client.Client, instance *redhatcopv1alpha1.GroupSync) error {
+
+	patch := client.MergeFrom(instance.DeepCopy())
+
+	annotations := instance.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constants.SyncNow] = time.Now().UTC().Format(time.RFC3339Nano)
+	instance.SetAnnotations(annotations)
+
+	return c.Patch(context, instance, patch)
+}